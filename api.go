@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TenableAPI is the subset of the Tenable client that the resources
+// and data sources depend on.  Keeping them behind an interface lets
+// their CRUD logic be unit tested against an in-memory fake instead of
+// an HTTP server.  *tenable.Client is the production implementation.
+type TenableAPI interface {
+	CreateUser(ctx context.Context, username, password string, permissions int, name, email, accountType string, enabled bool) (*tenable.User, error)
+	GetUser(ctx context.Context, id int) (*tenable.User, error)
+	CurrentUser(ctx context.Context) (*tenable.User, error)
+	GetUsersByIDs(ctx context.Context, ids []int) ([]*tenable.User, error)
+	UpdateUser(ctx context.Context, id int, permissions *int, name, email *string, enabled *bool) (*tenable.User, error)
+	DeleteUser(ctx context.Context, id int) error
+	ChangeUserPassword(ctx context.Context, id int, password string) error
+	SetUserForcePasswordChange(ctx context.Context, id int, force bool) error
+	GenerateAPIKeys(ctx context.Context, id int) (*tenable.APIKeys, error)
+	GetUserAuthorizations(ctx context.Context, id int) (*tenable.UserAuthorizations, error)
+	UpdateUserAuthorizations(ctx context.Context, id int, auth tenable.UserAuthorizations) error
+	ListUsers(ctx context.Context) ([]*tenable.User, error)
+	ListRoles(ctx context.Context) ([]*tenable.Role, error)
+	GetRole(ctx context.Context, id int) (*tenable.Role, error)
+	CreateRole(ctx context.Context, name, description string, privileges []string) (*tenable.Role, error)
+	UpdateRole(ctx context.Context, id int, name, description string, privileges []string) (*tenable.Role, error)
+	DeleteRole(ctx context.Context, id int) error
+	ListGroups(ctx context.Context) ([]*tenable.Group, error)
+	GetGroup(ctx context.Context, id int) (*tenable.Group, error)
+	CreateGroup(ctx context.Context, name string) (*tenable.Group, error)
+	RenameGroup(ctx context.Context, id int, name string) (*tenable.Group, error)
+	DeleteGroup(ctx context.Context, id int) error
+	ListGroupUsers(ctx context.Context, groupID int) ([]*tenable.User, error)
+	AddGroupUser(ctx context.Context, groupID, userID int) error
+	RemoveGroupUser(ctx context.Context, groupID, userID int) error
+	GetTagCategory(ctx context.Context, uuid string) (*tenable.TagCategory, error)
+	CreateTagCategory(ctx context.Context, name, description string) (*tenable.TagCategory, error)
+	UpdateTagCategory(ctx context.Context, uuid, name, description string) (*tenable.TagCategory, error)
+	DeleteTagCategory(ctx context.Context, uuid string) error
+	GetTagValue(ctx context.Context, uuid string) (*tenable.TagValue, error)
+	CreateTagValue(ctx context.Context, categoryUUID, value, description string, filters *tenable.TagFilter) (*tenable.TagValue, error)
+	UpdateTagValue(ctx context.Context, uuid, value, description string, filters *tenable.TagFilter) (*tenable.TagValue, error)
+	DeleteTagValue(ctx context.Context, uuid string) error
+	GetPermission(ctx context.Context, uuid string) (*tenable.Permission, error)
+	CreatePermission(ctx context.Context, p tenable.Permission) (string, error)
+	UpdatePermission(ctx context.Context, uuid string, p tenable.Permission) error
+	DeletePermission(ctx context.Context, uuid string) error
+	ListScanners(ctx context.Context) ([]*tenable.Scanner, error)
+	ListNetworkScanners(ctx context.Context, networkUUID string) ([]*tenable.Scanner, error)
+	AssignNetworkScanner(ctx context.Context, networkUUID, scannerUUID string) error
+	ListAgents(ctx context.Context) ([]*tenable.Agent, error)
+	GetAgent(ctx context.Context, id int) (*tenable.Agent, error)
+	RenameAgent(ctx context.Context, id int, name string) error
+	UnlinkAgent(ctx context.Context, id int) error
+	ListAgentGroups(ctx context.Context) ([]*tenable.AgentGroup, error)
+	ListAgentGroupAgents(ctx context.Context, groupID int) ([]*tenable.Agent, error)
+	AddAgentsToGroup(ctx context.Context, groupID int, agentIDs []int) error
+	RemoveAgentsFromGroup(ctx context.Context, groupID int, agentIDs []int) error
+	ListScanTemplates(ctx context.Context, templateType string) ([]*tenable.ScanTemplate, error)
+	GetScan(ctx context.Context, id int) (*tenable.Scan, error)
+	CreateScan(ctx context.Context, cfg tenable.ScanConfig) (int, error)
+	UpdateScan(ctx context.Context, id int, cfg tenable.ScanConfig) error
+	DeleteScan(ctx context.Context, id int) error
+	ListScanCredentials(ctx context.Context, scanID int) ([]tenable.ScanCredential, error)
+	GetPolicy(ctx context.Context, id int) (*tenable.Policy, error)
+	CreatePolicy(ctx context.Context, cfg tenable.PolicyConfig) (int, error)
+	UpdatePolicy(ctx context.Context, id int, cfg tenable.PolicyConfig) error
+	DeletePolicy(ctx context.Context, id int) error
+	GetManagedCredential(ctx context.Context, uuid string) (*tenable.ManagedCredential, error)
+	CreateManagedCredential(ctx context.Context, cfg tenable.ManagedCredentialConfig) (string, error)
+	UpdateManagedCredential(ctx context.Context, uuid string, cfg tenable.ManagedCredentialConfig) error
+	DeleteManagedCredential(ctx context.Context, uuid string) error
+	Ping(ctx context.Context) (*tenable.ServerStatus, error)
+}
+
+var _ TenableAPI = (*tenable.Client)(nil)
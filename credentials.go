@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultProfile is the shared credentials profile used when neither
+// the profile attribute nor TENABLE_PROFILE is set.
+const defaultProfile = "default"
+
+// sharedCredentials holds the API keys of one profile in the shared
+// credentials file.
+type sharedCredentials struct {
+	AccessKey string
+	SecretKey string
+}
+
+// defaultSharedCredentialsFile returns ~/.tenable/credentials, or an
+// empty string when the home directory cannot be determined.
+func defaultSharedCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".tenable", "credentials")
+}
+
+// loadSharedCredentials reads the named profile from an INI-style
+// shared credentials file such as:
+//
+//	[default]
+//	access_key = ...
+//	secret_key = ...
+//
+//	[production]
+//	access_key = ...
+//	secret_key = ...
+//
+// Blank lines and lines starting with # or ; are ignored.  Keys
+// outside of a section and unknown keys are ignored as well, so the
+// file can carry settings for other tools.
+func loadSharedCredentials(filename, profile string) (*sharedCredentials, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var creds *sharedCredentials
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: malformed section header %q", filename, lineNo, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == profile && creds == nil {
+				creds = &sharedCredentials{}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value, got %q", filename, lineNo, line)
+		}
+		if section != profile {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "access_key":
+			creds.AccessKey = strings.TrimSpace(value)
+		case "secret_key":
+			creds.SecretKey = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, filename)
+	}
+	return creds, nil
+}
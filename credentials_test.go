@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadSharedCredentials verifies that profiles are read from an
+// INI-style file and that a missing profile is reported.
+func TestLoadSharedCredentials(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	content := `# Tenable API keys
+[default]
+access_key = default-access
+secret_key = default-secret
+
+[production]
+access_key=prod-access
+; comment inside a section
+secret_key = prod-secret
+region = eu
+`
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+
+	creds, err := loadSharedCredentials(filename, "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.AccessKey != "prod-access" || creds.SecretKey != "prod-secret" {
+		t.Errorf("unexpected credentials: %+v", creds)
+	}
+
+	creds, err = loadSharedCredentials(filename, defaultProfile)
+	if err != nil || creds.AccessKey != "default-access" {
+		t.Errorf("default profile = %+v, %v", creds, err)
+	}
+
+	if _, err := loadSharedCredentials(filename, "staging"); err == nil {
+		t.Errorf("expected error for missing profile")
+	}
+}
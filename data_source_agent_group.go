@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	// Structured logging
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// agentGroupDataSource implements a data source that retrieves a single
+// Tenable VM agent group by ID or name.  This lets membership
+// resources reference agent groups that are created and owned by
+// other teams.  The data source calls ListAgentGroups and filters the
+// results.  Either `id` or `name` must be specified; if both are
+// provided, `id` takes precedence.  A name that matches more than one
+// group is reported as an error rather than picking one.
+type agentGroupDataSource struct {
+	client TenableAPI
+}
+
+// agentGroupDataSourceModel defines the state structure for the agent
+// group data source.  The id and name attributes are also optional
+// inputs for filtering.
+type agentGroupDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	UUID        types.String `tfsdk:"uuid"`
+	AgentsCount types.Int64  `tfsdk:"agents_count"`
+}
+
+// NewAgentGroupDataSource returns a new agent group data source.
+func NewAgentGroupDataSource() datasource.DataSource {
+	return &agentGroupDataSource{}
+}
+
+// Metadata sets the data source type name to `tenablevm_agent_group`.
+func (d *agentGroupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_group"
+}
+
+// Schema defines the input and output attributes for the agent group
+// data source.  The id and name attributes are optional filters used
+// to select a single agent group.
+func (d *agentGroupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Numeric identifier of the agent group. If set, this value is used to locate the agent group.",
+				MarkdownDescription: "Numeric identifier of the agent group. If set, this value is used to locate the agent group.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Name of the agent group, matched case-insensitively. Used to locate the agent group when id is not provided; a name matching more than one group is an error.",
+				MarkdownDescription: "Name of the agent group, matched case-insensitively. Used to locate the agent group when id is not provided; a name matching more than one group is an error.",
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the agent group.",
+				MarkdownDescription: "UUID of the agent group.",
+			},
+			"agents_count": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "Number of agents in the agent group.",
+				MarkdownDescription: "Number of agents in the agent group.",
+			},
+		},
+		Description:         "Retrieves a Tenable VM agent group by ID or name.",
+		MarkdownDescription: "Retrieves a Tenable VM agent group by ID or name.",
+	}
+}
+
+// Configure stores the API client on the data source.
+func (d *agentGroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_agent_group data source is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+// Read executes the lookup for an agent group by ID or name.  It
+// calls ListAgentGroups and filters the results.
+func (d *agentGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		return
+	}
+	// Log debug
+	tflog.Debug(ctx, "Reading Tenable VM agent group data source")
+	var config agentGroupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var group *tenable.AgentGroup
+	if !config.ID.IsNull() && !config.ID.IsUnknown() && config.ID.ValueString() != "" {
+		idStr := config.ID.ValueString()
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Invalid Agent Group ID",
+				"The id attribute must be a numeric string.",
+			)
+			return
+		}
+		groups, err := d.client.ListAgentGroups(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing Tenable VM agent groups",
+				errorDetail(err),
+			)
+			return
+		}
+		for _, g := range groups {
+			if g.ID == id {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			resp.Diagnostics.AddError(
+				"Agent Group Not Found",
+				"No Tenable VM agent group was found with id "+idStr+".",
+			)
+			return
+		}
+	} else if !config.Name.IsNull() && !config.Name.IsUnknown() && config.Name.ValueString() != "" {
+		name := config.Name.ValueString()
+		groups, err := d.client.ListAgentGroups(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing Tenable VM agent groups",
+				errorDetail(err),
+			)
+			return
+		}
+		var matches []*tenable.AgentGroup
+		for _, g := range groups {
+			if strings.EqualFold(g.Name, name) {
+				matches = append(matches, g)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddError(
+				"Agent Group Not Found",
+				"No Tenable VM agent group was found with name "+name+".",
+			)
+			return
+		case 1:
+		default:
+			ids := make([]string, 0, len(matches))
+			for _, g := range matches {
+				ids = append(ids, strconv.Itoa(g.ID))
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Ambiguous Agent Group",
+				fmt.Sprintf("%d Tenable VM agent groups match name %q (IDs: %s). Use the id attribute instead.", len(matches), name, strings.Join(ids, ", ")),
+			)
+			return
+		}
+		group = matches[0]
+	} else {
+		resp.Diagnostics.AddError(
+			"Missing Search Parameter",
+			"Either the id or name attribute must be set to look up a Tenable VM agent group.",
+		)
+		return
+	}
+	var state agentGroupDataSourceModel
+	state.ID = types.StringValue(strconv.Itoa(group.ID))
+	state.Name = types.StringValue(group.Name)
+	state.UUID = types.StringValue(group.UUID)
+	state.AgentsCount = types.Int64Value(int64(group.AgentsCount))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	// Log info message
+	tflog.Info(ctx, "Read Tenable VM agent group data source", map[string]any{
+		"agent_group_id": state.ID.ValueString(),
+		"name":           state.Name.ValueString(),
+	})
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAgentGroupDataSourceReadByName(t *testing.T) {
+	ctx := context.Background()
+
+	sample := map[string]interface{}{
+		"groups": []map[string]interface{}{
+			{"id": 100, "uuid": "agent-group-uuid1", "name": "Linux Servers", "agents_count": 12},
+			{"id": 101, "uuid": "agent-group-uuid2", "name": "Windows Servers", "agents_count": 3},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scanners/null/agent-groups" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+
+	ds := &agentGroupDataSource{client: newTestClient(ts)}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	nameVal, _ := types.StringValue("windows servers").ToTerraformValue(ctx)
+	req := datasource.ReadRequest{Config: buildConfig(ctx, schResp.Schema, map[string]tftypes.Value{"name": nameVal})}
+	resp := datasource.ReadResponse{State: emptyState(ctx, schResp.Schema)}
+
+	ds.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state agentGroupDataSourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if state.ID.ValueString() != "101" || state.UUID.ValueString() != "agent-group-uuid2" || state.AgentsCount.ValueInt64() != 3 {
+		t.Errorf("unexpected state: %+v", state)
+	}
+}
+
+func TestAgentGroupDataSourceReadByNameAmbiguous(t *testing.T) {
+	ctx := context.Background()
+
+	sample := map[string]interface{}{
+		"groups": []map[string]interface{}{
+			{"id": 100, "uuid": "agent-group-uuid1", "name": "Linux Servers", "agents_count": 12},
+			{"id": 102, "uuid": "agent-group-uuid3", "name": "linux servers", "agents_count": 1},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+
+	ds := &agentGroupDataSource{client: newTestClient(ts)}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	nameVal, _ := types.StringValue("Linux Servers").ToTerraformValue(ctx)
+	req := datasource.ReadRequest{Config: buildConfig(ctx, schResp.Schema, map[string]tftypes.Value{"name": nameVal})}
+	resp := datasource.ReadResponse{State: emptyState(ctx, schResp.Schema)}
+
+	ds.Read(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an error for an ambiguous name")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "100, 102") {
+		t.Errorf("expected matching IDs in detail, got %q", detail)
+	}
+}
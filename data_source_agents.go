@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	// Structured logging
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// pluginFeedIDLayout is the time layout of agent plugin_feed_id values,
+// which encode the feed release time as YYYYMMDDHHMM.
+const pluginFeedIDLayout = "200601021504"
+
+// agentsDataSource implements a data source that lists Tenable VM
+// agents with optional health filters.  The filters are applied on the
+// provider side after listing all agents, so stale-agent cleanup
+// automation can be driven directly from Terraform outputs.
+type agentsDataSource struct {
+	client TenableAPI
+}
+
+// agentsDataSourceModel defines the state structure for the agents data
+// source.  The filter attributes are optional inputs; agents is
+// computed.
+type agentsDataSourceModel struct {
+	LastConnectOlderThanDays types.Int64  `tfsdk:"last_connect_older_than_days"`
+	CoreVersion              types.String `tfsdk:"core_version"`
+	PluginFeedOlderThanDays  types.Int64  `tfsdk:"plugin_feed_older_than_days"`
+	Agents                   []agentModel `tfsdk:"agents"`
+}
+
+// agentModel describes a single agent entry in the agents list.
+type agentModel struct {
+	ID           types.String `tfsdk:"id"`
+	UUID         types.String `tfsdk:"uuid"`
+	Name         types.String `tfsdk:"name"`
+	Platform     types.String `tfsdk:"platform"`
+	Distro       types.String `tfsdk:"distro"`
+	IP           types.String `tfsdk:"ip"`
+	Status       types.String `tfsdk:"status"`
+	CoreVersion  types.String `tfsdk:"core_version"`
+	PluginFeedID types.String `tfsdk:"plugin_feed_id"`
+	LastConnect  types.Int64  `tfsdk:"last_connect"`
+	LastScanned  types.Int64  `tfsdk:"last_scanned"`
+}
+
+// NewAgentsDataSource returns a new agents data source.
+func NewAgentsDataSource() datasource.DataSource {
+	return &agentsDataSource{}
+}
+
+// Metadata sets the data source type name to `tenablevm_agents`.
+func (d *agentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agents"
+}
+
+// Schema defines the optional health filters and the computed agents
+// list.
+func (d *agentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"last_connect_older_than_days": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "Only return agents whose last connection is older than this many days.",
+				MarkdownDescription: "Only return agents whose last connection is older than this many days.",
+			},
+			"core_version": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return agents running this core version. A partial version such as 10.4 matches every 10.4.x release.",
+				MarkdownDescription: "Only return agents running this core version. A partial version such as `10.4` matches every `10.4.x` release.",
+			},
+			"plugin_feed_older_than_days": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "Only return agents whose plugin feed is older than this many days. Agents that have never reported a plugin feed are treated as stale.",
+				MarkdownDescription: "Only return agents whose plugin feed is older than this many days. Agents that have never reported a plugin feed are treated as stale.",
+			},
+			"agents": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "Agents matching all of the configured filters.",
+				MarkdownDescription: "Agents matching all of the configured filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Numeric identifier of the agent.",
+							MarkdownDescription: "Numeric identifier of the agent.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							Description:         "UUID of the agent.",
+							MarkdownDescription: "UUID of the agent.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Name of the agent.",
+							MarkdownDescription: "Name of the agent.",
+						},
+						"platform": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Platform of the agent host (e.g. LINUX, WINDOWS).",
+							MarkdownDescription: "Platform of the agent host (e.g. `LINUX`, `WINDOWS`).",
+						},
+						"distro": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Operating system distribution of the agent host.",
+							MarkdownDescription: "Operating system distribution of the agent host.",
+						},
+						"ip": schema.StringAttribute{
+							Computed:            true,
+							Description:         "IP address of the agent host.",
+							MarkdownDescription: "IP address of the agent host.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Connection status of the agent (e.g. on, off, init).",
+							MarkdownDescription: "Connection status of the agent (e.g. `on`, `off`, `init`).",
+						},
+						"core_version": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Core version of the agent software.",
+							MarkdownDescription: "Core version of the agent software.",
+						},
+						"plugin_feed_id": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Plugin feed loaded on the agent, in YYYYMMDDHHMM form.",
+							MarkdownDescription: "Plugin feed loaded on the agent, in `YYYYMMDDHHMM` form.",
+						},
+						"last_connect": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "Unix timestamp of the agent's last connection.",
+							MarkdownDescription: "Unix timestamp of the agent's last connection.",
+						},
+						"last_scanned": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "Unix timestamp of the agent's last scan.",
+							MarkdownDescription: "Unix timestamp of the agent's last scan.",
+						},
+					},
+				},
+			},
+		},
+		Description:         "Lists Tenable VM agents, optionally filtered by connection age, core version and plugin feed staleness.",
+		MarkdownDescription: "Lists Tenable VM agents, optionally filtered by connection age, core version and plugin feed staleness.",
+	}
+}
+
+// Configure stores the API client on the data source.
+func (d *agentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_agents data source is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+// Read lists the agents and keeps those matching every configured
+// filter.  Filters that are not set are ignored.
+func (d *agentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		return
+	}
+	// Log debug
+	tflog.Debug(ctx, "Reading Tenable VM agents data source")
+	var config agentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.LastConnectOlderThanDays.IsNull() && config.LastConnectOlderThanDays.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("last_connect_older_than_days"),
+			"Invalid Filter Value",
+			"The last_connect_older_than_days attribute must not be negative.",
+		)
+	}
+	if !config.PluginFeedOlderThanDays.IsNull() && config.PluginFeedOlderThanDays.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("plugin_feed_older_than_days"),
+			"Invalid Filter Value",
+			"The plugin_feed_older_than_days attribute must not be negative.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	agents, err := d.client.ListAgents(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing Tenable VM agents",
+			errorDetail(err),
+		)
+		return
+	}
+
+	now := time.Now()
+	state := config
+	state.Agents = make([]agentModel, 0, len(agents))
+	for _, a := range agents {
+		if !config.LastConnectOlderThanDays.IsNull() {
+			cutoff := now.AddDate(0, 0, -int(config.LastConnectOlderThanDays.ValueInt64()))
+			if !time.Unix(a.LastConnect, 0).Before(cutoff) {
+				continue
+			}
+		}
+		if !config.CoreVersion.IsNull() {
+			want := config.CoreVersion.ValueString()
+			if a.CoreVersion != want && !strings.HasPrefix(a.CoreVersion, want+".") {
+				continue
+			}
+		}
+		if !config.PluginFeedOlderThanDays.IsNull() {
+			cutoff := now.AddDate(0, 0, -int(config.PluginFeedOlderThanDays.ValueInt64()))
+			// An unparsable or missing feed ID means the agent never
+			// loaded plugins, which is as stale as it gets.
+			if feed, err := time.Parse(pluginFeedIDLayout, a.PluginFeedID); err == nil && !feed.Before(cutoff) {
+				continue
+			}
+		}
+		state.Agents = append(state.Agents, agentModel{
+			ID:           types.StringValue(strconv.Itoa(a.ID)),
+			UUID:         stringValueOrNull(a.UUID),
+			Name:         types.StringValue(a.Name),
+			Platform:     stringValueOrNull(a.Platform),
+			Distro:       stringValueOrNull(a.Distro),
+			IP:           stringValueOrNull(a.IP),
+			Status:       stringValueOrNull(a.Status),
+			CoreVersion:  stringValueOrNull(a.CoreVersion),
+			PluginFeedID: stringValueOrNull(a.PluginFeedID),
+			LastConnect:  types.Int64Value(a.LastConnect),
+			LastScanned:  types.Int64Value(a.LastScanned),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	// Log info message
+	tflog.Info(ctx, "Read Tenable VM agents data source", map[string]any{
+		"total":   len(agents),
+		"matched": len(state.Agents),
+	})
+}
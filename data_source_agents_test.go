@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAgentsDataSourceReadFilters(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now()
+	old := now.AddDate(0, 0, -60)
+	sample := map[string]interface{}{
+		"agents": []map[string]interface{}{
+			{"id": 1, "name": "fresh", "core_version": "10.4.2", "last_connect": now.Unix(), "plugin_feed_id": now.UTC().Format(pluginFeedIDLayout)},
+			{"id": 2, "name": "stale", "core_version": "10.4.1", "last_connect": old.Unix(), "plugin_feed_id": old.UTC().Format(pluginFeedIDLayout)},
+			{"id": 3, "name": "stale-old-core", "core_version": "8.3.0", "last_connect": old.Unix()},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scanners/null/agents" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+
+	ds := &agentsDataSource{client: newTestClient(ts)}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	req := datasource.ReadRequest{Config: buildConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"last_connect_older_than_days": tftypes.NewValue(tftypes.Number, 30),
+		"core_version":                 tftypes.NewValue(tftypes.String, "10.4"),
+		"plugin_feed_older_than_days":  tftypes.NewValue(tftypes.Number, 7),
+	})}
+	resp := datasource.ReadResponse{State: emptyState(ctx, schResp.Schema)}
+
+	ds.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state agentsDataSourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if len(state.Agents) != 1 || state.Agents[0].Name.ValueString() != "stale" {
+		t.Errorf("unexpected agents: %+v", state.Agents)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	// Structured logging
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
 )
 
 // groupDataSource implements a data source that retrieves a single Tenable VM
@@ -20,7 +22,7 @@ import (
 // `id` or `name` must be specified; if both are provided, `id` takes
 // precedence.
 type groupDataSource struct {
-	client *Client
+	client TenableAPI
 }
 
 // groupDataSourceModel defines the state structure for the group data
@@ -31,6 +33,7 @@ type groupDataSourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	UUID        types.String `tfsdk:"uuid"`
 	Description types.String `tfsdk:"description"`
+	RawJSON     types.String `tfsdk:"raw_json"`
 }
 
 // NewGroupDataSource returns a new group data source.
@@ -71,6 +74,7 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description:         "Description of the group.",
 				MarkdownDescription: "Description of the group.",
 			},
+			"raw_json": rawJSONAttribute("group"),
 		},
 		Description:         "Retrieves a Tenable VM group by ID or name.",
 		MarkdownDescription: "Retrieves a Tenable VM group by ID or name.",
@@ -82,15 +86,15 @@ func (d *groupDataSource) Configure(_ context.Context, req datasource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data Type",
-			"The provider data supplied to the tenablevm_group data source is not a *Client. This is a bug in the provider implementation.",
+			"The provider data supplied to the tenablevm_group data source is not a *providerData. This is a bug in the provider implementation.",
 		)
 		return
 	}
-	d.client = c
+	d.client = data.Client
 }
 
 // Read executes the lookup for a group by ID or name.  It calls
@@ -108,7 +112,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var group *Group
+	var group *tenable.Group
 	if !config.ID.IsNull() && !config.ID.IsUnknown() && config.ID.ValueString() != "" {
 		idStr := config.ID.ValueString()
 		id, err := strconv.Atoi(idStr)
@@ -120,11 +124,11 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			)
 			return
 		}
-		groups, err := d.client.ListGroups()
+		groups, err := d.client.ListGroups(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error listing Tenable VM groups",
-				err.Error(),
+				errorDetail(err),
 			)
 			return
 		}
@@ -143,11 +147,11 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		}
 	} else if !config.Name.IsNull() && !config.Name.IsUnknown() && config.Name.ValueString() != "" {
 		name := config.Name.ValueString()
-		groups, err := d.client.ListGroups()
+		groups, err := d.client.ListGroups(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error listing Tenable VM groups",
-				err.Error(),
+				errorDetail(err),
 			)
 			return
 		}
@@ -180,6 +184,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	} else {
 		state.Description = types.StringNull()
 	}
+	state.RawJSON = rawJSONValue(group.RawJSON)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	// Log info message
 	tflog.Info(ctx, "Read Tenable VM group data source", map[string]any{
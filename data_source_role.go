@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	// Structured logging
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
 )
 
 // roleDataSource implements a data source that retrieves a single Tenable VM
@@ -21,7 +23,7 @@ import (
 // `name` must be specified; if both are provided, `id` takes
 // precedence.
 type roleDataSource struct {
-	client *Client
+	client TenableAPI
 }
 
 // roleDataSourceModel defines the state structure for the role data
@@ -32,6 +34,7 @@ type roleDataSourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	UUID        types.String `tfsdk:"uuid"`
 	Description types.String `tfsdk:"description"`
+	RawJSON     types.String `tfsdk:"raw_json"`
 }
 
 // NewRoleDataSource returns a new role data source.  The provider
@@ -74,6 +77,7 @@ func (d *roleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description:         "Description of the role.",
 				MarkdownDescription: "Description of the role.",
 			},
+			"raw_json": rawJSONAttribute("role"),
 		},
 		Description:         "Retrieves a Tenable VM role by ID or name.",
 		MarkdownDescription: "Retrieves a Tenable VM role by ID or name.",
@@ -85,15 +89,15 @@ func (d *roleDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data Type",
-			"The provider data supplied to the tenablevm_role data source is not a *Client. This is a bug in the provider implementation.",
+			"The provider data supplied to the tenablevm_role data source is not a *providerData. This is a bug in the provider implementation.",
 		)
 		return
 	}
-	d.client = c
+	d.client = data.Client
 }
 
 // Read executes the lookup for a role by ID or name.  It calls
@@ -113,7 +117,7 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 	// Determine search criteria: id takes precedence over name
-	var role *Role
+	var role *tenable.Role
 	if !config.ID.IsNull() && !config.ID.IsUnknown() && config.ID.ValueString() != "" {
 		// parse ID string to int
 		idStr := config.ID.ValueString()
@@ -127,11 +131,11 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 			return
 		}
 		// call ListRoles and find by ID
-		roles, err := d.client.ListRoles()
+		roles, err := d.client.ListRoles(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error listing Tenable VM roles",
-				err.Error(),
+				errorDetail(err),
 			)
 			return
 		}
@@ -150,11 +154,11 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		}
 	} else if !config.Name.IsNull() && !config.Name.IsUnknown() && config.Name.ValueString() != "" {
 		name := config.Name.ValueString()
-		roles, err := d.client.ListRoles()
+		roles, err := d.client.ListRoles(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error listing Tenable VM roles",
-				err.Error(),
+				errorDetail(err),
 			)
 			return
 		}
@@ -188,6 +192,7 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	} else {
 		state.Description = types.StringNull()
 	}
+	state.RawJSON = rawJSONValue(role.RawJSON)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	// Log info message with found role
 	tflog.Info(ctx, "Read Tenable VM role data source", map[string]any{
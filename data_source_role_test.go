@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+func TestRoleDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	fake.roles = []*tenable.Role{
+		{ID: 1, UUID: "uuid-1", Name: "Auditor"},
+		{ID: 2, UUID: "uuid-2", Name: "Scan Operator", Description: "Runs scans", RawJSON: []byte(`{"id":2,"privileges":["scan.launch"]}`)},
+	}
+	ds := &roleDataSource{client: fake}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	for attr, value := range map[string]string{"id": "2", "name": "scan operator"} {
+		req := datasource.ReadRequest{Config: buildUserConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+			attr: tftypes.NewValue(tftypes.String, value),
+		})}
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+		ds.Read(ctx, req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", attr, resp.Diagnostics)
+		}
+		var state roleDataSourceModel
+		resp.State.Get(ctx, &state)
+		if state.UUID.ValueString() != "uuid-2" || state.Description.ValueString() != "Runs scans" || state.RawJSON.ValueString() != `{"id":2,"privileges":["scan.launch"]}` {
+			t.Errorf("%s: unexpected state: %+v", attr, state)
+		}
+	}
+
+	req := datasource.ReadRequest{Config: buildUserConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Administrator"),
+	})}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	ds.Read(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected a not found diagnostic")
+	}
+}
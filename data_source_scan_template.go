@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	// Structured logging
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// scanTemplateDataSource implements a data source that resolves a
+// single editor template to its UUID, so scan and policy resources can
+// reference templates by the title shown in the Tenable UI.  Either
+// `title` or `name` must be specified.  The lookup only succeeds when
+// exactly one template matches; an ambiguous match is reported as an
+// error rather than silently picking one.
+type scanTemplateDataSource struct {
+	client TenableAPI
+}
+
+// scanTemplateDataSourceModel defines the state structure for the scan
+// template data source.  The type, title and name attributes are
+// also optional inputs for filtering.
+type scanTemplateDataSourceModel struct {
+	Type        types.String `tfsdk:"type"`
+	Title       types.String `tfsdk:"title"`
+	Name        types.String `tfsdk:"name"`
+	UUID        types.String `tfsdk:"uuid"`
+	Description types.String `tfsdk:"description"`
+	IsAgent     types.Bool   `tfsdk:"is_agent"`
+}
+
+// NewScanTemplateDataSource returns a new scan template data source.
+func NewScanTemplateDataSource() datasource.DataSource {
+	return &scanTemplateDataSource{}
+}
+
+// Metadata sets the data source type name to `tenablevm_scan_template`.
+func (d *scanTemplateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan_template"
+}
+
+// Schema defines the input and output attributes for the scan template
+// data source.
+func (d *scanTemplateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Template type to search: scan or policy. Defaults to scan.",
+				MarkdownDescription: "Template type to search: `scan` or `policy`. Defaults to `scan`.",
+			},
+			"title": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Title of the template as shown in the Tenable UI (e.g. Advanced Network Scan). Matching is case-insensitive.",
+				MarkdownDescription: "Title of the template as shown in the Tenable UI (e.g. `Advanced Network Scan`). Matching is case-insensitive.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Short name of the template (e.g. advanced). Used when title is not provided.",
+				MarkdownDescription: "Short name of the template (e.g. `advanced`). Used when title is not provided.",
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the template.",
+				MarkdownDescription: "UUID of the template.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Description of the template.",
+				MarkdownDescription: "Description of the template.",
+			},
+			"is_agent": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether the template is used for agent scans.",
+				MarkdownDescription: "Whether the template is used for agent scans.",
+			},
+		},
+		Description:         "Resolves a single Tenable VM scan or policy template by title or name.",
+		MarkdownDescription: "Resolves a single Tenable VM scan or policy template by title or name.",
+	}
+}
+
+// Configure stores the API client on the data source.
+func (d *scanTemplateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_scan_template data source is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+// Read lists the templates of the requested type and resolves the
+// single template matching the title or name.  Zero or multiple
+// matches produce an error diagnostic.
+func (d *scanTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		return
+	}
+	// Log debug
+	tflog.Debug(ctx, "Reading Tenable VM scan template data source")
+	var config scanTemplateDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	templateType := "scan"
+	if !config.Type.IsNull() && !config.Type.IsUnknown() {
+		templateType = config.Type.ValueString()
+	}
+	if templateType != "scan" && templateType != "policy" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid Template Type",
+			"The type attribute must be either \"scan\" or \"policy\".",
+		)
+		return
+	}
+
+	var attr, want string
+	var match func(*tenable.ScanTemplate) bool
+	if !config.Title.IsNull() && !config.Title.IsUnknown() && config.Title.ValueString() != "" {
+		attr, want = "title", config.Title.ValueString()
+		match = func(t *tenable.ScanTemplate) bool { return strings.EqualFold(t.Title, want) }
+	} else if !config.Name.IsNull() && !config.Name.IsUnknown() && config.Name.ValueString() != "" {
+		attr, want = "name", config.Name.ValueString()
+		match = func(t *tenable.ScanTemplate) bool { return strings.EqualFold(t.Name, want) }
+	} else {
+		resp.Diagnostics.AddError(
+			"Missing Search Parameter",
+			"Either the title or name attribute must be set to look up a Tenable VM scan template.",
+		)
+		return
+	}
+
+	templates, err := d.client.ListScanTemplates(ctx, templateType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing Tenable VM scan templates",
+			errorDetail(err),
+		)
+		return
+	}
+	var matches []*tenable.ScanTemplate
+	for _, t := range templates {
+		if match(t) {
+			matches = append(matches, t)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attr),
+			"Scan Template Not Found",
+			fmt.Sprintf("No Tenable VM %s template was found with %s %q.", templateType, attr, want),
+		)
+		return
+	case 1:
+	default:
+		uuids := make([]string, 0, len(matches))
+		for _, t := range matches {
+			uuids = append(uuids, t.UUID)
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attr),
+			"Ambiguous Scan Template",
+			fmt.Sprintf("%d Tenable VM %s templates match %s %q (UUIDs: %s). Use a more specific value.", len(matches), templateType, attr, want, strings.Join(uuids, ", ")),
+		)
+		return
+	}
+
+	template := matches[0]
+	state := scanTemplateDataSourceModel{
+		Type:        types.StringValue(templateType),
+		Title:       types.StringValue(template.Title),
+		Name:        types.StringValue(template.Name),
+		UUID:        types.StringValue(template.UUID),
+		Description: stringValueOrNull(template.Description),
+		IsAgent:     types.BoolValue(template.IsAgent),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	// Log info message
+	tflog.Info(ctx, "Read Tenable VM scan template data source", map[string]any{
+		"uuid":  state.UUID.ValueString(),
+		"title": state.Title.ValueString(),
+	})
+}
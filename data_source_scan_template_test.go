@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func scanTemplateTestServer(t *testing.T) *httptest.Server {
+	sample := map[string]interface{}{
+		"templates": []map[string]interface{}{
+			{"uuid": "tmpl-basic", "name": "basic", "title": "Basic Network Scan", "desc": "A full system scan"},
+			{"uuid": "tmpl-advanced", "name": "advanced", "title": "Advanced Network Scan", "desc": "Configure a scan without using any recommendations."},
+			{"uuid": "tmpl-dup1", "name": "dup1", "title": "Duplicate"},
+			{"uuid": "tmpl-dup2", "name": "dup2", "title": "duplicate"},
+		},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/editor/scan/templates" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+}
+
+func TestScanTemplateDataSourceReadByTitle(t *testing.T) {
+	ctx := context.Background()
+	ts := scanTemplateTestServer(t)
+	defer ts.Close()
+
+	ds := &scanTemplateDataSource{client: newTestClient(ts)}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	req := datasource.ReadRequest{Config: buildConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"title": tftypes.NewValue(tftypes.String, "advanced network scan"),
+	})}
+	resp := datasource.ReadResponse{State: emptyState(ctx, schResp.Schema)}
+
+	ds.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var state scanTemplateDataSourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if state.UUID.ValueString() != "tmpl-advanced" || state.Type.ValueString() != "scan" || state.Name.ValueString() != "advanced" {
+		t.Errorf("unexpected state: %+v", state)
+	}
+}
+
+func TestScanTemplateDataSourceReadAmbiguous(t *testing.T) {
+	ctx := context.Background()
+	ts := scanTemplateTestServer(t)
+	defer ts.Close()
+
+	ds := &scanTemplateDataSource{client: newTestClient(ts)}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	req := datasource.ReadRequest{Config: buildConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"title": tftypes.NewValue(tftypes.String, "Duplicate"),
+	})}
+	resp := datasource.ReadResponse{State: emptyState(ctx, schResp.Schema)}
+
+	ds.Read(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected ambiguous match diagnostic")
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	// Structured logging
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// scannersDataSource implements a data source that lists the scanners
+// linked to the Tenable VM container together with their health
+// details.  Exposing status, last connection time, licence type,
+// plugin set and running scan counts lets configurations compute
+// capacity dashboards or route scans to the least busy scanner.
+type scannersDataSource struct {
+	client TenableAPI
+}
+
+// scannersDataSourceModel defines the state structure for the scanners
+// data source.
+type scannersDataSourceModel struct {
+	Scanners []scannerModel `tfsdk:"scanners"`
+}
+
+// scannerModel describes a single scanner entry in the scanners list.
+type scannerModel struct {
+	ID              types.String `tfsdk:"id"`
+	UUID            types.String `tfsdk:"uuid"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Status          types.String `tfsdk:"status"`
+	LastConnect     types.Int64  `tfsdk:"last_connect"`
+	LicenseType     types.String `tfsdk:"license_type"`
+	LoadedPluginSet types.String `tfsdk:"loaded_plugin_set"`
+	ScanCount       types.Int64  `tfsdk:"scan_count"`
+}
+
+// NewScannersDataSource returns a new scanners data source.
+func NewScannersDataSource() datasource.DataSource {
+	return &scannersDataSource{}
+}
+
+// Metadata sets the data source type name to `tenablevm_scanners`.
+func (d *scannersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scanners"
+}
+
+// Schema defines the computed scanners list and the health attributes
+// reported for each scanner.
+func (d *scannersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"scanners": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "Scanners linked to the Tenable VM container.",
+				MarkdownDescription: "Scanners linked to the Tenable VM container.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Numeric identifier of the scanner.",
+							MarkdownDescription: "Numeric identifier of the scanner.",
+						},
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							Description:         "UUID of the scanner.",
+							MarkdownDescription: "UUID of the scanner.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Name of the scanner.",
+							MarkdownDescription: "Name of the scanner.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Scanner type (e.g. local, managed, pool).",
+							MarkdownDescription: "Scanner type (e.g. `local`, `managed`, `pool`).",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Connection status of the scanner (e.g. on, off).",
+							MarkdownDescription: "Connection status of the scanner (e.g. `on`, `off`).",
+						},
+						"last_connect": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "Unix timestamp of the scanner's last connection to Tenable VM.",
+							MarkdownDescription: "Unix timestamp of the scanner's last connection to Tenable VM.",
+						},
+						"license_type": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Licence type reported by the scanner.",
+							MarkdownDescription: "Licence type reported by the scanner.",
+						},
+						"loaded_plugin_set": schema.StringAttribute{
+							Computed:            true,
+							Description:         "Plugin set currently loaded on the scanner.",
+							MarkdownDescription: "Plugin set currently loaded on the scanner.",
+						},
+						"scan_count": schema.Int64Attribute{
+							Computed:            true,
+							Description:         "Number of scans currently running on the scanner.",
+							MarkdownDescription: "Number of scans currently running on the scanner.",
+						},
+					},
+				},
+			},
+		},
+		Description:         "Lists Tenable VM scanners and their health details.",
+		MarkdownDescription: "Lists Tenable VM scanners and their health details.",
+	}
+}
+
+// Configure stores the API client on the data source.
+func (d *scannersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_scanners data source is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+// Read lists the scanners and populates the state with their health
+// details.  Empty string fields are stored as null.
+func (d *scannersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		return
+	}
+	// Log debug
+	tflog.Debug(ctx, "Reading Tenable VM scanners data source")
+	scanners, err := d.client.ListScanners(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing Tenable VM scanners",
+			errorDetail(err),
+		)
+		return
+	}
+	state := scannersDataSourceModel{Scanners: make([]scannerModel, 0, len(scanners))}
+	for _, s := range scanners {
+		state.Scanners = append(state.Scanners, scannerModel{
+			ID:              types.StringValue(strconv.Itoa(s.ID)),
+			UUID:            stringValueOrNull(s.UUID),
+			Name:            types.StringValue(s.Name),
+			Type:            stringValueOrNull(s.Type),
+			Status:          stringValueOrNull(s.Status),
+			LastConnect:     types.Int64Value(s.LastConnect),
+			LicenseType:     stringValueOrNull(s.LicenseType),
+			LoadedPluginSet: stringValueOrNull(s.LoadedPluginSet),
+			ScanCount:       types.Int64Value(int64(s.ScanCount)),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	// Log info message
+	tflog.Info(ctx, "Read Tenable VM scanners data source", map[string]any{
+		"count": len(state.Scanners),
+	})
+}
+
+// stringValueOrNull converts an API string into a framework value,
+// mapping the empty string to null so absent fields are not reported
+// as empty strings in state.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
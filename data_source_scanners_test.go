@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestScannersDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	sample := map[string]interface{}{
+		"scanners": []map[string]interface{}{
+			{
+				"id": 5, "uuid": "scanner-uuid1", "name": "dc1-scanner", "type": "managed",
+				"status": "on", "last_connect": 1700000000, "license": map[string]interface{}{"type": "vm"},
+				"loaded_plugin_set": "202401010000", "scan_count": 2,
+			},
+			{"id": 6, "uuid": "scanner-uuid2", "name": "dc2-scanner", "status": "off"},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scanners" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+
+	ds := &scannersDataSource{client: newTestClient(ts)}
+	var schResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schResp)
+
+	req := datasource.ReadRequest{Config: buildConfig(ctx, schResp.Schema, nil)}
+	resp := datasource.ReadResponse{State: emptyState(ctx, schResp.Schema)}
+
+	ds.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state scannersDataSourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if len(state.Scanners) != 2 {
+		t.Fatalf("got %d scanners, want 2", len(state.Scanners))
+	}
+	s := state.Scanners[0]
+	if s.ID.ValueString() != "5" || s.Status.ValueString() != "on" || s.LastConnect.ValueInt64() != 1700000000 ||
+		s.LicenseType.ValueString() != "vm" || s.LoadedPluginSet.ValueString() != "202401010000" || s.ScanCount.ValueInt64() != 2 {
+		t.Errorf("unexpected scanner: %+v", s)
+	}
+	if !state.Scanners[1].LicenseType.IsNull() {
+		t.Errorf("expected null license_type for second scanner, got %v", state.Scanners[1].LicenseType)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	// Structured logging for data source
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
 )
 
 // userDataSource implements a data source for retrieving information about
@@ -36,19 +38,23 @@ import (
 // provided, `id` takes precedence.  If neither is provided, the
 // data source will return an error.
 type userDataSource struct {
-	client *Client
+	client TenableAPI
 }
 
 // userDataSourceModel maps the data source schema into a Go struct.
 // Attributes that are not provided in the configuration are ignored
 // on input.  All attributes are computed on output.
 type userDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Username    types.String `tfsdk:"username"`
-	Name        types.String `tfsdk:"name"`
-	Email       types.String `tfsdk:"email"`
-	Permissions types.Int64  `tfsdk:"permissions"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
+	ID             types.String `tfsdk:"id"`
+	Username       types.String `tfsdk:"username"`
+	Name           types.String `tfsdk:"name"`
+	Email          types.String `tfsdk:"email"`
+	Permissions    types.Int64  `tfsdk:"permissions"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	LastLogin      types.String `tfsdk:"last_login"`
+	LoginFailCount types.Int64  `tfsdk:"login_fail_count"`
+	Lockout        types.Bool   `tfsdk:"lockout"`
+	RawJSON        types.String `tfsdk:"raw_json"`
 }
 
 // NewUserDataSource returns a new data source instance.  The provider
@@ -103,6 +109,22 @@ func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description:         "Whether the user account is enabled.",
 				MarkdownDescription: "Whether the user account is enabled.",
 			},
+			"last_login": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Time of the user's last successful login in RFC 3339 format, or null if the user never logged in.",
+				MarkdownDescription: "Time of the user's last successful login in RFC 3339 format, or null if the user never logged in.",
+			},
+			"login_fail_count": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "Number of failed logins since the last successful one.",
+				MarkdownDescription: "Number of failed logins since the last successful one.",
+			},
+			"lockout": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether the account is locked after too many failed logins.",
+				MarkdownDescription: "Whether the account is locked after too many failed logins.",
+			},
+			"raw_json": rawJSONAttribute("user"),
 		},
 		Description:         "Retrieves information about a Tenable VM user by ID or username.",
 		MarkdownDescription: "Retrieves information about a Tenable VM user by ID or username.",
@@ -117,15 +139,15 @@ func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data Type",
-			"The provider data supplied to the tenablevm_user data source is not a *Client. This is a bug in the provider implementation.",
+			"The provider data supplied to the tenablevm_user data source is not a *providerData. This is a bug in the provider implementation.",
 		)
 		return
 	}
-	d.client = c
+	d.client = data.Client
 }
 
 // Read performs the lookup operation.  It determines the search key
@@ -143,7 +165,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 	// Determine which key to use for lookup.  id has precedence.
-	var user *User
+	var user *tenable.User
 	if !config.ID.IsNull() && !config.ID.IsUnknown() && config.ID.ValueString() != "" {
 		idStr := config.ID.ValueString()
 		id, err := strconv.Atoi(idStr)
@@ -155,22 +177,22 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 			)
 			return
 		}
-		u, err := d.client.GetUser(id)
+		u, err := d.client.GetUser(ctx, id)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error retrieving Tenable VM user",
-				err.Error(),
+				errorDetail(err),
 			)
 			return
 		}
 		user = u
 	} else if !config.Username.IsNull() && !config.Username.IsUnknown() && config.Username.ValueString() != "" {
 		username := config.Username.ValueString()
-		users, err := d.client.ListUsers()
+		users, err := d.client.ListUsers(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error listing Tenable VM users",
-				err.Error(),
+				errorDetail(err),
 			)
 			return
 		}
@@ -210,6 +232,10 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 	state.Permissions = types.Int64Value(int64(user.Permissions))
 	state.Enabled = types.BoolValue(user.Enabled)
+	state.LastLogin = lastLoginValue(user.LastLogin)
+	state.LoginFailCount = types.Int64Value(int64(user.LoginFailCount))
+	state.Lockout = types.BoolValue(user.Lockout != 0)
+	state.RawJSON = rawJSONValue(user.RawJSON)
 	// Write computed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	// Log info message with found user
@@ -36,6 +36,7 @@ func TestUserDataSourceReadByID(t *testing.T) {
 	sample := map[string]interface{}{
 		"id": 1, "uuid": "uuid-1", "username": "alice", "name": "Alice",
 		"email": "alice@example.com", "permissions": 16, "enabled": true,
+		"lastlogin": 1700000000000, "login_fail_count": 3, "lockout": 1,
 	}
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -69,6 +70,9 @@ func TestUserDataSourceReadByID(t *testing.T) {
 		state.Email.ValueString() != "alice@example.com" || !state.Enabled.ValueBool() {
 		t.Errorf("unexpected state: %+v", state)
 	}
+	if state.LastLogin.ValueString() != "2023-11-14T22:13:20Z" || state.LoginFailCount.ValueInt64() != 3 || !state.Lockout.ValueBool() {
+		t.Errorf("unexpected login activity: %s, %s, %s", state.LastLogin, state.LoginFailCount, state.Lockout)
+	}
 }
 
 func TestUserDataSourceReadByUsername(t *testing.T) {
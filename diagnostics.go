@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// errorDetail formats err for the detail of a diagnostic.  Tenable API
+// errors are broken into labelled fields (status, request, error code
+// and request ID) below the API's message, so practitioners can quote
+// them to Tenable support instead of reading a raw response body.
+// Other errors are returned as is.
+func errorDetail(err error) string {
+	var apiErr *tenable.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	var b strings.Builder
+	message := apiErr.Message
+	if message == "" {
+		message = "The Tenable API returned " + apiErr.Status + "."
+	}
+	b.WriteString(message)
+	fmt.Fprintf(&b, "\n\nStatus: %s\nRequest: %s %s", apiErr.Status, apiErr.Method, apiErr.Path)
+	if apiErr.Code != "" {
+		fmt.Fprintf(&b, "\nError code: %s", apiErr.Code)
+	}
+	if apiErr.RequestID != "" {
+		fmt.Fprintf(&b, "\nRequest ID: %s", apiErr.RequestID)
+	}
+	if apiErr.Hint != "" {
+		b.WriteString("\n\n" + apiErr.Hint)
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+func TestErrorDetail(t *testing.T) {
+	err := fmt.Errorf("reading user: %w", &tenable.APIError{
+		StatusCode: 400,
+		Status:     "400 Bad Request",
+		Method:     "PUT",
+		Path:       "/users/7",
+		Message:    "Invalid permissions value",
+		Code:       "INVALID_PERMISSIONS",
+		RequestID:  "req-7",
+	})
+	want := "Invalid permissions value\n\nStatus: 400 Bad Request\nRequest: PUT /users/7\nError code: INVALID_PERMISSIONS\nRequest ID: req-7"
+	if got := errorDetail(err); got != want {
+		t.Errorf("errorDetail = %q, want %q", got, want)
+	}
+
+	if got := errorDetail(errors.New("connection refused")); got != "connection refused" {
+		t.Errorf("errorDetail of a plain error = %q", got)
+	}
+}
@@ -0,0 +1,1141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// fakeTenable is an in-memory TenableAPI for unit tests.  Users and
+// scans are stored by ID, tag categories, tag values and permissions
+// by UUID and groups and roles in order, and all are mutated by the
+// CRUD methods; the other collections are returned as seeded.  Missing objects
+// produce an *tenable.APIError with status 404, so
+// errors.Is(err, tenable.ErrNotFound) behaves as it does against the
+// real API.  Set err to make every call fail; calls with a done context
+// fail with its error.  Set enableErr to make CreateUser fail after
+// creating a disabled user, as the client does when the follow-up call
+// to disable it fails.  networks holds the scanner UUIDs assigned to
+// each known network; scanners not listed are in the default network.
+// agentMembers holds the agent IDs in each agent group; bulk changes
+// complete at once.
+type fakeTenable struct {
+	mu        sync.Mutex
+	nextID    int
+	err       error
+	enableErr error
+
+	users        map[int]*tenable.User
+	passwords    map[int]string
+	forceChange  map[int]bool
+	keys         map[int]*tenable.APIKeys
+	keySerial    int
+	auths        map[int]tenable.UserAuthorizations
+	roles        []*tenable.Role
+	groups       []*tenable.Group
+	members      map[int]map[int]bool
+	categories   map[string]*tenable.TagCategory
+	tagValues    map[string]*tenable.TagValue
+	permissions  map[string]*tenable.Permission
+	scanners     []*tenable.Scanner
+	networks     map[string]map[string]bool
+	agents       []*tenable.Agent
+	agentGroups  []*tenable.AgentGroup
+	agentMembers map[int]map[int]bool
+	templates    map[string][]*tenable.ScanTemplate
+	scans        map[int]*tenable.Scan
+	scanCreds    map[int][]tenable.ScanCredential
+	policies     map[int]*tenable.Policy
+	credentials  map[string]*tenable.ManagedCredential
+	status       *tenable.ServerStatus
+	currentID    int
+}
+
+var _ TenableAPI = (*fakeTenable)(nil)
+
+// newFakeTenable returns a fake seeded with the given users.
+func newFakeTenable(users ...*tenable.User) *fakeTenable {
+	f := &fakeTenable{nextID: 1, users: make(map[int]*tenable.User), passwords: make(map[int]string), forceChange: make(map[int]bool), members: make(map[int]map[int]bool), categories: make(map[string]*tenable.TagCategory), tagValues: make(map[string]*tenable.TagValue), permissions: make(map[string]*tenable.Permission), keys: make(map[int]*tenable.APIKeys), auths: make(map[int]tenable.UserAuthorizations)}
+	for _, u := range users {
+		f.users[u.ID] = u
+		if u.ID >= f.nextID {
+			f.nextID = u.ID + 1
+		}
+	}
+	return f
+}
+
+// fail returns the error a call should fail with: err when set, or
+// the context's error once it is done, like the client.
+func (f *fakeTenable) fail(ctx context.Context) error {
+	if f.err != nil {
+		return f.err
+	}
+	return ctx.Err()
+}
+
+func fakeNotFound(path string) error {
+	return &tenable.APIError{StatusCode: http.StatusNotFound, Status: "404 Not Found", Path: path, Message: "not found"}
+}
+
+func (f *fakeTenable) CreateUser(ctx context.Context, username, password string, permissions int, name, email, accountType string, enabled bool) (*tenable.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	u := &tenable.User{
+		ID:          f.nextID,
+		UUID:        fmt.Sprintf("uuid-%d", f.nextID),
+		Username:    username,
+		Name:        name,
+		Email:       email,
+		Permissions: permissions,
+		Enabled:     enabled,
+		Type:        accountType,
+	}
+	f.nextID++
+	f.users[u.ID] = u
+	f.passwords[u.ID] = password
+	if !enabled && f.enableErr != nil {
+		u.Enabled = true
+		copied := *u
+		return &copied, f.enableErr
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (f *fakeTenable) GetUser(ctx context.Context, id int) (*tenable.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	u, ok := f.users[id]
+	if !ok {
+		return nil, fakeNotFound(fmt.Sprintf("/users/%d", id))
+	}
+	copied := *u
+	return &copied, nil
+}
+
+// CurrentUser returns the user with ID currentID.
+func (f *fakeTenable) CurrentUser(ctx context.Context) (*tenable.User, error) {
+	return f.GetUser(ctx, f.currentID)
+}
+
+// GetUsersByIDs fetches the users in turn and collects the failures
+// in a *tenable.BulkError like the client.
+func (f *fakeTenable) GetUsersByIDs(ctx context.Context, ids []int) ([]*tenable.User, error) {
+	var users []*tenable.User
+	errs := make(map[int]error)
+	for _, id := range ids {
+		u, err := f.GetUser(ctx, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		users = append(users, u)
+	}
+	if len(errs) > 0 {
+		return users, &tenable.BulkError{Errors: errs}
+	}
+	return users, nil
+}
+
+func (f *fakeTenable) UpdateUser(ctx context.Context, id int, permissions *int, name, email *string, enabled *bool) (*tenable.User, error) {
+	f.mu.Lock()
+	if err := f.fail(ctx); err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	u, ok := f.users[id]
+	if !ok {
+		f.mu.Unlock()
+		return nil, fakeNotFound(fmt.Sprintf("/users/%d", id))
+	}
+	if permissions != nil {
+		u.Permissions = *permissions
+	}
+	if name != nil {
+		u.Name = *name
+	}
+	if email != nil {
+		u.Email = *email
+	}
+	if enabled != nil {
+		u.Enabled = *enabled
+	}
+	f.mu.Unlock()
+	return f.GetUser(ctx, id)
+}
+
+func (f *fakeTenable) DeleteUser(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.users[id]; !ok {
+		return fakeNotFound(fmt.Sprintf("/users/%d", id))
+	}
+	delete(f.users, id)
+	return nil
+}
+
+func (f *fakeTenable) ChangeUserPassword(ctx context.Context, id int, password string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.users[id]; !ok {
+		return fakeNotFound(fmt.Sprintf("/users/%d/chpasswd", id))
+	}
+	f.passwords[id] = password
+	return nil
+}
+
+// GenerateAPIKeys issues a new key pair per call, replacing the
+// previous one like the API does.
+func (f *fakeTenable) SetUserForcePasswordChange(ctx context.Context, id int, force bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.users[id]; !ok {
+		return fakeNotFound(fmt.Sprintf("/users/%d", id))
+	}
+	f.forceChange[id] = force
+	return nil
+}
+
+func (f *fakeTenable) GenerateAPIKeys(ctx context.Context, id int) (*tenable.APIKeys, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	if _, ok := f.users[id]; !ok {
+		return nil, fakeNotFound(fmt.Sprintf("/users/%d/keys", id))
+	}
+	f.keySerial++
+	keys := &tenable.APIKeys{AccessKey: fmt.Sprintf("access-%d", f.keySerial), SecretKey: fmt.Sprintf("secret-%d", f.keySerial)}
+	f.keys[id] = keys
+	copied := *keys
+	return &copied, nil
+}
+
+// GetUserAuthorizations returns the stored flags; users start with
+// every method permitted like new Tenable accounts.
+func (f *fakeTenable) GetUserAuthorizations(ctx context.Context, id int) (*tenable.UserAuthorizations, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	if _, ok := f.users[id]; !ok {
+		return nil, fakeNotFound(fmt.Sprintf("/users/%d/authorizations", id))
+	}
+	auth, ok := f.auths[id]
+	if !ok {
+		auth = tenable.UserAuthorizations{APIPermitted: true, PasswordPermitted: true, SAMLPermitted: true}
+	}
+	return &auth, nil
+}
+
+func (f *fakeTenable) UpdateUserAuthorizations(ctx context.Context, id int, auth tenable.UserAuthorizations) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.users[id]; !ok {
+		return fakeNotFound(fmt.Sprintf("/users/%d/authorizations", id))
+	}
+	f.auths[id] = auth
+	return nil
+}
+
+func (f *fakeTenable) ListUsers(ctx context.Context) ([]*tenable.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	users := make([]*tenable.User, 0, len(f.users))
+	for _, u := range f.users {
+		copied := *u
+		users = append(users, &copied)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (f *fakeTenable) ListRoles(ctx context.Context) ([]*tenable.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	return append([]*tenable.Role(nil), f.roles...), nil
+}
+
+func (f *fakeTenable) GetRole(ctx context.Context, id int) (*tenable.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	for _, r := range f.roles {
+		if r.ID == id {
+			copied := *r
+			return &copied, nil
+		}
+	}
+	return nil, fakeNotFound(fmt.Sprintf("/roles/%d", id))
+}
+
+func (f *fakeTenable) CreateRole(ctx context.Context, name, description string, privileges []string) (*tenable.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	r := &tenable.Role{ID: f.nextID, UUID: fmt.Sprintf("role-uuid-%d", f.nextID), Name: name, Description: description, Privileges: append([]string(nil), privileges...)}
+	f.nextID++
+	f.roles = append(f.roles, r)
+	copied := *r
+	return &copied, nil
+}
+
+func (f *fakeTenable) UpdateRole(ctx context.Context, id int, name, description string, privileges []string) (*tenable.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	for _, r := range f.roles {
+		if r.ID == id {
+			r.Name, r.Description, r.Privileges = name, description, append([]string(nil), privileges...)
+			copied := *r
+			return &copied, nil
+		}
+	}
+	return nil, fakeNotFound(fmt.Sprintf("/roles/%d", id))
+}
+
+func (f *fakeTenable) DeleteRole(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	for i, r := range f.roles {
+		if r.ID == id {
+			f.roles = append(f.roles[:i], f.roles[i+1:]...)
+			return nil
+		}
+	}
+	return fakeNotFound(fmt.Sprintf("/roles/%d", id))
+}
+
+func (f *fakeTenable) ListGroups(ctx context.Context) ([]*tenable.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	return append([]*tenable.Group(nil), f.groups...), nil
+}
+
+func (f *fakeTenable) GetGroup(ctx context.Context, id int) (*tenable.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	for _, g := range f.groups {
+		if g.ID == id {
+			copied := *g
+			return &copied, nil
+		}
+	}
+	return nil, fakeNotFound(fmt.Sprintf("/groups/%d", id))
+}
+
+func (f *fakeTenable) CreateGroup(ctx context.Context, name string) (*tenable.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	g := &tenable.Group{ID: f.nextID, UUID: fmt.Sprintf("group-uuid-%d", f.nextID), Name: name}
+	f.nextID++
+	f.groups = append(f.groups, g)
+	copied := *g
+	return &copied, nil
+}
+
+func (f *fakeTenable) RenameGroup(ctx context.Context, id int, name string) (*tenable.Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	for _, g := range f.groups {
+		if g.ID == id {
+			g.Name = name
+			copied := *g
+			return &copied, nil
+		}
+	}
+	return nil, fakeNotFound(fmt.Sprintf("/groups/%d", id))
+}
+
+func (f *fakeTenable) DeleteGroup(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	for i, g := range f.groups {
+		if g.ID == id {
+			f.groups = append(f.groups[:i], f.groups[i+1:]...)
+			delete(f.members, id)
+			return nil
+		}
+	}
+	return fakeNotFound(fmt.Sprintf("/groups/%d", id))
+}
+
+// hasGroup reports whether a group exists.  f.mu must be held.
+func (f *fakeTenable) hasGroup(id int) bool {
+	for _, g := range f.groups {
+		if g.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeTenable) ListGroupUsers(ctx context.Context, groupID int) ([]*tenable.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	if !f.hasGroup(groupID) {
+		return nil, fakeNotFound(fmt.Sprintf("/groups/%d/users", groupID))
+	}
+	var users []*tenable.User
+	for id := range f.members[groupID] {
+		if u, ok := f.users[id]; ok {
+			copied := *u
+			users = append(users, &copied)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (f *fakeTenable) AddGroupUser(ctx context.Context, groupID, userID int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.users[userID]; !ok || !f.hasGroup(groupID) {
+		return fakeNotFound(fmt.Sprintf("/groups/%d/users/%d", groupID, userID))
+	}
+	if f.members[groupID] == nil {
+		f.members[groupID] = make(map[int]bool)
+	}
+	f.members[groupID][userID] = true
+	return nil
+}
+
+func (f *fakeTenable) RemoveGroupUser(ctx context.Context, groupID, userID int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if !f.members[groupID][userID] {
+		return fakeNotFound(fmt.Sprintf("/groups/%d/users/%d", groupID, userID))
+	}
+	delete(f.members[groupID], userID)
+	return nil
+}
+
+func (f *fakeTenable) GetTagCategory(ctx context.Context, uuid string) (*tenable.TagCategory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	c, ok := f.categories[uuid]
+	if !ok {
+		return nil, fakeNotFound("/tags/categories/" + uuid)
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (f *fakeTenable) CreateTagCategory(ctx context.Context, name, description string) (*tenable.TagCategory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	c := &tenable.TagCategory{UUID: fmt.Sprintf("category-uuid-%d", f.nextID), Name: name, Description: description}
+	f.nextID++
+	f.categories[c.UUID] = c
+	copied := *c
+	return &copied, nil
+}
+
+func (f *fakeTenable) UpdateTagCategory(ctx context.Context, uuid, name, description string) (*tenable.TagCategory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	c, ok := f.categories[uuid]
+	if !ok {
+		return nil, fakeNotFound("/tags/categories/" + uuid)
+	}
+	c.Name, c.Description = name, description
+	copied := *c
+	return &copied, nil
+}
+
+func (f *fakeTenable) DeleteTagCategory(ctx context.Context, uuid string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.categories[uuid]; !ok {
+		return fakeNotFound("/tags/categories/" + uuid)
+	}
+	delete(f.categories, uuid)
+	for id, v := range f.tagValues {
+		if v.CategoryUUID == uuid {
+			delete(f.tagValues, id)
+		}
+	}
+	return nil
+}
+
+func (f *fakeTenable) GetTagValue(ctx context.Context, uuid string) (*tenable.TagValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	v, ok := f.tagValues[uuid]
+	if !ok {
+		return nil, fakeNotFound("/tags/values/" + uuid)
+	}
+	copied := *v
+	return &copied, nil
+}
+
+func (f *fakeTenable) CreateTagValue(ctx context.Context, categoryUUID, value, description string, filters *tenable.TagFilter) (*tenable.TagValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	c, ok := f.categories[categoryUUID]
+	if !ok {
+		return nil, fakeNotFound("/tags/categories/" + categoryUUID)
+	}
+	v := &tenable.TagValue{UUID: fmt.Sprintf("value-uuid-%d", f.nextID), CategoryUUID: c.UUID, CategoryName: c.Name, Value: value, Description: description, Type: "static", Filters: filters}
+	if filters != nil {
+		v.Type = "dynamic"
+	}
+	f.nextID++
+	f.tagValues[v.UUID] = v
+	copied := *v
+	return &copied, nil
+}
+
+func (f *fakeTenable) UpdateTagValue(ctx context.Context, uuid, value, description string, filters *tenable.TagFilter) (*tenable.TagValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	v, ok := f.tagValues[uuid]
+	if !ok {
+		return nil, fakeNotFound("/tags/values/" + uuid)
+	}
+	v.Value, v.Description = value, description
+	if filters != nil {
+		v.Filters = filters
+	}
+	copied := *v
+	return &copied, nil
+}
+
+func (f *fakeTenable) DeleteTagValue(ctx context.Context, uuid string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.tagValues[uuid]; !ok {
+		return fakeNotFound("/tags/values/" + uuid)
+	}
+	delete(f.tagValues, uuid)
+	return nil
+}
+
+func (f *fakeTenable) GetPermission(ctx context.Context, uuid string) (*tenable.Permission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	p, ok := f.permissions[uuid]
+	if !ok {
+		return nil, fakeNotFound("/api/v3/access-control/permissions/" + uuid)
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (f *fakeTenable) CreatePermission(ctx context.Context, p tenable.Permission) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return "", err
+	}
+	p.UUID = fmt.Sprintf("permission-uuid-%d", f.nextID)
+	f.nextID++
+	f.permissions[p.UUID] = &p
+	return p.UUID, nil
+}
+
+func (f *fakeTenable) UpdatePermission(ctx context.Context, uuid string, p tenable.Permission) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.permissions[uuid]; !ok {
+		return fakeNotFound("/api/v3/access-control/permissions/" + uuid)
+	}
+	p.UUID = uuid
+	f.permissions[uuid] = &p
+	return nil
+}
+
+func (f *fakeTenable) DeletePermission(ctx context.Context, uuid string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.permissions[uuid]; !ok {
+		return fakeNotFound("/api/v3/access-control/permissions/" + uuid)
+	}
+	delete(f.permissions, uuid)
+	return nil
+}
+
+func (f *fakeTenable) ListScanners(context.Context) ([]*tenable.Scanner, error) {
+	return f.scanners, f.err
+}
+
+func (f *fakeTenable) ListNetworkScanners(ctx context.Context, networkUUID string) ([]*tenable.Scanner, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	assigned, ok := f.networks[networkUUID]
+	if !ok {
+		return nil, fakeNotFound("/networks/" + networkUUID + "/scanners")
+	}
+	var scanners []*tenable.Scanner
+	for _, s := range f.scanners {
+		if assigned[s.UUID] {
+			copied := *s
+			scanners = append(scanners, &copied)
+		}
+	}
+	return scanners, nil
+}
+
+func (f *fakeTenable) AssignNetworkScanner(ctx context.Context, networkUUID, scannerUUID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	_, known := f.networks[networkUUID]
+	if !known && networkUUID != tenable.DefaultNetworkUUID {
+		return fakeNotFound("/networks/" + networkUUID)
+	}
+	for _, assigned := range f.networks {
+		delete(assigned, scannerUUID)
+	}
+	if known {
+		f.networks[networkUUID][scannerUUID] = true
+	}
+	return nil
+}
+
+func (f *fakeTenable) ListAgents(context.Context) ([]*tenable.Agent, error) {
+	return f.agents, f.err
+}
+
+func (f *fakeTenable) GetAgent(ctx context.Context, id int) (*tenable.Agent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	for _, a := range f.agents {
+		if a.ID == id {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, fakeNotFound(fmt.Sprintf("/scanners/null/agents/%d", id))
+}
+
+func (f *fakeTenable) RenameAgent(ctx context.Context, id int, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	for _, a := range f.agents {
+		if a.ID == id {
+			a.Name = name
+			return nil
+		}
+	}
+	return fakeNotFound(fmt.Sprintf("/scanners/null/agents/%d", id))
+}
+
+// UnlinkAgent removes the agent and its agent group memberships.
+func (f *fakeTenable) UnlinkAgent(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	for i, a := range f.agents {
+		if a.ID == id {
+			f.agents = append(f.agents[:i:i], f.agents[i+1:]...)
+			for _, members := range f.agentMembers {
+				delete(members, id)
+			}
+			return nil
+		}
+	}
+	return fakeNotFound(fmt.Sprintf("/scanners/null/agents/%d", id))
+}
+
+func (f *fakeTenable) ListAgentGroups(context.Context) ([]*tenable.AgentGroup, error) {
+	return f.agentGroups, f.err
+}
+
+func (f *fakeTenable) ListAgentGroupAgents(ctx context.Context, groupID int) ([]*tenable.Agent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	members, err := f.agentGroupMembers(groupID)
+	if err != nil {
+		return nil, err
+	}
+	var agents []*tenable.Agent
+	for _, a := range f.agents {
+		if members[a.ID] {
+			copied := *a
+			agents = append(agents, &copied)
+		}
+	}
+	return agents, nil
+}
+
+func (f *fakeTenable) AddAgentsToGroup(ctx context.Context, groupID int, agentIDs []int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	members, err := f.agentGroupMembers(groupID)
+	if err != nil {
+		return err
+	}
+	for _, id := range agentIDs {
+		members[id] = true
+	}
+	return nil
+}
+
+func (f *fakeTenable) RemoveAgentsFromGroup(ctx context.Context, groupID int, agentIDs []int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	members, err := f.agentGroupMembers(groupID)
+	if err != nil {
+		return err
+	}
+	for _, id := range agentIDs {
+		delete(members, id)
+	}
+	return nil
+}
+
+// agentGroupMembers returns the members of a seeded agent group.
+// Callers hold mu.
+func (f *fakeTenable) agentGroupMembers(groupID int) (map[int]bool, error) {
+	for _, g := range f.agentGroups {
+		if g.ID == groupID {
+			if f.agentMembers == nil {
+				f.agentMembers = make(map[int]map[int]bool)
+			}
+			if f.agentMembers[groupID] == nil {
+				f.agentMembers[groupID] = make(map[int]bool)
+			}
+			return f.agentMembers[groupID], nil
+		}
+	}
+	return nil, fakeNotFound(fmt.Sprintf("/scanners/null/agent-groups/%d", groupID))
+}
+
+func (f *fakeTenable) ListScanTemplates(_ context.Context, templateType string) ([]*tenable.ScanTemplate, error) {
+	return f.templates[templateType], f.err
+}
+
+// fakeMyScansFolder is the folder the fake puts scans in when no
+// folder is given, like the My Scans folder of the real API.
+const fakeMyScansFolder = 2
+
+// applyScanConfig writes cfg into scan the way the API stores it,
+// including the normalization of rrules.
+func applyScanConfig(scan *tenable.Scan, cfg tenable.ScanConfig) {
+	scan.TemplateUUID = cfg.TemplateUUID
+	scan.Name = cfg.Name
+	scan.Description = cfg.Description
+	scan.Targets = cfg.Targets
+	scan.ScannerID = cfg.ScannerID
+	scan.FolderID = cfg.FolderID
+	if scan.FolderID == 0 {
+		scan.FolderID = fakeMyScansFolder
+	}
+	scan.Enabled = cfg.Enabled
+	scan.RRules = cfg.RRules
+	if cfg.RRules != "" {
+		scan.RRules = normalizeScanRRule(cfg.RRules)
+	}
+	scan.StartTime = cfg.StartTime
+	scan.Timezone = cfg.Timezone
+	scan.AgentGroupIDs = slices.Clone(cfg.AgentGroupIDs)
+	if cfg.Notification != nil {
+		scan.Notification = nil
+		if len(cfg.Notification.Emails) > 0 {
+			n := *cfg.Notification
+			n.Filters = slices.Clone(n.Filters)
+			scan.Notification = &n
+		}
+	}
+}
+
+func (f *fakeTenable) GetScan(ctx context.Context, id int) (*tenable.Scan, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	scan, ok := f.scans[id]
+	if !ok {
+		return nil, fakeNotFound(fmt.Sprintf("/scans/%d", id))
+	}
+	copied := *scan
+	return &copied, nil
+}
+
+func (f *fakeTenable) CreateScan(ctx context.Context, cfg tenable.ScanConfig) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return 0, err
+	}
+	scan := &tenable.Scan{ID: f.nextID, UUID: fmt.Sprintf("scan-uuid-%d", f.nextID)}
+	f.nextID++
+	applyScanConfig(scan, cfg)
+	if f.scans == nil {
+		f.scans = make(map[int]*tenable.Scan)
+	}
+	f.scans[scan.ID] = scan
+	f.applyScanCredentials(scan.ID, cfg.Credentials)
+	return scan.ID, nil
+}
+
+func (f *fakeTenable) UpdateScan(ctx context.Context, id int, cfg tenable.ScanConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	scan, ok := f.scans[id]
+	if !ok {
+		return fakeNotFound(fmt.Sprintf("/scans/%d", id))
+	}
+	applyScanConfig(scan, cfg)
+	f.applyScanCredentials(id, cfg.Credentials)
+	return nil
+}
+
+// applyScanCredentials removes and attaches the credentials in creds.
+// Like the API, inline credentials get numeric IDs and their settings
+// are not returned.
+func (f *fakeTenable) applyScanCredentials(scanID int, creds *tenable.ScanCredentials) {
+	if creds == nil {
+		return
+	}
+	if f.scanCreds == nil {
+		f.scanCreds = make(map[int][]tenable.ScanCredential)
+	}
+	var kept []tenable.ScanCredential
+	for _, c := range f.scanCreds[scanID] {
+		if !slices.Contains(creds.Delete, c.ID) {
+			kept = append(kept, c)
+		}
+	}
+	for _, c := range creds.Add {
+		attached := tenable.ScanCredential{ID: c.UUID, UUID: c.UUID, Category: c.Category, Type: c.Type}
+		if c.UUID == "" {
+			attached.ID = strconv.Itoa(f.nextID)
+			f.nextID++
+		}
+		kept = append(kept, attached)
+	}
+	f.scanCreds[scanID] = kept
+}
+
+func (f *fakeTenable) ListScanCredentials(ctx context.Context, scanID int) ([]tenable.ScanCredential, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	if _, ok := f.scans[scanID]; !ok {
+		return nil, fakeNotFound(fmt.Sprintf("/editor/scan/%d", scanID))
+	}
+	return slices.Clone(f.scanCreds[scanID]), nil
+}
+
+func (f *fakeTenable) DeleteScan(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.scans[id]; !ok {
+		return fakeNotFound(fmt.Sprintf("/scans/%d", id))
+	}
+	delete(f.scans, id)
+	delete(f.scanCreds, id)
+	return nil
+}
+
+// fakePolicyDefaults are the settings the fake gives new policies,
+// like the template defaults of the real API.
+var fakePolicyDefaults = map[string]string{"safe_checks": "true", "max_checks": "5"}
+
+// fakePolicyPlugins is the plugin selection the fake gives new
+// policies: every family enabled.
+var fakePolicyPlugins = map[string]tenable.PolicyPluginFamily{
+	"General": {Status: tenable.PluginEnabled},
+	"Windows": {Status: tenable.PluginEnabled},
+}
+
+func (f *fakeTenable) GetPolicy(ctx context.Context, id int) (*tenable.Policy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	policy, ok := f.policies[id]
+	if !ok {
+		return nil, fakeNotFound(fmt.Sprintf("/policies/%d", id))
+	}
+	copied := *policy
+	copied.Settings = maps.Clone(policy.Settings)
+	copied.Plugins = maps.Clone(policy.Plugins)
+	return &copied, nil
+}
+
+func (f *fakeTenable) CreatePolicy(ctx context.Context, cfg tenable.PolicyConfig) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return 0, err
+	}
+	policy := &tenable.Policy{ID: f.nextID, TemplateUUID: cfg.TemplateUUID, Owner: "owner@example.com", Settings: maps.Clone(fakePolicyDefaults), Plugins: maps.Clone(fakePolicyPlugins)}
+	f.nextID++
+	applyPolicyConfig(policy, cfg)
+	if f.policies == nil {
+		f.policies = make(map[int]*tenable.Policy)
+	}
+	f.policies[policy.ID] = policy
+	return policy.ID, nil
+}
+
+func (f *fakeTenable) UpdatePolicy(ctx context.Context, id int, cfg tenable.PolicyConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	policy, ok := f.policies[id]
+	if !ok {
+		return fakeNotFound(fmt.Sprintf("/policies/%d", id))
+	}
+	applyPolicyConfig(policy, cfg)
+	return nil
+}
+
+// applyPolicyConfig writes cfg into policy.  Like the API, settings
+// and plugin families left out of cfg keep their values.
+func applyPolicyConfig(policy *tenable.Policy, cfg tenable.PolicyConfig) {
+	maps.Copy(policy.Settings, cfg.Settings)
+	maps.Copy(policy.Plugins, cfg.Plugins)
+	policy.Name, policy.Description = cfg.Name, cfg.Description
+	policy.Settings["name"], policy.Settings["description"] = cfg.Name, cfg.Description
+}
+
+func (f *fakeTenable) DeletePolicy(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.policies[id]; !ok {
+		return fakeNotFound(fmt.Sprintf("/policies/%d", id))
+	}
+	delete(f.policies, id)
+	return nil
+}
+
+// GetManagedCredential returns a copy of the credential with its
+// secrets masked, like the API.
+func (f *fakeTenable) GetManagedCredential(ctx context.Context, uuid string) (*tenable.ManagedCredential, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	cred, ok := f.credentials[uuid]
+	if !ok {
+		return nil, fakeNotFound("/credentials/" + uuid)
+	}
+	copied := *cred
+	copied.Settings = maps.Clone(cred.Settings)
+	copied.Permissions = slices.Clone(cred.Permissions)
+	for k := range copied.Settings {
+		if slices.Contains(managedCredentialSecretSettings, k) {
+			copied.Settings[k] = "********"
+		}
+	}
+	return &copied, nil
+}
+
+func (f *fakeTenable) CreateManagedCredential(ctx context.Context, cfg tenable.ManagedCredentialConfig) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return "", err
+	}
+	cred := &tenable.ManagedCredential{
+		UUID:        fmt.Sprintf("cred-uuid-%d", f.nextID),
+		Category:    "Host",
+		Type:        cfg.Type,
+		Settings:    map[string]string{},
+		Permissions: []tenable.CredentialPermission{fakeCredentialOwner},
+	}
+	f.nextID++
+	applyManagedCredentialConfig(cred, cfg)
+	if f.credentials == nil {
+		f.credentials = make(map[string]*tenable.ManagedCredential)
+	}
+	f.credentials[cred.UUID] = cred
+	return cred.UUID, nil
+}
+
+func (f *fakeTenable) UpdateManagedCredential(ctx context.Context, uuid string, cfg tenable.ManagedCredentialConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	cred, ok := f.credentials[uuid]
+	if !ok {
+		return fakeNotFound("/credentials/" + uuid)
+	}
+	applyManagedCredentialConfig(cred, cfg)
+	return nil
+}
+
+// fakeCredentialOwner is the owner's grant on credentials created by
+// the fake.
+var fakeCredentialOwner = tenable.CredentialPermission{GranteeUUID: "owner-uuid", Type: tenable.GranteeUser, Permissions: tenable.CredentialPermissionOwner}
+
+// applyManagedCredentialConfig writes cfg into cred.  Like the API,
+// settings left out of cfg keep their values, and permissions replace
+// the grants other than the owner's.
+func applyManagedCredentialConfig(cred *tenable.ManagedCredential, cfg tenable.ManagedCredentialConfig) {
+	cred.Name, cred.Description = cfg.Name, cfg.Description
+	maps.Copy(cred.Settings, cfg.Settings)
+	if cfg.Permissions != nil {
+		cred.Permissions = append([]tenable.CredentialPermission{fakeCredentialOwner}, cfg.Permissions...)
+	}
+}
+
+func (f *fakeTenable) DeleteManagedCredential(ctx context.Context, uuid string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+	if _, ok := f.credentials[uuid]; !ok {
+		return fakeNotFound("/credentials/" + uuid)
+	}
+	delete(f.credentials, uuid)
+	return nil
+}
+
+// Ping returns the seeded status, or a ready platform when none is set.
+func (f *fakeTenable) Ping(ctx context.Context) (*tenable.ServerStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+	if f.status != nil {
+		return f.status, nil
+	}
+	return &tenable.ServerStatus{Code: http.StatusOK, Status: "ready"}, nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the permission functions satisfy the function interface.
+var _ function.Function = &permissionIDFunction{}
+var _ function.Function = &permissionNameFunction{}
+
+// userPermissions lists the Tenable VM user roles and the numeric
+// permissions value the users API expects for each, from least to
+// most privileged.
+var userPermissions = []struct {
+	Name string
+	ID   int64
+}{
+	{"basic", 16},
+	{"scan_operator", 24},
+	{"standard", 32},
+	{"scan_manager", 40},
+	{"administrator", 64},
+}
+
+// userPermissionNames returns the role names accepted by
+// permission_id, for use in error messages.
+func userPermissionNames() []string {
+	names := make([]string, len(userPermissions))
+	for i, p := range userPermissions {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// permissionForRole returns the permissions value of a role name.
+// Matching is case-insensitive, treats spaces and hyphens as
+// underscores and accepts "admin" for administrator.
+func permissionForRole(name string) (int64, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+	if normalized == "admin" {
+		normalized = "administrator"
+	}
+	for _, p := range userPermissions {
+		if p.Name == normalized {
+			return p.ID, true
+		}
+	}
+	return 0, false
+}
+
+// roleForPermission returns the role name of a permissions value.
+func roleForPermission(id int64) (string, bool) {
+	for _, p := range userPermissions {
+		if p.ID == id {
+			return p.Name, true
+		}
+	}
+	return "", false
+}
+
+// permissionIDFunction implements the permission_id provider function,
+// converting a role name into the permissions value of tenablevm_user.
+type permissionIDFunction struct{}
+
+// NewPermissionIDFunction returns a new permission_id function.
+func NewPermissionIDFunction() function.Function {
+	return &permissionIDFunction{}
+}
+
+// Metadata sets the function name to `permission_id`.
+func (f *permissionIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permission_id"
+}
+
+// Definition describes the single string parameter and integer return
+// value of permission_id.
+func (f *permissionIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a Tenable role name to its permissions value.",
+		Description: "Returns the numeric permissions value used by tenablevm_user for a role name: basic (16), scan_operator (24), standard (32), scan_manager (40) or administrator (64). Matching is case-insensitive and treats spaces and hyphens as underscores.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Tenable role name, e.g. scan_manager.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+// Run maps the role name onto its permissions value.  Unknown names
+// produce an argument error listing the accepted values.
+func (f *permissionIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+	if id, ok := permissionForRole(name); ok {
+		resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, id))
+		return
+	}
+	resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown role %q, expected one of: %s", name, strings.Join(userPermissionNames(), ", ")))
+}
+
+// permissionNameFunction implements the permission_name provider
+// function, the inverse of permission_id.
+type permissionNameFunction struct{}
+
+// NewPermissionNameFunction returns a new permission_name function.
+func NewPermissionNameFunction() function.Function {
+	return &permissionNameFunction{}
+}
+
+// Metadata sets the function name to `permission_name`.
+func (f *permissionNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permission_name"
+}
+
+// Definition describes the single integer parameter and string return
+// value of permission_name.
+func (f *permissionNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a Tenable permissions value to its role name.",
+		Description: "Returns the role name (basic, scan_operator, standard, scan_manager or administrator) for the numeric permissions value of a tenablevm_user resource or data source.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "permissions",
+				Description: "Tenable permissions value: 16, 24, 32, 40 or 64.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run maps the permissions value onto its role name.  Values that do
+// not correspond to a role produce an argument error.
+func (f *permissionNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+	if name, ok := roleForPermission(id); ok {
+		resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+		return
+	}
+	ids := make([]string, len(userPermissions))
+	for i, p := range userPermissions {
+		ids[i] = fmt.Sprint(p.ID)
+	}
+	resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown permissions value %d, expected one of: %s", id, strings.Join(ids, ", ")))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPermissionIDFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewPermissionIDFunction()
+
+	for name, want := range map[string]int64{"basic": 16, "Scan Manager": 40, "scan-operator": 24, "ADMIN": 64, " standard ": 32} {
+		req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(name)})}
+		resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+		f.Run(ctx, req, &resp)
+		if resp.Error != nil {
+			t.Fatalf("permission_id(%q) unexpected error: %v", name, resp.Error)
+		}
+		if got := resp.Result.Value(); !got.Equal(types.Int64Value(want)) {
+			t.Errorf("permission_id(%q) = %v, want %d", name, got, want)
+		}
+	}
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("superuser")})}
+	resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Errorf("expected error for unknown role name")
+	}
+}
+
+func TestPermissionNameFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewPermissionNameFunction()
+
+	for id, want := range map[int64]string{16: "basic", 24: "scan_operator", 32: "standard", 40: "scan_manager", 64: "administrator"} {
+		req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.Int64Value(id)})}
+		resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		f.Run(ctx, req, &resp)
+		if resp.Error != nil {
+			t.Fatalf("permission_name(%d) unexpected error: %v", id, resp.Error)
+		}
+		if got := resp.Result.Value(); !got.Equal(types.StringValue(want)) {
+			t.Errorf("permission_name(%d) = %v, want %s", id, got, want)
+		}
+	}
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.Int64Value(48)})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Errorf("expected error for unknown permissions value")
+	}
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the IANA time zone database so timezone validation does
+	// not depend on the zoneinfo files of the machine running Terraform.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the scan_schedule function satisfies the function interface.
+var _ function.Function = &scanScheduleFunction{}
+
+// scanScheduleAttrTypes describes the object returned by scan_schedule.
+// The attribute names match the schedule fields of a scan's settings.
+var scanScheduleAttrTypes = map[string]attr.Type{
+	"rrules":    types.StringType,
+	"starttime": types.StringType,
+	"timezone":  types.StringType,
+}
+
+// scanScheduleModel maps the scan_schedule return object into a Go
+// struct.
+type scanScheduleModel struct {
+	RRules    types.String `tfsdk:"rrules"`
+	StartTime types.String `tfsdk:"starttime"`
+	Timezone  types.String `tfsdk:"timezone"`
+}
+
+// scanScheduleFrequencies lists the FREQ values Tenable accepts in a
+// scan rrule.
+var scanScheduleFrequencies = []string{"ONETIME", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// scanScheduleWeekdays maps accepted weekday spellings onto RRULE day
+// codes.
+var scanScheduleWeekdays = map[string]string{
+	"mo": "MO", "mon": "MO", "monday": "MO",
+	"tu": "TU", "tue": "TU", "tuesday": "TU",
+	"we": "WE", "wed": "WE", "wednesday": "WE",
+	"th": "TH", "thu": "TH", "thursday": "TH",
+	"fr": "FR", "fri": "FR", "friday": "FR",
+	"sa": "SA", "sat": "SA", "saturday": "SA",
+	"su": "SU", "sun": "SU", "sunday": "SU",
+}
+
+// scanScheduleStartLayouts are the accepted start_time formats.  The
+// time is wall-clock time in the schedule's timezone, so offsets are
+// deliberately not accepted.
+var scanScheduleStartLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// scanScheduleStartFormat is the starttime format used by the scans API.
+const scanScheduleStartFormat = "20060102T150405"
+
+// scanScheduleError reports which scan_schedule argument is invalid.
+type scanScheduleError struct {
+	Argument int
+	Message  string
+}
+
+func (e *scanScheduleError) Error() string {
+	return e.Message
+}
+
+// buildScanSchedule validates the friendly schedule inputs and returns
+// the rrules, starttime and timezone values for a scan.  Weekly
+// schedules without weekdays and monthly schedules run on the weekday
+// or day of month of the start time.
+func buildScanSchedule(frequency string, interval int64, weekdays []string, startTime, timezone string) (*scanScheduleModel, error) {
+	freq := strings.ToUpper(strings.TrimSpace(frequency))
+	valid := false
+	for _, f := range scanScheduleFrequencies {
+		if f == freq {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, &scanScheduleError{0, fmt.Sprintf("unknown frequency %q, expected one of: %s", frequency, strings.ToLower(strings.Join(scanScheduleFrequencies, ", ")))}
+	}
+	if interval < 1 {
+		return nil, &scanScheduleError{1, fmt.Sprintf("interval must be at least 1, got %d", interval)}
+	}
+	if freq == "ONETIME" && interval != 1 {
+		return nil, &scanScheduleError{1, "interval must be 1 for a onetime schedule"}
+	}
+
+	var days []string
+	seen := make(map[string]bool)
+	for _, d := range weekdays {
+		code, ok := scanScheduleWeekdays[strings.ToLower(strings.TrimSpace(d))]
+		if !ok {
+			return nil, &scanScheduleError{2, fmt.Sprintf("unknown weekday %q, expected e.g. MO or monday", d)}
+		}
+		if !seen[code] {
+			seen[code] = true
+			days = append(days, code)
+		}
+	}
+	if len(days) > 0 && freq != "WEEKLY" {
+		return nil, &scanScheduleError{2, "weekdays can only be set for a weekly schedule"}
+	}
+
+	loc, err := time.LoadLocation(strings.TrimSpace(timezone))
+	if err != nil || timezone == "" {
+		return nil, &scanScheduleError{4, fmt.Sprintf("unknown timezone %q, expected an IANA name such as Asia/Tokyo", timezone)}
+	}
+	var start time.Time
+	for _, layout := range scanScheduleStartLayouts {
+		if start, err = time.ParseInLocation(layout, strings.TrimSpace(startTime), loc); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, &scanScheduleError{3, fmt.Sprintf("start_time %q must be in the form YYYY-MM-DDTHH:MM[:SS] without a UTC offset", startTime)}
+	}
+
+	rrule := fmt.Sprintf("FREQ=%s;INTERVAL=%d", freq, interval)
+	switch freq {
+	case "WEEKLY":
+		if len(days) == 0 {
+			days = []string{strings.ToUpper(start.Weekday().String()[:2])}
+		}
+		rrule += ";BYDAY=" + strings.Join(days, ",")
+	case "MONTHLY":
+		rrule += fmt.Sprintf(";BYMONTHDAY=%d", start.Day())
+	}
+	return &scanScheduleModel{
+		RRules:    types.StringValue(rrule),
+		StartTime: types.StringValue(start.Format(scanScheduleStartFormat)),
+		Timezone:  types.StringValue(loc.String()),
+	}, nil
+}
+
+// scanScheduleWeekdayOrder lists the RRULE day codes in week order.
+var scanScheduleWeekdayOrder = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// parseScanRRule validates a scan rrule such as
+// FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH and returns its parts keyed by
+// upper-case name.  Only the parts Tenable scan schedules use are
+// accepted: FREQ, which is required, INTERVAL, BYDAY for weekly and
+// BYMONTHDAY for monthly schedules.
+func parseScanRRule(rrule string) (map[string]string, error) {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rrule, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key, value = strings.ToUpper(strings.TrimSpace(key)), strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("%q is not of the form NAME=VALUE", part)
+		}
+		if _, dup := parts[key]; dup {
+			return nil, fmt.Errorf("%s is given more than once", key)
+		}
+		parts[key] = value
+	}
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	valid := false
+	for _, f := range scanScheduleFrequencies {
+		valid = valid || f == freq
+	}
+	if !valid {
+		return nil, fmt.Errorf("unknown FREQ %q, expected one of: %s", freq, strings.Join(scanScheduleFrequencies, ", "))
+	}
+	for key, value := range parts {
+		switch key {
+		case "FREQ":
+		case "INTERVAL":
+			if n, err := strconv.Atoi(value); err != nil || n < 1 {
+				return nil, fmt.Errorf("INTERVAL must be a positive number, got %q", value)
+			}
+		case "BYDAY":
+			if freq != "WEEKLY" {
+				return nil, fmt.Errorf("BYDAY can only be set for FREQ=WEEKLY")
+			}
+			for _, day := range strings.Split(value, ",") {
+				if !slices.Contains(scanScheduleWeekdayOrder, strings.TrimSpace(day)) {
+					return nil, fmt.Errorf("unknown BYDAY day %q, expected e.g. MO", day)
+				}
+			}
+		case "BYMONTHDAY":
+			if freq != "MONTHLY" {
+				return nil, fmt.Errorf("BYMONTHDAY can only be set for FREQ=MONTHLY")
+			}
+			if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 31 {
+				return nil, fmt.Errorf("BYMONTHDAY must be a day of the month from 1 to 31, got %q", value)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %s, expected FREQ, INTERVAL, BYDAY or BYMONTHDAY", key)
+		}
+	}
+	return parts, nil
+}
+
+// normalizeScanRRule returns rrule in the form Tenable stores it: parts
+// in a fixed order, INTERVAL defaulted to 1 and weekdays in week order.
+// Two rrules describe the same schedule when their normal forms are
+// equal.  An invalid rrule is returned unchanged.
+func normalizeScanRRule(rrule string) string {
+	parts, err := parseScanRRule(rrule)
+	if err != nil {
+		return rrule
+	}
+	interval := parts["INTERVAL"]
+	if interval == "" {
+		interval = "1"
+	}
+	n, _ := strconv.Atoi(interval)
+	normal := fmt.Sprintf("FREQ=%s;INTERVAL=%d", parts["FREQ"], n)
+	if byDay := parts["BYDAY"]; byDay != "" {
+		given := strings.Split(strings.ReplaceAll(byDay, " ", ""), ",")
+		var days []string
+		for _, day := range scanScheduleWeekdayOrder {
+			if slices.Contains(given, day) {
+				days = append(days, day)
+			}
+		}
+		normal += ";BYDAY=" + strings.Join(days, ",")
+	}
+	if byMonthDay := parts["BYMONTHDAY"]; byMonthDay != "" {
+		n, _ := strconv.Atoi(byMonthDay)
+		normal += fmt.Sprintf(";BYMONTHDAY=%d", n)
+	}
+	return normal
+}
+
+// scanScheduleAPIStartLayouts are the starttime formats the scans API
+// accepts and returns; Tenable drops zero seconds on some endpoints.
+var scanScheduleAPIStartLayouts = []string{scanScheduleStartFormat, "20060102T1504"}
+
+// parseScanStartTime parses a starttime of the scans API.
+func parseScanStartTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range scanScheduleAPIStartLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("starttime %q must be in the form YYYYMMDDTHHMMSS, e.g. 20240401T093000", s)
+}
+
+// scanScheduleFunction implements the scan_schedule provider function,
+// which builds the RRULE based schedule Tenable scans expect from
+// friendly inputs.
+type scanScheduleFunction struct{}
+
+// NewScanScheduleFunction returns a new scan_schedule function.
+func NewScanScheduleFunction() function.Function {
+	return &scanScheduleFunction{}
+}
+
+// Metadata sets the function name to `scan_schedule`.
+func (f *scanScheduleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scan_schedule"
+}
+
+// Definition describes the schedule parameters and the object
+// returned by scan_schedule.
+func (f *scanScheduleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Tenable scan schedule.",
+		Description: "Validates a frequency, interval, weekdays, start time and timezone and returns an object with the rrules, starttime and timezone values of a Tenable scan schedule.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "frequency",
+				Description: "One of onetime, daily, weekly, monthly or yearly.",
+			},
+			function.Int64Parameter{
+				Name:        "interval",
+				Description: "Run every interval days, weeks, months or years. Must be 1 for onetime.",
+			},
+			function.ListParameter{
+				Name:        "weekdays",
+				ElementType: types.StringType,
+				Description: "Weekdays for a weekly schedule, e.g. [\"MO\", \"thursday\"]. Use an empty list to run on the weekday of start_time.",
+			},
+			function.StringParameter{
+				Name:        "start_time",
+				Description: "First run in the schedule's timezone, in the form YYYY-MM-DDTHH:MM[:SS].",
+			},
+			function.StringParameter{
+				Name:        "timezone",
+				Description: "IANA time zone name, e.g. Asia/Tokyo.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: scanScheduleAttrTypes},
+	}
+}
+
+// Run builds the schedule and reports invalid input as an error on the
+// offending argument.
+func (f *scanScheduleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var frequency, startTime, timezone string
+	var interval int64
+	var weekdays []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &frequency, &interval, &weekdays, &startTime, &timezone))
+	if resp.Error != nil {
+		return
+	}
+	schedule, err := buildScanSchedule(frequency, interval, weekdays, startTime, timezone)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(int64(err.(*scanScheduleError).Argument), err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, schedule))
+}
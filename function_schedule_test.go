@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestScanScheduleFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewScanScheduleFunction()
+
+	run := func(frequency string, interval int64, weekdays []string, startTime, timezone string) *function.RunResponse {
+		days := make([]attr.Value, len(weekdays))
+		for i, d := range weekdays {
+			days[i] = types.StringValue(d)
+		}
+		req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue(frequency),
+			types.Int64Value(interval),
+			types.ListValueMust(types.StringType, days),
+			types.StringValue(startTime),
+			types.StringValue(timezone),
+		})}
+		resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(scanScheduleAttrTypes))}
+		f.Run(ctx, req, &resp)
+		return &resp
+	}
+
+	for _, tc := range []struct {
+		frequency string
+		interval  int64
+		weekdays  []string
+		start     string
+		rrules    string
+		starttime string
+	}{
+		{"weekly", 2, []string{"monday", "TH", "mo"}, "2024-04-01T09:30", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "20240401T093000"},
+		{"Weekly", 1, nil, "2024-04-03 22:00:15", "FREQ=WEEKLY;INTERVAL=1;BYDAY=WE", "20240403T220015"},
+		{"monthly", 1, nil, "2024-04-15T01:00", "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=15", "20240415T010000"},
+		{"onetime", 1, nil, "2024-04-15T01:00", "FREQ=ONETIME;INTERVAL=1", "20240415T010000"},
+	} {
+		resp := run(tc.frequency, tc.interval, tc.weekdays, tc.start, "Asia/Tokyo")
+		if resp.Error != nil {
+			t.Fatalf("scan_schedule(%s) unexpected error: %v", tc.frequency, resp.Error)
+		}
+		want := types.ObjectValueMust(scanScheduleAttrTypes, map[string]attr.Value{
+			"rrules":    types.StringValue(tc.rrules),
+			"starttime": types.StringValue(tc.starttime),
+			"timezone":  types.StringValue("Asia/Tokyo"),
+		})
+		if got := resp.Result.Value(); !got.Equal(want) {
+			t.Errorf("scan_schedule(%s) = %v, want %v", tc.frequency, got, want)
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		frequency string
+		interval  int64
+		weekdays  []string
+		start     string
+		timezone  string
+		argument  int64
+	}{
+		{"frequency", "hourly", 1, nil, "2024-04-01T09:30", "UTC", 0},
+		{"interval", "daily", 0, nil, "2024-04-01T09:30", "UTC", 1},
+		{"weekday", "weekly", 1, []string{"someday"}, "2024-04-01T09:30", "UTC", 2},
+		{"weekdays on daily", "daily", 1, []string{"MO"}, "2024-04-01T09:30", "UTC", 2},
+		{"offset", "daily", 1, nil, "2024-04-01T09:30:00Z", "UTC", 3},
+		{"timezone", "daily", 1, nil, "2024-04-01T09:30", "Mars/Olympus", 4},
+	} {
+		resp := run(tc.frequency, tc.interval, tc.weekdays, tc.start, tc.timezone)
+		if resp.Error == nil {
+			t.Errorf("%s: expected error", tc.name)
+			continue
+		}
+		if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != tc.argument {
+			t.Errorf("%s: error reported on argument %v, want %d", tc.name, resp.Error.FunctionArgument, tc.argument)
+		}
+	}
+}
+
+func TestNormalizeScanRRule(t *testing.T) {
+	for in, want := range map[string]string{
+		"FREQ=WEEKLY;BYDAY=TH,MO":             "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH",
+		"byday=su,mo; freq=weekly;interval=2": "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU",
+		"FREQ=MONTHLY;BYMONTHDAY=05":          "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=5",
+		"FREQ=DAILY;INTERVAL=01;":             "FREQ=DAILY;INTERVAL=1",
+		"FREQ=HOURLY":                         "FREQ=HOURLY",
+	} {
+		if got := normalizeScanRRule(in); got != want {
+			t.Errorf("normalizeScanRRule(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
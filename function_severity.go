@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the severity functions satisfy the function interface.
+var _ function.Function = &severityNameFunction{}
+var _ function.Function = &severityNumberFunction{}
+
+// severityNames lists Tenable severity names indexed by their numeric
+// value.  Tenable uses 0 (info) through 4 (critical) in vulnerability
+// exports, workbench filters and policy thresholds.
+var severityNames = []string{"info", "low", "medium", "high", "critical"}
+
+// severityNameFunction implements the severity_name provider function,
+// converting a Tenable severity integer into its lower-case name.
+type severityNameFunction struct{}
+
+// NewSeverityNameFunction returns a new severity_name function.
+func NewSeverityNameFunction() function.Function {
+	return &severityNameFunction{}
+}
+
+// Metadata sets the function name to `severity_name`.
+func (f *severityNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "severity_name"
+}
+
+// Definition describes the single integer parameter and string return
+// value of severity_name.
+func (f *severityNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a Tenable severity number to its name.",
+		Description: "Returns the lower-case Tenable severity name (info, low, medium, high or critical) for a severity number between 0 and 4.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "severity",
+				Description: "Tenable severity number between 0 (info) and 4 (critical).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run maps the severity number onto its name.  Values outside the
+// known range produce an argument error.
+func (f *severityNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var severity int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &severity))
+	if resp.Error != nil {
+		return
+	}
+	if severity < 0 || severity >= int64(len(severityNames)) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("severity must be between 0 and %d, got %d", len(severityNames)-1, severity))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, severityNames[severity]))
+}
+
+// severityNumberFunction implements the severity_number provider
+// function, the inverse of severity_name.
+type severityNumberFunction struct{}
+
+// NewSeverityNumberFunction returns a new severity_number function.
+func NewSeverityNumberFunction() function.Function {
+	return &severityNumberFunction{}
+}
+
+// Metadata sets the function name to `severity_number`.
+func (f *severityNumberFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "severity_number"
+}
+
+// Definition describes the single string parameter and integer return
+// value of severity_number.
+func (f *severityNumberFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a Tenable severity name to its number.",
+		Description: "Returns the Tenable severity number (0-4) for a severity name. Matching is case-insensitive and also accepts \"informational\" for info.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Tenable severity name: info, low, medium, high or critical.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+// Run maps the severity name onto its number.  Unknown names produce
+// an argument error listing the accepted values.
+func (f *severityNumberFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "informational" {
+		normalized = "info"
+	}
+	for i, n := range severityNames {
+		if n == normalized {
+			resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, int64(i)))
+			return
+		}
+	}
+	resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown severity %q, expected one of: %s", name, strings.Join(severityNames, ", ")))
+}
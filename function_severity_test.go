@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSeverityNameFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewSeverityNameFunction()
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.Int64Value(4)})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	if got := resp.Result.Value(); !got.Equal(types.StringValue("critical")) {
+		t.Errorf("severity_name(4) = %v, want critical", got)
+	}
+
+	req = function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.Int64Value(5)})}
+	resp = function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Errorf("expected error for out of range severity")
+	}
+}
+
+func TestSeverityNumberFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewSeverityNumberFunction()
+
+	for name, want := range map[string]int64{"Info": 0, "informational": 0, "MEDIUM": 2, " high ": 3} {
+		req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(name)})}
+		resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+		f.Run(ctx, req, &resp)
+		if resp.Error != nil {
+			t.Fatalf("severity_number(%q) unexpected error: %v", name, resp.Error)
+		}
+		if got := resp.Result.Value(); !got.Equal(types.Int64Value(want)) {
+			t.Errorf("severity_number(%q) = %v, want %d", name, got, want)
+		}
+	}
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("severe")})}
+	resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Errorf("expected error for unknown severity name")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the tag functions satisfy the function interface.
+var _ function.Function = &parseTagFunction{}
+var _ function.Function = &formatTagFunction{}
+
+// tagReferenceAttrTypes describes the object returned by parse_tag.
+var tagReferenceAttrTypes = map[string]attr.Type{
+	"category": types.StringType,
+	"value":    types.StringType,
+}
+
+// tagReferenceModel maps the parse_tag return object into a Go struct.
+type tagReferenceModel struct {
+	Category types.String `tfsdk:"category"`
+	Value    types.String `tfsdk:"value"`
+}
+
+// splitTagReference splits a "Category:Value" reference on the first
+// colon.  Tenable allows colons in tag values but not in category
+// names, so everything after the first colon belongs to the value.
+// Surrounding whitespace on either side is trimmed.
+func splitTagReference(ref string) (string, string, error) {
+	category, value, ok := strings.Cut(ref, ":")
+	category = strings.TrimSpace(category)
+	value = strings.TrimSpace(value)
+	if !ok || category == "" || value == "" {
+		return "", "", fmt.Errorf("tag reference %q must be in the form Category:Value", ref)
+	}
+	return category, value, nil
+}
+
+// parseTagFunction implements the parse_tag provider function, which
+// splits a "Category:Value" tag reference into its parts.
+type parseTagFunction struct{}
+
+// NewParseTagFunction returns a new parse_tag function.
+func NewParseTagFunction() function.Function {
+	return &parseTagFunction{}
+}
+
+// Metadata sets the function name to `parse_tag`.
+func (f *parseTagFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_tag"
+}
+
+// Definition describes the string parameter and the category/value
+// object returned by parse_tag.
+func (f *parseTagFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a Tenable tag reference into category and value.",
+		Description: "Parses a tag reference in the form \"Category:Value\" and returns an object with category and value attributes. Only the first colon separates the two parts.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "tag",
+				Description: "Tag reference in the form Category:Value.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: tagReferenceAttrTypes},
+	}
+}
+
+// Run splits the tag reference and returns the parsed object.
+func (f *parseTagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ref string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ref))
+	if resp.Error != nil {
+		return
+	}
+	category, value, err := splitTagReference(ref)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	result := tagReferenceModel{
+		Category: types.StringValue(category),
+		Value:    types.StringValue(value),
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, &result))
+}
+
+// formatTagFunction implements the format_tag provider function, the
+// inverse of parse_tag.
+type formatTagFunction struct{}
+
+// NewFormatTagFunction returns a new format_tag function.
+func NewFormatTagFunction() function.Function {
+	return &formatTagFunction{}
+}
+
+// Metadata sets the function name to `format_tag`.
+func (f *formatTagFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_tag"
+}
+
+// Definition describes the category and value parameters and string
+// return value of format_tag.
+func (f *formatTagFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Tenable tag reference from category and value.",
+		Description: "Returns a tag reference in the form \"Category:Value\". The result can be parsed back with parse_tag.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "category",
+				Description: "Tag category name. Must not contain a colon.",
+			},
+			function.StringParameter{
+				Name:        "value",
+				Description: "Tag value.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run joins the category and value.  Categories containing a colon
+// are rejected because they could not be parsed back unambiguously.
+func (f *formatTagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var category, value string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &category, &value))
+	if resp.Error != nil {
+		return
+	}
+	category = strings.TrimSpace(category)
+	value = strings.TrimSpace(value)
+	if category == "" || strings.Contains(category, ":") {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("category %q must be non-empty and must not contain a colon", category))
+		return
+	}
+	if value == "" {
+		resp.Error = function.NewArgumentFuncError(1, "value must be non-empty")
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, category+":"+value))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseTagFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewParseTagFunction()
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("Location: Tokyo:DC1")})}
+	resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(tagReferenceAttrTypes))}
+	f.Run(ctx, req, &resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	want := types.ObjectValueMust(tagReferenceAttrTypes, map[string]attr.Value{
+		"category": types.StringValue("Location"),
+		"value":    types.StringValue("Tokyo:DC1"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("parse_tag = %v, want %v", got, want)
+	}
+
+	req = function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("NoColon")})}
+	resp = function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(tagReferenceAttrTypes))}
+	f.Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Errorf("expected error for malformed tag reference")
+	}
+}
+
+func TestFormatTagFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewFormatTagFunction()
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("Location"), types.StringValue("Tokyo:DC1")})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	if got := resp.Result.Value(); !got.Equal(types.StringValue("Location:Tokyo:DC1")) {
+		t.Errorf("format_tag = %v, want Location:Tokyo:DC1", got)
+	}
+
+	req = function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("Bad:Category"), types.StringValue("x")})}
+	resp = function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, req, &resp)
+	if resp.Error == nil {
+		t.Errorf("expected error for category containing a colon")
+	}
+}
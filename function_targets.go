@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the normalize_targets function satisfies the function interface.
+var _ function.Function = &normalizeTargetsFunction{}
+
+// normalizeTargetsFunction implements the normalize_targets provider
+// function, which validates scan targets at plan time and joins them
+// into the comma-separated form the scans API expects.
+type normalizeTargetsFunction struct{}
+
+// NewNormalizeTargetsFunction returns a new normalize_targets function.
+func NewNormalizeTargetsFunction() function.Function {
+	return &normalizeTargetsFunction{}
+}
+
+// Metadata sets the function name to `normalize_targets`.
+func (f *normalizeTargetsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_targets"
+}
+
+// Definition describes the list parameter and string return value of
+// normalize_targets.
+func (f *normalizeTargetsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validate and normalize a list of scan targets.",
+		Description: "Validates each target as an IP address, CIDR block, IP range (first-last) or hostname and returns the targets in canonical form, deduplicated in their original order and joined with commas. Elements may themselves contain comma-separated targets.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "targets",
+				ElementType: types.StringType,
+				Description: "Scan targets, e.g. [\"10.0.0.0/24\", \"10.0.1.1-10.0.1.50\", \"web.example.com\"].",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes every target and reports the first invalid one as an
+// argument error.
+func (f *normalizeTargetsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var targets []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &targets))
+	if resp.Error != nil {
+		return
+	}
+	normalized, err := normalizeTargets(targets)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, strings.Join(normalized, ",")))
+}
+
+// normalizeTargets splits, validates, canonicalizes and deduplicates
+// scan targets.
+func normalizeTargets(targets []string) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, entry := range targets {
+		for _, raw := range strings.Split(entry, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			target, err := normalizeTarget(raw)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[target] {
+				seen[target] = true
+				out = append(out, target)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one scan target is required")
+	}
+	return out, nil
+}
+
+// normalizeTarget returns the canonical form of a single target.  CIDR
+// blocks are masked to their network address and hostnames are
+// lower-cased without a trailing dot.
+func normalizeTarget(target string) (string, error) {
+	if addr, err := netip.ParseAddr(target); err == nil {
+		return addr.String(), nil
+	}
+	if strings.Contains(target, "/") {
+		prefix, err := netip.ParsePrefix(target)
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR target %q", target)
+		}
+		return prefix.Masked().String(), nil
+	}
+	if first, last, ok := strings.Cut(target, "-"); ok {
+		start, err1 := netip.ParseAddr(strings.TrimSpace(first))
+		end, err2 := netip.ParseAddr(strings.TrimSpace(last))
+		if err1 == nil && err2 == nil {
+			if start.Is4() != end.Is4() || end.Less(start) {
+				return "", fmt.Errorf("invalid IP range target %q: the end address must be of the same family and not before the start", target)
+			}
+			return start.String() + "-" + end.String(), nil
+		}
+		if err1 == nil || err2 == nil {
+			return "", fmt.Errorf("invalid IP range target %q", target)
+		}
+	}
+	host := strings.ToLower(strings.TrimSuffix(target, "."))
+	if !validHostname(host) {
+		return "", fmt.Errorf("invalid scan target %q: expected an IP address, CIDR block, IP range or hostname", target)
+	}
+	return host, nil
+}
+
+// validHostname reports whether host is a valid RFC 1123 hostname.
+func validHostname(host string) bool {
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
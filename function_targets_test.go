@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeTargetsFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewNormalizeTargetsFunction()
+
+	run := func(targets ...string) *function.RunResponse {
+		elems := make([]attr.Value, len(targets))
+		for i, target := range targets {
+			elems[i] = types.StringValue(target)
+		}
+		req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(types.StringType, elems)})}
+		resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		f.Run(ctx, req, &resp)
+		return &resp
+	}
+
+	resp := run(" 10.0.0.5/24", "10.0.1.1 - 10.0.1.50", "Web.Example.com.", "10.0.0.0/24, web.example.com", "2001:db8::0001", "192.168.1.10")
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	want := "10.0.0.0/24,10.0.1.1-10.0.1.50,web.example.com,2001:db8::1,192.168.1.10"
+	if got := resp.Result.Value(); !got.Equal(types.StringValue(want)) {
+		t.Errorf("normalize_targets = %v, want %s", got, want)
+	}
+
+	for _, bad := range [][]string{
+		{"10.0.0.0/33"},
+		{"10.0.0.50-10.0.0.1"},
+		{"10.0.0.1-2001:db8::1"},
+		{"10.0.0.1-nope"},
+		{"bad_host.example.com"},
+		{"-leading.example.com"},
+		{" , "},
+	} {
+		if resp := run(bad...); resp.Error == nil {
+			t.Errorf("normalize_targets(%q) expected error", bad)
+		}
+	}
+}
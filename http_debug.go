@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// debugBodyLimit caps how much of a request or response body is
+// logged, so large list responses do not flood the Terraform log.
+const debugBodyLimit = 16 * 1024
+
+// debugTransport logs every request and response at debug level when
+// the provider's http_debug flag is set.  It logs through the
+// request's context, falling back to ctx, captured at Configure time,
+// for requests whose context has no logger.
+type debugTransport struct {
+	ctx context.Context
+	rt  http.RoundTripper
+}
+
+// RoundTrip logs the request, forwards it and logs the response.
+// Credentials in headers and password-like fields in JSON bodies are
+// redacted.
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]any{
+		"method":  req.Method,
+		"path":    req.URL.RequestURI(),
+		"headers": tenable.RedactHeaders(req.Header),
+	}
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			fields["body"] = redactBody(b)
+		}
+	}
+	logCtx := tenable.RequestLogContext(req, t.ctx)
+	tflog.Debug(logCtx, "Tenable API request", fields)
+
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		tflog.Debug(logCtx, "Tenable API request failed", map[string]any{
+			"method":     req.Method,
+			"path":       req.URL.RequestURI(),
+			"latency_ms": latency.Milliseconds(),
+			"error":      err.Error(),
+		})
+		return nil, err
+	}
+
+	// Only the logged prefix of the body is read here; the rest is
+	// passed through as it arrives, so that streamed downloads are not
+	// buffered in memory.
+	b, readErr := io.ReadAll(io.LimitReader(resp.Body, debugBodyLimit+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+	fields = map[string]any{
+		"method":     req.Method,
+		"path":       req.URL.RequestURI(),
+		"status":     resp.StatusCode,
+		"latency_ms": latency.Milliseconds(),
+		"request_id": resp.Header.Get("X-Request-Uuid"),
+		"headers":    tenable.RedactHeaders(resp.Header),
+		"body":       redactBody(b),
+	}
+	if readErr != nil {
+		fields["body_error"] = readErr.Error()
+	}
+	tflog.Debug(logCtx, "Tenable API response", fields)
+	return resp, nil
+}
+
+// secretFieldPattern matches the string values of password, secret
+// and token fields in JSON text that cannot be decoded, such as a
+// response body cut off at debugBodyLimit.
+var secretFieldPattern = regexp.MustCompile(`(?i)("[^"]*(?:password|secret|token)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// redactBody returns a body for logging.  JSON bodies have the values
+// of password, secret and token fields replaced at any depth; other
+// bodies, including JSON cut off before its end, are logged as text
+// with such fields replaced where they can be recognised.  Both are
+// truncated to debugBodyLimit.
+func redactBody(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err == nil {
+		if redacted, err := json.Marshal(tenable.RedactJSON(v)); err == nil {
+			b = redacted
+		}
+	} else {
+		b = secretFieldPattern.ReplaceAll(b, []byte(`${1}"[REDACTED]"`))
+	}
+	if len(b) > debugBodyLimit {
+		return string(b[:debugBodyLimit]) + "...[truncated]"
+	}
+	return string(b)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// TestDebugTransport verifies that requests and responses are logged
+// with credentials redacted and that the response body is still
+// readable by the client.
+func TestDebugTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Uuid", "req-123")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "username": "alice", "token": "session-token"})
+	}))
+	defer ts.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	client := newTestClient(ts)
+	client.Http.Transport = &debugTransport{ctx: ctx, rt: client.Http.Transport}
+
+	resp, err := client.Request(context.Background(), http.MethodPost, "users", json.RawMessage(`{"username":"alice","password":"hunter2"}`))
+	if err != nil {
+		t.Fatalf("Request error: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(resp, &out); err != nil || out["username"] != "alice" {
+		t.Errorf("response body not passed through: %v", out)
+	}
+
+	got := logs.String()
+	for _, secret := range []string{"hunter2", "secret", "session-token"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("log output contains %q: %s", secret, got)
+		}
+	}
+	for _, want := range []string{"Tenable API request", "Tenable API response", "req-123", `\"username\":\"alice\"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("log output missing %q: %s", want, got)
+		}
+	}
+}
+
+// TestDebugTransportStreamsLargeBody verifies that a response larger
+// than debugBodyLimit is handed to the client before it has been read
+// in full, and that only its prefix is logged.
+func TestDebugTransportStreamsLargeBody(t *testing.T) {
+	release := make(chan struct{})
+	chunk := bytes.Repeat([]byte("a"), 2*debugBodyLimit)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(chunk)
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write(chunk)
+	}))
+	defer ts.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	rt := &debugTransport{ctx: ctx, rt: ts.Client().Transport}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/scans/1/export/2/download", nil)
+
+	done := make(chan *http.Response)
+	go func() {
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Errorf("RoundTrip error: %v", err)
+		}
+		done <- resp
+	}()
+	var resp *http.Response
+	select {
+	case resp = <-done:
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("RoundTrip waited for the whole response body")
+	}
+	close(release)
+	if resp == nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || len(body) != 2*len(chunk) {
+		t.Errorf("read %d bytes, %v; want %d", len(body), err, 2*len(chunk))
+	}
+	if got := logs.String(); !strings.Contains(got, "...[truncated]") || len(got) > 2*debugBodyLimit {
+		t.Errorf("expected a truncated body in %d bytes of log output", len(got))
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	got := redactBody([]byte(`{"users":[{"name":"a","Password":"x","nested":{"secretKey":"y"}}]}`))
+	want := `{"users":[{"Password":"[REDACTED]","name":"a","nested":{"secretKey":"[REDACTED]"}}]}`
+	if got != want {
+		t.Errorf("redactBody = %s, want %s", got, want)
+	}
+	if got := redactBody(bytes.Repeat([]byte("a"), debugBodyLimit+1)); !strings.HasSuffix(got, "...[truncated]") {
+		t.Errorf("expected truncated body")
+	}
+	// JSON cut off at the limit cannot be decoded but is still redacted.
+	got = redactBody([]byte(`{"users":[{"name":"a","password":"hunter2"},{"name":"b","api_token":"abc`))
+	want = `{"users":[{"name":"a","password":"[REDACTED]"},{"name":"b","api_token":"[REDACTED]"`
+	if got != want {
+		t.Errorf("redactBody = %s, want %s", got, want)
+	}
+}
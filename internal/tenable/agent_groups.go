@@ -0,0 +1,79 @@
+package tenable
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Bounds of the backoff while waiting for an agent bulk task.  Bulk
+// group changes usually finish within seconds.
+const (
+	agentTaskMinWait = 500 * time.Millisecond
+	agentTaskMaxWait = 10 * time.Second
+)
+
+// AgentTask is the status of an asynchronous agent bulk operation.
+type AgentTask struct {
+	TaskID  string `json:"task_id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// ListAgentGroupAgents retrieves the agents in the agent group with
+// the given ID.  The agent group details endpoint pages its "agents"
+// property like the agents list.
+func (c *Client) ListAgentGroupAgents(ctx context.Context, groupID int) ([]*Agent, error) {
+	items, err := c.listAll(ctx, fmt.Sprintf("scanners/null/agent-groups/%d", groupID), "agents")
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[Agent](items)
+}
+
+// AddAgentsToGroup adds the agents with the given IDs to an agent
+// group and waits for the bulk task to finish.
+func (c *Client) AddAgentsToGroup(ctx context.Context, groupID int, agentIDs []int) error {
+	return c.agentGroupBulk(ctx, groupID, "add", agentIDs)
+}
+
+// RemoveAgentsFromGroup removes the agents with the given IDs from an
+// agent group and waits for the bulk task to finish.  The agents stay
+// linked to the container.
+func (c *Client) RemoveAgentsFromGroup(ctx context.Context, groupID int, agentIDs []int) error {
+	return c.agentGroupBulk(ctx, groupID, "remove", agentIDs)
+}
+
+// agentGroupBulk starts a bulk add or remove task on an agent group
+// and polls it until it completes.  A task that fails returns an error
+// wrapping ErrJobFailed with the task's message.
+func (c *Client) agentGroupBulk(ctx context.Context, groupID int, op string, agentIDs []int) error {
+	if len(agentIDs) == 0 {
+		return nil
+	}
+	base := fmt.Sprintf("scanners/null/agent-groups/%d/agents/_bulk", groupID)
+	task, err := post[*AgentTask](ctx, c, base+"/"+op, map[string]interface{}{"items": agentIDs})
+	if err != nil {
+		return err
+	}
+	if done, err := JobDone(task.Status); done || err != nil {
+		return agentTaskError(task, err)
+	}
+	task, err = Poll(ctx, PollOptions{MinWait: agentTaskMinWait, MaxWait: agentTaskMaxWait}, func(ctx context.Context) (*AgentTask, bool, error) {
+		t, err := get[*AgentTask](ctx, c, base+"/"+task.TaskID)
+		if err != nil {
+			return nil, false, err
+		}
+		done, err := JobDone(t.Status)
+		return t, done, err
+	})
+	return agentTaskError(task, err)
+}
+
+// agentTaskError adds the message of a failed task to err.
+func agentTaskError(task *AgentTask, err error) error {
+	if err != nil && task != nil && task.Message != "" {
+		return fmt.Errorf("agent task %s: %w: %s", task.TaskID, err, task.Message)
+	}
+	return err
+}
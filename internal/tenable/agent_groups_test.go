@@ -0,0 +1,77 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestClient_AgentGroupBulk verifies that bulk add and remove post the
+// agent IDs and poll the task until it completes, and that a failed
+// task is reported with its message.
+func TestClient_AgentGroupBulk(t *testing.T) {
+	var requests, bodies []string
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, strings.TrimSpace(string(body)))
+			if strings.HasSuffix(r.URL.Path, "/remove") {
+				w.Write([]byte(`{"task_id":"t2","status":"FAILED","message":"agent 9 is not in the group"}`))
+				return
+			}
+			w.Write([]byte(`{"task_id":"t1","status":"NEW"}`))
+		case strings.HasSuffix(r.URL.Path, "/_bulk/t1"):
+			polls++
+			if polls == 1 {
+				w.Write([]byte(`{"task_id":"t1","status":"RUNNING"}`))
+				return
+			}
+			w.Write([]byte(`{"task_id":"t1","status":"COMPLETED"}`))
+		default:
+			w.Write([]byte(`{"id":4,"name":"linux","agents":[{"id":7,"uuid":"a-uuid","name":"web1"}],"pagination":{"total":1}}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	if err := client.AddAgentsToGroup(ctx, 4, []int{7, 8}); err != nil {
+		t.Fatalf("AddAgentsToGroup: %v", err)
+	}
+	err := client.RemoveAgentsFromGroup(ctx, 4, []int{9})
+	if !errors.Is(err, ErrJobFailed) || !strings.Contains(err.Error(), "agent 9 is not in the group") {
+		t.Errorf("RemoveAgentsFromGroup = %v, want ErrJobFailed with the task message", err)
+	}
+	// Nothing to do sends no request.
+	if err := client.RemoveAgentsFromGroup(ctx, 4, nil); err != nil {
+		t.Errorf("RemoveAgentsFromGroup with no agents: %v", err)
+	}
+	agents, err := client.ListAgentGroupAgents(ctx, 4)
+	if err != nil || len(agents) != 1 || agents[0].ID != 7 {
+		t.Fatalf("ListAgentGroupAgents = %v, %v", agents, err)
+	}
+
+	wantRequests := []string{
+		"POST /scanners/null/agent-groups/4/agents/_bulk/add",
+		"GET /scanners/null/agent-groups/4/agents/_bulk/t1",
+		"GET /scanners/null/agent-groups/4/agents/_bulk/t1",
+		"POST /scanners/null/agent-groups/4/agents/_bulk/remove",
+		"GET /scanners/null/agent-groups/4",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	wantBodies := []string{`{"items":[7,8]}`, `{"items":[9]}`}
+	if !reflect.DeepEqual(bodies, wantBodies) {
+		t.Errorf("bodies = %v, want %v", bodies, wantBodies)
+	}
+}
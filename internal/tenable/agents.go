@@ -0,0 +1,26 @@
+package tenable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetAgent returns the linked agent with the given ID.  A missing or
+// unlinked agent fails with an error wrapping ErrNotFound.
+func (c *Client) GetAgent(ctx context.Context, id int) (*Agent, error) {
+	return get[*Agent](ctx, c, fmt.Sprintf("scanners/null/agents/%d", id))
+}
+
+// RenameAgent changes the name the agent is listed under.  The host
+// name the agent reports is not changed.
+func (c *Client) RenameAgent(ctx context.Context, id int, name string) error {
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("scanners/null/agents/%d", id), map[string]interface{}{"name": name})
+}
+
+// UnlinkAgent unlinks an agent from the container.  The agent stops
+// reporting and its record is removed; the software stays installed on
+// the host until it is uninstalled there.
+func (c *Client) UnlinkAgent(ctx context.Context, id int) error {
+	return call(ctx, c, http.MethodDelete, fmt.Sprintf("scanners/null/agents/%d", id), nil)
+}
@@ -0,0 +1,52 @@
+package tenable
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestClient_Agent verifies the agent lifecycle requests.
+func TestClient_Agent(t *testing.T) {
+	var requests, bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		if len(body) > 0 {
+			bodies = append(bodies, strings.TrimSpace(string(body)))
+		}
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":7,"uuid":"a-uuid","name":"web1","platform":"LINUX","status":"on"}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	agent, err := client.GetAgent(ctx, 7)
+	if err != nil || agent.UUID != "a-uuid" || agent.Name != "web1" || len(agent.RawJSON) == 0 {
+		t.Fatalf("GetAgent = %+v, %v", agent, err)
+	}
+	if err := client.RenameAgent(ctx, 7, "web1.example.com"); err != nil {
+		t.Fatalf("RenameAgent: %v", err)
+	}
+	if err := client.UnlinkAgent(ctx, 7); err != nil {
+		t.Fatalf("UnlinkAgent: %v", err)
+	}
+	wantRequests := []string{
+		"GET /scanners/null/agents/7",
+		"PUT /scanners/null/agents/7",
+		"DELETE /scanners/null/agents/7",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	if want := []string{`{"name":"web1.example.com"}`}; !reflect.DeepEqual(bodies, want) {
+		t.Errorf("bodies = %v, want %v", bodies, want)
+	}
+}
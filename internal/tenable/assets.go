@@ -0,0 +1,72 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AssetFilter is a single condition of an asset query, such as
+// sources eq AWS.  Conditions are combined using AND or OR.
+type AssetFilter struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// AssetDeletionJob describes the outcome of a bulk asset deletion
+// request.  The job UUID is only present when Tenable processes the
+// deletion asynchronously.
+type AssetDeletionJob struct {
+	UUID       string
+	AssetCount int
+	RawJSON    json.RawMessage
+}
+
+// UnmarshalJSON decodes a bulk deletion response and keeps it in RawJSON.
+// The job UUID is reported as job_uuid or uuid depending on the API
+// version, and the asset count is nested as response.data.asset_count.
+func (j *AssetDeletionJob) UnmarshalJSON(b []byte) error {
+	var record struct {
+		JobUUID  string `json:"job_uuid"`
+		UUID     string `json:"uuid"`
+		Response struct {
+			Data struct {
+				AssetCount int `json:"asset_count"`
+			} `json:"data"`
+		} `json:"response"`
+	}
+	if err := decodeRecord(b, &record, &j.RawJSON); err != nil {
+		return err
+	}
+	j.UUID = record.JobUUID
+	if j.UUID == "" {
+		j.UUID = record.UUID
+	}
+	j.AssetCount = record.Response.Data.AssetCount
+	return nil
+}
+
+// DeleteAssets submits a bulk asset deletion for every asset matching
+// the filters.  filterType selects how the filters are combined ("and"
+// or "or").  When hardDelete is true the assets are removed
+// permanently instead of being marked as deleted and their licences
+// are released immediately.
+func (c *Client) DeleteAssets(ctx context.Context, filterType string, filters []AssetFilter, hardDelete bool) (*AssetDeletionJob, error) {
+	payload := map[string]interface{}{
+		"query": map[string]interface{}{
+			strings.ToLower(filterType): filters,
+		},
+		"hard_delete": hardDelete,
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "api/v2/assets/bulk-jobs/delete", payload)
+	if err != nil {
+		return nil, err
+	}
+	job := &AssetDeletionJob{}
+	if err := c.do(req, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
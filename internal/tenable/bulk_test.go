@@ -0,0 +1,64 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_GetUsersByIDs verifies ordering, de-duplication, bounded
+// fan-out and the aggregated errors of GetUsersByIDs.
+func TestClient_GetUsersByIDs(t *testing.T) {
+	var running, peak, calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		n := running.Add(1)
+		defer running.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		var id int
+		fmt.Sscanf(r.URL.Path, "/users/%d", &id)
+		if id%5 == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%d,"username":"user%d"}`, id, id)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	ids := []int{3, 1, 5, 2, 3}
+	for i := 6; i <= 24; i++ {
+		ids = append(ids, i)
+	}
+	users, err := client.GetUsersByIDs(context.Background(), ids)
+	var bulk *BulkError
+	if !errors.As(err, &bulk) || len(bulk.Errors) != 4 || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want a BulkError with 4 not found users", err)
+	}
+	if !strings.HasPrefix(err.Error(), "4 requests failed: 5: ") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if len(users) != 19 || users[0].ID != 3 || users[1].ID != 1 || users[2].ID != 2 {
+		t.Errorf("got %d users starting %v, want 19 in request order", len(users), users[:3])
+	}
+	if calls.Load() != 23 {
+		t.Errorf("calls = %d, want 23 (duplicate fetched once)", calls.Load())
+	}
+	if peak.Load() > bulkConcurrency {
+		t.Errorf("peak concurrency = %d, want at most %d", peak.Load(), bulkConcurrency)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetUsersByIDs(ctx, []int{1, 2}); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled GetUsersByIDs = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,101 @@
+package tenable
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache keys of the list endpoints served from listCache.
+const (
+	cacheKeyUsers    = "users"
+	cacheKeyRoles    = "roles"
+	cacheKeyGroups   = "groups"
+	cacheKeyPolicies = "policies"
+)
+
+// listCache keeps the results of list endpoints for the duration of a
+// Terraform run, so that many data sources looking up one user each
+// share a single /users call.  Concurrent callers that miss the cache
+// wait for the first one's request instead of issuing their own.
+// Failed fetches are not cached.
+type listCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	done    chan struct{}
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+// get returns the cached value for key, calling fetch when there is
+// no entry or it is older than ttl.  The fetch runs detached from ctx,
+// so that a caller that gives up does not fail or empty the list for
+// the others waiting on it; each caller stops waiting when its own
+// context ends.
+func (c *listCache) get(ctx context.Context, key string, ttl time.Duration, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok || !(e.expires.IsZero() || time.Now().Before(e.expires)) {
+		e = &cacheEntry{done: make(chan struct{})}
+		if c.entries == nil {
+			c.entries = make(map[string]*cacheEntry)
+		}
+		c.entries[key] = e
+		go c.fill(context.WithoutCancel(ctx), key, e, ttl, fetch)
+	}
+	c.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-e.done:
+		return e.value, e.err
+	}
+}
+
+// fill runs fetch for the entry e of key and releases its waiters.
+// Failed fetches drop the entry, successful ones expire after ttl.
+func (c *listCache) fill(ctx context.Context, key string, e *cacheEntry, ttl time.Duration, fetch func(context.Context) (interface{}, error)) {
+	e.value, e.err = fetch(ctx)
+	c.mu.Lock()
+	if e.err != nil {
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+	} else {
+		e.expires = time.Now().Add(ttl)
+	}
+	c.mu.Unlock()
+	close(e.done)
+}
+
+// invalidate drops the entry for key so the next call fetches it
+// again.  Methods that change users call it so later lookups in the
+// same run see the change.
+func (c *listCache) invalidate(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// cachedList serves a list endpoint through the client's cache when
+// ListCacheTTL is set.  Callers get their own copy of the slice, but
+// the records themselves are shared and must not be modified.  fetch
+// is called with ctx, or with a context detached from it when the list
+// is shared through the cache.
+func cachedList[T any](ctx context.Context, c *Client, key string, fetch func(context.Context) ([]*T, error)) ([]*T, error) {
+	if c.ListCacheTTL <= 0 {
+		return fetch(ctx)
+	}
+	v, err := c.cache.get(ctx, key, c.ListCacheTTL, func(ctx context.Context) (interface{}, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := v.([]*T)
+	return append([]*T(nil), items...), nil
+}
@@ -0,0 +1,126 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_listCache verifies that concurrent list calls share one
+// request, that changing a user invalidates the cached list, and that
+// the cache is off without a TTL.
+func TestClient_listCache(t *testing.T) {
+	var lists atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /users":
+			lists.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "username": "alice"}})
+		case "DELETE /users/1":
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.ListCacheTTL = time.Minute
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if users, err := client.ListUsers(context.Background()); err != nil || len(users) != 1 {
+				t.Errorf("ListUsers = %v, %v", users, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := lists.Load(); got != 1 {
+		t.Errorf("concurrent ListUsers sent %d requests, want 1", got)
+	}
+
+	if err := client.DeleteUser(context.Background(), 1); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	client.ListUsers(context.Background())
+	if got := lists.Load(); got != 2 {
+		t.Errorf("ListUsers after DeleteUser sent %d requests in total, want 2", got)
+	}
+
+	client.ListCacheTTL = 0
+	client.ListUsers(context.Background())
+	client.ListUsers(context.Background())
+	if got := lists.Load(); got != 4 {
+		t.Errorf("uncached ListUsers sent %d requests in total, want 4", got)
+	}
+}
+
+func TestListCache_errorsNotCached(t *testing.T) {
+	var c listCache
+	calls := 0
+	fetch := func(context.Context) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("boom")
+		}
+		return "ok", nil
+	}
+	if _, err := c.get(context.Background(), "k", time.Minute, fetch); err == nil {
+		t.Fatal("expected the first fetch to fail")
+	}
+	if v, err := c.get(context.Background(), "k", time.Minute, fetch); err != nil || v != "ok" {
+		t.Errorf("get after failure = %v, %v", v, err)
+	}
+	if v, _ := c.get(context.Background(), "k", time.Minute, fetch); v != "ok" || calls != 2 {
+		t.Errorf("expected the successful value to be cached, calls = %d", calls)
+	}
+}
+
+// TestListCache_cancelledCallerDoesNotFailOthers verifies that the
+// caller that started a fetch can give up without failing the callers
+// waiting on the same entry.
+func TestListCache_cancelledCallerDoesNotFailOthers(t *testing.T) {
+	var c listCache
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, error) {
+		<-release
+		return "ok", ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.get(ctx, "k", time.Minute, fetch)
+		first <- err
+	}()
+	// Let the first caller start the fetch before the second joins.
+	time.Sleep(20 * time.Millisecond)
+	second := make(chan interface{}, 1)
+	go func() {
+		v, err := c.get(context.Background(), "k", time.Minute, fetch)
+		if err != nil {
+			v = err
+		}
+		second <- v
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+	close(release)
+	if v := <-second; v != "ok" {
+		t.Errorf("waiting caller got %v, want ok", v)
+	}
+	if v, err := c.get(context.Background(), "k", time.Minute, fetch); v != "ok" || err != nil {
+		t.Errorf("cached value = %v, %v", v, err)
+	}
+}
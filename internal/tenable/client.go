@@ -0,0 +1,769 @@
+// Package tenable is a client for the Tenable Vulnerability Management
+// REST API.  It handles authentication, retries, rate limiting and
+// pagination, and decodes responses into typed models for the
+// Terraform provider's resources and data sources.
+package tenable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultBaseURL is the public Tenable cloud endpoint used when a
+// client does not set BaseURL.
+const DefaultBaseURL = "https://cloud.tenable.com"
+
+// environmentBaseURLs maps the provider's environment shortcut onto
+// Tenable cloud endpoints.  Commercial regions share cloud.tenable.com
+// (data residency follows the container, not the hostname); FedRAMP
+// customers use a separate endpoint.
+var environmentBaseURLs = map[string]string{
+	"us":     DefaultBaseURL,
+	"eu":     DefaultBaseURL,
+	"ap":     DefaultBaseURL,
+	"us-fed": "https://fedcloud.tenable.com",
+}
+
+// EnvironmentBaseURL returns the API endpoint of a named Tenable
+// environment such as "eu" or "us-fed".  Names are case-insensitive.
+func EnvironmentBaseURL(name string) (string, bool) {
+	base, ok := environmentBaseURLs[strings.ToLower(strings.TrimSpace(name))]
+	return base, ok
+}
+
+// Environments returns the recognised environment names in sorted
+// order.
+func Environments() []string {
+	names := make([]string, 0, len(environmentBaseURLs))
+	for name := range environmentBaseURLs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Client encapsulates low‑level interactions with the Tenable
+// Vulnerability Management REST API.  It handles HTTP request
+// construction, authentication header insertion, and response
+// decoding.  Each method returns a parsed response or an error.
+type Client struct {
+	AccessKey string
+	SecretKey string
+	Http      *http.Client
+
+	// BaseURL overrides the Tenable API endpoint.  The public cloud
+	// endpoint is used when empty.
+	BaseURL string
+
+	// UserAgent is sent with every request so Tenable audit logs can
+	// attribute traffic to the provider.  Go's default is used when
+	// empty.
+	UserAgent string
+
+	// Username and Password switch the client to session
+	// authentication: the credentials are exchanged for a token via
+	// POST /session and sent as an X-Cookie header instead of
+	// X-ApiKeys.  The token is obtained lazily and renewed when the
+	// API rejects it as expired.
+	Username string
+	Password string
+
+	sessionMu sync.Mutex
+	session   string
+
+	// ImpersonateUsername, when set, makes every request act on behalf
+	// of that user via the X-Impersonate header.  MSSP administrators
+	// use it to manage child accounts without separate API keys.
+	ImpersonateUsername string
+
+	// limiter throttles outgoing requests once SetRateLimit has been
+	// called.  A nil limiter sends requests immediately.
+	limiter *rateLimiter
+
+	// ListCacheTTL keeps the results of ListUsers, ListRoles and
+	// ListGroups for this long, so repeated lookups within a run share
+	// one API call.  Zero disables the cache.
+	ListCacheTTL time.Duration
+	cache        listCache
+
+	// inflight deduplicates concurrent GETs of the same URL.
+	inflight singleflight.Group
+	// etags revalidates GET responses that carried an ETag.
+	etags etagCache
+	// slots caps the calls running at once, see
+	// SetMaxConcurrentRequests.
+	slots semaphore
+
+	// metrics counts the calls made, see Metrics.
+	metrics metrics
+
+	// MaxRetries is the number of times a request is retried after a
+	// transient failure.  Zero disables retries.
+	MaxRetries int
+	// RetryMinWait and RetryMaxWait bound the exponential backoff
+	// between retries: the first retry waits RetryMinWait and each
+	// subsequent wait doubles, capped at RetryMaxWait.
+	RetryMinWait time.Duration
+	RetryMaxWait time.Duration
+
+	// Timeout bounds each attempt of a single-object call, including
+	// reading the response; zero selects DefaultTimeout.  ListTimeout
+	// and TransferTimeout do the same for collection GETs and for
+	// exports, downloads and uploads, see TimeoutClass.  Use
+	// ContextWithTimeout to override them for a single call.
+	Timeout         time.Duration
+	ListTimeout     time.Duration
+	TransferTimeout time.Duration
+}
+
+// newRequest constructs an HTTP request bound to ctx for the given
+// path and optional JSON body.  The path is appended to the base URL and
+// authentication headers are applied.  The caller is responsible for
+// executing the returned request.
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	base := DefaultBaseURL
+	if c.BaseURL != "" {
+		base = c.BaseURL
+	}
+	url := strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+
+	var buf io.Reader
+	if body != nil {
+		b := new(bytes.Buffer)
+		if err := json.NewEncoder(b).Encode(body); err != nil {
+			return nil, err
+		}
+		buf = b
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	// According to Tenable's API documentation, clients must set the
+	// X-ApiKeys header using the access key and secret key for
+	// authentication【507416795845449†L142-L160】.  Session
+	// authenticated clients add their X-Cookie header in do instead,
+	// since the token may be renewed between attempts.
+	if !c.usesSession() {
+		req.Header.Set("X-ApiKeys", fmt.Sprintf("accessKey=%s; secretKey=%s;", c.AccessKey, c.SecretKey))
+	}
+	if c.ImpersonateUsername != "" {
+		req.Header.Set("X-Impersonate", "username="+c.ImpersonateUsername)
+	}
+	return req, nil
+}
+
+// usesSession reports whether the client authenticates with a
+// username and password rather than API keys.
+func (c *Client) usesSession() bool {
+	return c.Username != ""
+}
+
+// sessionToken returns the current session token, logging in first
+// when there is none.  Concurrent callers share a single login.
+func (c *Client) sessionToken(ctx context.Context) (string, error) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.session == "" {
+		token, err := c.login(ctx)
+		if err != nil {
+			return "", err
+		}
+		c.session = token
+	}
+	return c.session, nil
+}
+
+// expireSession discards the session token if it is still the given
+// stale one, so that the next sessionToken call logs in again.  A
+// token already renewed by another goroutine is kept.
+func (c *Client) expireSession(stale string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.session == stale {
+		c.session = ""
+	}
+}
+
+// login exchanges the username and password for a session token.  It
+// bypasses do so that a rejected login is not retried as an expired
+// session.
+func (c *Client) login(ctx context.Context) (string, error) {
+	credentials := map[string]string{"username": c.Username, "password": c.Password}
+	req, err := c.newRequest(ctx, "POST", "session", credentials)
+	if err != nil {
+		return "", err
+	}
+	// The session belongs to the administrator; impersonation only
+	// applies to the requests made with it.
+	req.Header.Del("X-Impersonate")
+	if err := c.limiter.wait(req.Context()); err != nil {
+		return "", err
+	}
+	attemptReq, cancel := c.withAttemptTimeout(req)
+	resp, err := c.Http.Do(attemptReq)
+	if err != nil {
+		cancel()
+		c.metrics.recordResponse(req, 0)
+		return "", err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	c.metrics.recordResponse(req, resp.StatusCode)
+	defer resp.Body.Close()
+	body, err := responseBody(resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(body)
+		message, _ := parseErrorBody(bodyBytes)
+		return "", fmt.Errorf("session login failed: %s: %s", resp.Status, message)
+	}
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return "", err
+	}
+	if result.Token == "" {
+		return "", fmt.Errorf("session login failed: response did not contain a token")
+	}
+	return result.Token, nil
+}
+
+// do executes the HTTP request and decodes the JSON response into
+// target if provided.  Non‑2xx responses result in an error with the
+// body text included for debugging.  A nil target suppresses decoding
+// entirely.
+//
+// Concurrent GETs of the same URL share one in-flight request: when
+// many resources refresh the same object at once, only the first
+// sends it and the others decode its response.
+func (c *Client) do(req *http.Request, target interface{}) error {
+	var body []byte
+	var err error
+	if req.Method == http.MethodGet {
+		// The shared GET runs detached from the caller that started
+		// it, so that its cancellation does not fail the others; each
+		// attempt is still bounded by the client's timeouts.  Every
+		// caller stops waiting when its own context ends.
+		ctx := req.Context()
+		shared := req.WithContext(context.WithoutCancel(ctx))
+		ch := c.inflight.DoChan(req.URL.String(), func() (interface{}, error) {
+			return c.send(shared)
+		})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res := <-ch:
+			body, _ = res.Val.([]byte)
+			err = res.Err
+		}
+	} else {
+		body, err = c.send(req)
+	}
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return nil
+	}
+	return json.NewDecoder(bytes.NewReader(body)).Decode(target)
+}
+
+// send executes the request and returns the response body.  The
+// request holds a concurrency slot until its body has been read,
+// including any retries and session renewal.  GETs are revalidated
+// against a remembered response when the API provided an ETag.
+func (c *Client) send(req *http.Request) ([]byte, error) {
+	if err := c.slots.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	defer c.slots.release()
+	etagKey := c.etags.prepare(req)
+	data, ok, err := c.sendOnce(req, etagKey)
+	if err == nil && !ok {
+		// The remembered body was evicted while the revalidation was
+		// in flight, so the 304 cannot be served; fetch it in full.
+		req.Header.Del("If-None-Match")
+		data, _, err = c.sendOnce(req, etagKey)
+	}
+	return data, err
+}
+
+// sendOnce performs one round trip for send and resolves its body
+// against the ETag cache.
+func (c *Client) sendOnce(req *http.Request, etagKey string) ([]byte, bool, error) {
+	resp, body, err := c.roundTrip(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, false, err
+	}
+	data, ok := c.etags.resolve(etagKey, resp, data)
+	return data, ok, nil
+}
+
+// roundTrip executes the request with retries and session renewal.  On
+// success it returns the response, whose body the caller must close,
+// and a reader for its decompressed content.  Non‑2xx responses result
+// in an *APIError, except 304 to a request with If-None-Match.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, io.Reader, error) {
+	var resp *http.Response
+	sent, reauthenticated := false, false
+	for attempt := 0; ; attempt++ {
+		// Rewind the request body before resending it.
+		if sent && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			req.Body = body
+		}
+		var token string
+		if c.usesSession() {
+			var err error
+			if token, err = c.sessionToken(req.Context()); err != nil {
+				return nil, nil, err
+			}
+			req.Header.Set("X-Cookie", "token="+token)
+		}
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return nil, nil, err
+		}
+		attemptReq, cancel := c.withAttemptTimeout(req)
+		r, err := c.Http.Do(attemptReq)
+		sent = true
+		if err != nil {
+			cancel()
+			c.metrics.recordResponse(req, 0)
+			if attempt < c.MaxRetries && idempotentMethod(req.Method) && retryableError(err) {
+				c.metrics.recordRetry()
+				if err := sleepContext(req.Context(), jitter(c.retryWait(attempt))); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			return nil, nil, err
+		}
+		r.Body = &cancelOnClose{ReadCloser: r.Body, cancel: cancel}
+		c.metrics.recordResponse(req, r.StatusCode)
+		// An expired session is answered with 401.  Log in again once
+		// and resend without counting it as a retry.
+		if c.usesSession() && r.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			io.Copy(io.Discard, r.Body)
+			r.Body.Close()
+			c.expireSession(token)
+			reauthenticated = true
+			attempt--
+			continue
+		}
+		if attempt < c.MaxRetries && retryableStatus(req.Method, r.StatusCode) {
+			wait := jitter(c.retryWait(attempt))
+			retryAfter, ok := parseRetryAfter(r.Header.Get("Retry-After"), time.Now())
+			if ok && retryAfter > maxRetryAfter {
+				// Waiting this long would stall the apply; surface
+				// the error instead.
+				resp = r
+				break
+			}
+			if ok && retryAfter > wait {
+				wait = retryAfter
+			}
+			// Drain the body so the connection can be reused.
+			io.Copy(io.Discard, r.Body)
+			r.Body.Close()
+			c.metrics.recordRetry()
+			if err := sleepContext(req.Context(), wait); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		resp = r
+		break
+	}
+	body, err := responseBody(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	// 304 answers a revalidation with If-None-Match; the caller serves
+	// the body it remembered.
+	notModified := resp.StatusCode == http.StatusNotModified && req.Header.Get("If-None-Match") != ""
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !notModified {
+		// read body for error message
+		bodyBytes, _ := io.ReadAll(body)
+		resp.Body.Close()
+		message, code := parseErrorBody(bodyBytes)
+		return nil, nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Method:     req.Method,
+			Path:       req.URL.Path,
+			Message:    message,
+			Code:       code,
+			RequestID:  resp.Header.Get("X-Request-Uuid"),
+			Hint:       c.apiErrorHint(req, resp.StatusCode),
+		}
+	}
+	return resp, body, nil
+}
+
+// responseBody returns the decompressed body of resp.  List responses
+// are large, so requests ask for gzip.  http.Transport adds the
+// Accept-Encoding header and decompresses on its own; this covers
+// injected round trippers that pass the compressed body through.
+func responseBody(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing response: %w", err)
+	}
+	return zr, nil
+}
+
+// retryableStatus reports whether a response status signals a
+// transient condition that is worth retrying.  Tenable uses 429 when
+// a client exceeds its rate limit; the request was rejected before
+// being processed, so it is retried for every method.  Server errors
+// (500, 502, 503, 504) may arrive after the request took effect, so
+// they are only retried for idempotent methods.
+func retryableStatus(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotentMethod(method)
+	}
+	return false
+}
+
+// idempotentMethod reports whether repeating a request with the given
+// method has the same effect as sending it once.  POST creates objects
+// or triggers jobs in the Tenable API and is never retried blindly.
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryableError reports whether a transport error is likely
+// transient: connection resets and refusals, connections closed
+// mid-response, and timeouts.  Cancellation and TLS verification
+// failures are permanent.
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &certErr) || errors.As(err, &unknownAuthority) || errors.As(err, &hostnameErr) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// jitter spreads a backoff randomly over [d/2, d] so that clients
+// throttled at the same moment do not retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(d-half)+1))
+}
+
+// maxRetryAfter is the longest Retry-After the client waits for before
+// giving up on a request.
+const maxRetryAfter = 5 * time.Minute
+
+// parseRetryAfter parses a Retry-After header given either as a number
+// of seconds or as an HTTP date.  It reports false when the header is
+// absent or malformed.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryWait returns the backoff before the given retry attempt
+// (starting at zero).  The wait doubles with each attempt from
+// RetryMinWait and never exceeds RetryMaxWait.
+func (c *Client) retryWait(attempt int) time.Duration {
+	wait := c.RetryMinWait
+	for i := 0; i < attempt && wait < c.RetryMaxWait; i++ {
+		wait *= 2
+	}
+	if c.RetryMaxWait > 0 && wait > c.RetryMaxWait {
+		wait = c.RetryMaxWait
+	}
+	return wait
+}
+
+// parseErrorBody extracts the human readable message and the error
+// code from a Tenable error body.  Tenable typically responds with a
+// JSON object such as {"statusCode":403,"error":"Forbidden","message":"..."}
+// or {"error":"...","code":...}; when the body is not JSON or carries
+// no message, the raw text is returned as the message.  The code may be
+// a number or a string and is empty when absent.
+func parseErrorBody(body []byte) (message, code string) {
+	var e struct {
+		Error   string          `json:"error"`
+		Message string          `json:"message"`
+		Code    json.RawMessage `json:"code"`
+	}
+	if err := json.Unmarshal(body, &e); err == nil {
+		if len(e.Code) > 0 && string(e.Code) != "null" {
+			if err := json.Unmarshal(e.Code, &code); err != nil {
+				code = string(e.Code)
+			}
+		}
+		switch {
+		case e.Message != "":
+			return e.Message, code
+		case e.Error != "":
+			return e.Error, code
+		}
+	}
+	return strings.TrimSpace(string(body)), code
+}
+
+// endpointRoles names the role Tenable requires for the endpoints
+// under a path segment, for the 403 hint.  Endpoints not listed need a
+// role that depends on the method or on object permissions, and get a
+// generic hint.
+var endpointRoles = map[string]string{
+	"users":    "the Administrator role (permissions 64)",
+	"groups":   "the Administrator role (permissions 64)",
+	"roles":    "the Administrator role (permissions 64)",
+	"assets":   "the Administrator role (permissions 64)",
+	"scans":    "at least the Scan Operator role (permissions 24) and Can Edit permission on the scan",
+	"policies": "at least the Standard role (permissions 32)",
+}
+
+// apiErrorHint returns practitioner guidance for status codes that
+// Tenable commonly uses to signal configuration problems rather than
+// provider bugs.  An empty string is returned for all other codes so
+// the raw API message stands on its own.  Authentication hints name
+// the credentials the client uses: API keys or a username and
+// password.
+func (c *Client) apiErrorHint(req *http.Request, status int) string {
+	endpoint := req.Method + " " + req.URL.Path
+	switch status {
+	case http.StatusUnauthorized:
+		if c.usesSession() {
+			return "The username and password were rejected; verify them and that the user is enabled and allowed to log in with a password."
+		}
+		return "The access_key and secret_key were rejected; verify the API keys are current and belong to an enabled user."
+	case http.StatusForbidden:
+		subject, owner := "The API keys are", "the user that owns the API keys"
+		if c.usesSession() {
+			subject, owner = fmt.Sprintf("The user %q is", c.Username), "that user"
+		}
+		for _, segment := range strings.Split(req.URL.Path, "/") {
+			if role, ok := endpointRoles[segment]; ok {
+				return fmt.Sprintf("%s not permitted to call %s, which requires %s; check the role assigned to %s.", subject, endpoint, role, owner)
+			}
+		}
+		return fmt.Sprintf("%s not permitted to call %s; check the role assigned to %s and its permissions on the object against those the Tenable API documentation lists for the endpoint.", subject, endpoint, owner)
+	case http.StatusConflict:
+		return "The object is locked by another operation, most often a running scan. Wait for the scan to finish or stop it, then re-run terraform apply."
+	case http.StatusPreconditionFailed:
+		return "The Tenable VM license does not cover " + endpoint + ". Check that the container is licensed for this feature and that the asset or user limit has not been reached."
+	}
+	return ""
+}
+
+// listPageSize is the number of records requested per page by listAll.
+const listPageSize = 1000
+
+// listAll fetches every record of a limit/offset paginated list
+// endpoint.  Records are read from the top-level array of the
+// response, or from the array under key when the endpoint wraps its
+// results in an object.  Paging stops at the total reported in a
+// pagination object, at the first short page, or when a page brings
+// no new records, which protects against endpoints that ignore offset
+// and return the full list every time.
+func (c *Client) listAll(ctx context.Context, path, key string) ([]json.RawMessage, error) {
+	var all []json.RawMessage
+	seen := make(map[string]bool)
+	for offset := 0; ; offset += listPageSize {
+		query := url.Values{}
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(listPageSize))
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		req, err := c.newRequest(ctx, http.MethodGet, path+sep+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var page []json.RawMessage
+		total := -1
+		if key == "" {
+			if err := c.do(req, &page); err != nil {
+				return nil, err
+			}
+		} else {
+			var wrapped map[string]json.RawMessage
+			if err := c.do(req, &wrapped); err != nil {
+				return nil, err
+			}
+			if items, ok := wrapped[key]; ok {
+				if err := json.Unmarshal(items, &page); err != nil {
+					return nil, err
+				}
+			}
+			var pagination struct {
+				Total *int `json:"total"`
+			}
+			if p, ok := wrapped["pagination"]; ok && json.Unmarshal(p, &pagination) == nil && pagination.Total != nil {
+				total = *pagination.Total
+			}
+		}
+
+		added := 0
+		for _, item := range page {
+			var record struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if json.Unmarshal(item, &record) == nil && record.ID != nil {
+				if seen[string(record.ID)] {
+					continue
+				}
+				seen[string(record.ID)] = true
+			}
+			all = append(all, item)
+			added++
+		}
+		if len(page) < listPageSize || added == 0 || (total >= 0 && len(all) >= total) {
+			return all, nil
+		}
+	}
+}
+
+// decodeRecord decodes an API record into the modelled fields and
+// keeps the complete record, compacted, in raw, so fields the provider
+// does not model yet remain available.
+func decodeRecord(b []byte, fields interface{}, raw *json.RawMessage) error {
+	if err := json.Unmarshal(b, fields); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return err
+	}
+	*raw = buf.Bytes()
+	return nil
+}
+
+// hasField reports whether the JSON object raw contains key, for
+// telling a missing field from a zero value.
+func hasField(raw json.RawMessage, key string) bool {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) != nil {
+		return false
+	}
+	_, ok := fields[key]
+	return ok
+}
+
+// decodeList decodes each record returned by listAll into a new T.
+func decodeList[T any](items []json.RawMessage) ([]*T, error) {
+	out := make([]*T, 0, len(items))
+	for _, item := range items {
+		v := new(T)
+		if err := json.Unmarshal(item, v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// Request issues an arbitrary request against the Tenable API and
+// returns the raw JSON response.  It backs the generic tenablevm_rest
+// resource for endpoints the provider does not model yet.  A nil
+// body sends no payload, and an empty response body yields a nil
+// result rather than an error.
+func (c *Client) Request(ctx context.Context, method, path string, body json.RawMessage) (json.RawMessage, error) {
+	var payload interface{}
+	if len(body) > 0 {
+		payload = body
+	}
+	req, err := c.newRequest(ctx, method, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	var resp json.RawMessage
+	if err := c.do(req, &resp); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,568 @@
+package tenable
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type rewriteTransport struct {
+	base *url.URL
+	rt   http.RoundTripper
+}
+
+func (r rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u := *req.URL
+	u.Scheme = r.base.Scheme
+	u.Host = r.base.Host
+	req.URL = &u
+	return r.rt.RoundTrip(req)
+}
+
+func newTestClient(ts *httptest.Server) *Client {
+	base, _ := url.Parse(ts.URL)
+	return &Client{
+		AccessKey: "access",
+		SecretKey: "secret",
+		Http:      &http.Client{Transport: rewriteTransport{base: base, rt: ts.Client().Transport}},
+	}
+}
+
+// TestClient_newRequestHeaders verifies that newRequest sets the X-ApiKeys header
+// and Content-Type for JSON bodies.  This ensures API authentication headers
+// conform to Tenable's specification.
+func TestClient_newRequestHeaders(t *testing.T) {
+	client := &Client{
+		AccessKey: "access123",
+		SecretKey: "secret456",
+		Http:      http.DefaultClient,
+	}
+	req, err := client.newRequest(context.Background(), http.MethodGet, "users", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := req.Header.Get("X-ApiKeys"), "accessKey=access123; secretKey=secret456;"; got != want {
+		t.Errorf("X-ApiKeys header = %q, want %q", got, want)
+	}
+	if got, want := req.Header.Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("Content-Type header = %q, want %q", got, want)
+	}
+	if got := req.Header.Get("X-Impersonate"); got != "" {
+		t.Errorf("unexpected X-Impersonate header %q", got)
+	}
+
+	client.ImpersonateUsername = "admin@child.example.com"
+	req, err = client.newRequest(context.Background(), http.MethodGet, "users", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := req.Header.Get("X-Impersonate"), "username=admin@child.example.com"; got != want {
+		t.Errorf("X-Impersonate header = %q, want %q", got, want)
+	}
+}
+
+// TestClient_doErrorHints verifies that well known failure status codes
+// produce targeted guidance and that Tenable's JSON error message is
+// surfaced instead of the raw body.
+func TestClient_doErrorHints(t *testing.T) {
+	cases := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusForbidden, "Administrator role"},
+		{http.StatusConflict, "running scan"},
+		{http.StatusPreconditionFailed, "license"},
+	}
+	for _, tc := range cases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tc.status)
+			json.NewEncoder(w).Encode(map[string]interface{}{"statusCode": tc.status, "error": "Failure", "message": "tenable says no"})
+		}))
+		client := newTestClient(ts)
+		_, err := client.GetUser(context.Background(), 1)
+		ts.Close()
+		if err == nil {
+			t.Fatalf("status %d: expected error", tc.status)
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("status %d: error %q does not contain %q", tc.status, err.Error(), tc.want)
+		}
+		if !strings.Contains(err.Error(), "tenable says no") || strings.Contains(err.Error(), "statusCode") {
+			t.Errorf("status %d: error %q should contain only the API message", tc.status, err.Error())
+		}
+	}
+}
+
+// TestClient_apiErrorHint verifies that 403 hints name the role the
+// endpoint needs, and that 401 and 403 hints refer to the credentials
+// in use.
+func TestClient_apiErrorHint(t *testing.T) {
+	keys := &Client{AccessKey: "access", SecretKey: "secret"}
+	session := &Client{Username: "breakglass", Password: "hunter2"}
+	for _, tc := range []struct {
+		client        *Client
+		status        int
+		method, path  string
+		want, notWant string
+	}{
+		{keys, http.StatusForbidden, http.MethodPost, "/users", "Administrator role (permissions 64)", "Scan Operator"},
+		{keys, http.StatusForbidden, http.MethodPut, "/scans/42", "Scan Operator role (permissions 24)", "Administrator"},
+		{keys, http.StatusForbidden, http.MethodPost, "/tags/values", "the user that owns the API keys", "Administrator"},
+		{session, http.StatusForbidden, http.MethodPost, "/policies", `The user "breakglass" is not permitted to call POST /policies, which requires at least the Standard role`, "API keys"},
+		{keys, http.StatusUnauthorized, http.MethodGet, "/users", "access_key and secret_key were rejected", "username"},
+		{session, http.StatusUnauthorized, http.MethodGet, "/users", "username and password were rejected", "access_key"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		hint := tc.client.apiErrorHint(req, tc.status)
+		if !strings.Contains(hint, tc.want) || strings.Contains(hint, tc.notWant) {
+			t.Errorf("%d %s %s: hint %q, want %q and not %q", tc.status, tc.method, tc.path, hint, tc.want, tc.notWant)
+		}
+	}
+}
+
+// TestClient_doRetries verifies that transient failures are retried up
+// to MaxRetries times and that the request body is resent intact.
+func TestClient_doRetries(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["enabled"] != false {
+			t.Errorf("attempt %d: unexpected body %v (%v)", attempts, body, err)
+		}
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.MaxRetries = 2
+	client.RetryMinWait = time.Millisecond
+	client.RetryMaxWait = 2 * time.Millisecond
+	if err := client.SetUserEnabled(context.Background(), 1, false); err != nil {
+		t.Fatalf("SetUserEnabled error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+
+	attempts = 0
+	client.MaxRetries = 1
+	if err := client.SetUserEnabled(context.Background(), 1, false); err == nil {
+		t.Errorf("expected error once retries are exhausted")
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+// TestClient_doSession verifies that a session authenticated client
+// logs in lazily, sends the token as X-Cookie and logs in again once
+// when the token expires.
+func TestClient_doSession(t *testing.T) {
+	logins := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/session" {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["username"] != "breakglass" || body["password"] != "hunter2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{"error": "Invalid Credentials"})
+				return
+			}
+			logins++
+			json.NewEncoder(w).Encode(map[string]string{"token": "token-" + strconv.Itoa(logins)})
+			return
+		}
+		if r.Header.Get("X-ApiKeys") != "" {
+			t.Errorf("unexpected X-ApiKeys header in session mode")
+		}
+		// The first token is treated as expired.
+		if r.Header.Get("X-Cookie") != "token=token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "username": "alice"}})
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.AccessKey, client.SecretKey = "", ""
+	client.Username, client.Password = "breakglass", "hunter2"
+	users, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers error: %v", err)
+	}
+	if len(users) != 1 || logins != 2 {
+		t.Errorf("got %d users after %d logins, want 1 user after 2 logins", len(users), logins)
+	}
+
+	// The renewed token is reused.
+	if _, err := client.ListUsers(context.Background()); err != nil || logins != 2 {
+		t.Errorf("second ListUsers: err=%v logins=%d", err, logins)
+	}
+
+	client = newTestClient(ts)
+	client.Username, client.Password = "breakglass", "wrong"
+	if _, err := client.ListUsers(context.Background()); err == nil || !strings.Contains(err.Error(), "Invalid Credentials") {
+		t.Errorf("expected login failure, got %v", err)
+	}
+}
+
+// TestClient_doSessionReplaysWrites verifies that a write rejected
+// because the session expired is replayed with its body after logging
+// in again, and that a 401 that persists after the new login is
+// returned instead of logging in repeatedly.
+func TestClient_doSessionReplaysWrites(t *testing.T) {
+	logins := 0
+	valid := "token-2"
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/session" {
+			logins++
+			json.NewEncoder(w).Encode(map[string]string{"token": "token-" + strconv.Itoa(logins)})
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if r.Header.Get("X-Cookie") != "token="+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.AccessKey, client.SecretKey = "", ""
+	client.Username, client.Password = "breakglass", "hunter2"
+	if err := client.SetUserEnabled(context.Background(), 3, false); err != nil {
+		t.Fatalf("SetUserEnabled: %v", err)
+	}
+	if logins != 2 || len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], `"enabled":false`) {
+		t.Errorf("logins = %d, bodies = %q; want 2 logins and the body sent twice", logins, bodies)
+	}
+
+	logins, bodies, valid = 0, nil, "never"
+	client = newTestClient(ts)
+	client.AccessKey, client.SecretKey = "", ""
+	client.Username, client.Password = "breakglass", "hunter2"
+	if err := client.SetUserEnabled(context.Background(), 3, false); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("SetUserEnabled = %v, want ErrUnauthorized", err)
+	}
+	if logins != 2 {
+		t.Errorf("logins = %d, want 2", logins)
+	}
+}
+
+// TestClient_doRetryAfter verifies that a 429 waits for the Retry-After
+// the server asks for, and that an excessive Retry-After is surfaced as
+// an error instead of stalling the request.
+func TestClient_doRetryAfter(t *testing.T) {
+	attempts := 0
+	retryAfter := "1"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.MaxRetries = 2
+	client.RetryMinWait = time.Millisecond
+	client.RetryMaxWait = time.Millisecond
+	start := time.Now()
+	if err := client.SetUserEnabled(context.Background(), 1, true); err != nil {
+		t.Fatalf("SetUserEnabled error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", elapsed)
+	}
+
+	attempts = 0
+	retryAfter = "3600"
+	if err := client.SetUserEnabled(context.Background(), 1, true); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited for an excessive Retry-After, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Duration{
+		"5":                             5 * time.Second,
+		"0":                             0,
+		"Mon, 01 Apr 2024 12:00:30 GMT": 30 * time.Second,
+		"Mon, 01 Apr 2024 11:00:00 GMT": 0,
+	} {
+		if got, ok := parseRetryAfter(value, now); !ok || got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s", value, got, ok, want)
+		}
+	}
+	for _, value := range []string{"", "-1", "soon"} {
+		if _, ok := parseRetryAfter(value, now); ok {
+			t.Errorf("parseRetryAfter(%q) unexpectedly ok", value)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("sleepContext on a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+// TestClient_doRetriesServerErrors verifies that 5xx responses and
+// dropped connections are retried for idempotent requests, while a
+// POST that may already have taken effect is not.
+func TestClient_doRetriesServerErrors(t *testing.T) {
+	attempts := 0
+	failures := []int{http.StatusInternalServerError, http.StatusBadGateway, 0, http.StatusGatewayTimeout}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= len(failures) {
+			if failures[attempts-1] == 0 {
+				// Drop the connection without a response.
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			w.WriteHeader(failures[attempts-1])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.MaxRetries = len(failures)
+	client.RetryMinWait = time.Millisecond
+	client.RetryMaxWait = 2 * time.Millisecond
+	if _, err := client.ListUsers(context.Background()); err != nil {
+		t.Fatalf("ListUsers error: %v", err)
+	}
+	if attempts != len(failures)+1 {
+		t.Errorf("attempts = %d, want %d", attempts, len(failures)+1)
+	}
+
+	attempts = 0
+	if _, err := client.CreateUser(context.Background(), "alice", "password", 16, "", "", "local", true); err == nil {
+		t.Errorf("expected CreateUser to fail on the first 500")
+	}
+	if attempts != 1 {
+		t.Errorf("POST attempts = %d, want 1", attempts)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if got := jitter(time.Second); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("jitter(1s) = %s, want within [500ms, 1s]", got)
+		}
+	}
+	if got := jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %s, want 0", got)
+	}
+}
+
+// TestClient_listAllPagination verifies that list endpoints are read
+// page by page until complete, and that endpoints ignoring offset do
+// not cause an endless loop.
+func TestClient_listAllPagination(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/scanners/null/agents":
+			const total = 2500
+			var agents []map[string]interface{}
+			for i := offset; i < offset+limit && i < total; i++ {
+				agents = append(agents, map[string]interface{}{"id": i + 1, "name": fmt.Sprintf("agent-%d", i+1)})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"agents":     agents,
+				"pagination": map[string]interface{}{"total": total, "offset": offset, "limit": limit},
+			})
+		case "/users":
+			// Ignores offset and always returns the same full page.
+			users := make([]map[string]interface{}, listPageSize)
+			for i := range users {
+				users[i] = map[string]interface{}{"id": i + 1, "username": fmt.Sprintf("user-%d", i+1)}
+			}
+			json.NewEncoder(w).Encode(users)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	agents, err := client.ListAgents(context.Background())
+	if err != nil {
+		t.Fatalf("ListAgents error: %v", err)
+	}
+	if len(agents) != 2500 || agents[2499].ID != 2500 || requests != 3 {
+		t.Errorf("got %d agents in %d requests, want 2500 in 3", len(agents), requests)
+	}
+
+	requests = 0
+	users, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers error: %v", err)
+	}
+	if len(users) != listPageSize || requests != 2 {
+		t.Errorf("got %d users in %d requests, want %d in 2", len(users), requests, listPageSize)
+	}
+}
+
+// TestClient_retryWait verifies the exponential backoff is capped.
+func TestClient_retryWait(t *testing.T) {
+	client := &Client{RetryMinWait: time.Second, RetryMaxWait: 5 * time.Second}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if got := client.retryWait(attempt); got != want {
+			t.Errorf("retryWait(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
+
+// TestClient_doGzip verifies that compressed responses are requested
+// and decoded, both when http.Transport decompresses them and when an
+// injected round tripper passes the compressed body through.
+func TestClient_doGzip(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		json.NewEncoder(zw).Encode(map[string]interface{}{"id": 7, "username": "alice"})
+		zw.Close()
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	user, err := client.GetUser(context.Background(), 7)
+	if err != nil || user.Username != "alice" {
+		t.Fatalf("GetUser = %+v, %v", user, err)
+	}
+
+	// Asking for gzip explicitly stops http.Transport from
+	// decompressing, as a custom round tripper would.
+	client.Http.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("Accept-Encoding", "gzip")
+		return rewriteTransport{base: mustParseURL(ts.URL), rt: ts.Client().Transport}.RoundTrip(req)
+	})
+	user, err = client.GetUser(context.Background(), 7)
+	if err != nil || user.Username != "alice" {
+		t.Fatalf("GetUser through custom transport = %+v, %v", user, err)
+	}
+}
+
+func mustParseURL(raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// TestClient_doSharesInflightGets verifies that concurrent GETs of the
+// same URL are sent once and that every caller gets the response.
+func TestClient_doSharesInflightGets(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "username": "alice"})
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if user, err := client.GetUser(context.Background(), 1); err != nil || user.Username != "alice" {
+				t.Errorf("GetUser = %+v, %v", user, err)
+			}
+		}()
+	}
+	// Give the callers time to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if got := hits.Load(); got != 1 {
+		t.Errorf("concurrent GetUser sent %d requests, want 1", got)
+	}
+}
+
+// TestClient_doInflightGetSurvivesCancel verifies that cancelling the
+// caller that started a shared GET fails only that caller.
+func TestClient_doInflightGetSurvivesCancel(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "username": "alice"})
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.GetUser(ctx, 1)
+		first <- err
+	}()
+	<-started
+	second := make(chan error, 1)
+	go func() {
+		user, err := client.GetUser(context.Background(), 1)
+		if err == nil && user.Username != "alice" {
+			err = fmt.Errorf("username = %q", user.Username)
+		}
+		second <- err
+	}()
+	// Give the second caller time to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("waiting caller failed with the cancelled caller: %v", err)
+	}
+}
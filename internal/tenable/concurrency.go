@@ -0,0 +1,39 @@
+package tenable
+
+import "context"
+
+// semaphore bounds the number of requests a Client has in flight.  A
+// nil semaphore admits every request.
+type semaphore chan struct{}
+
+// SetMaxConcurrentRequests caps the number of API calls the client
+// runs at once, regardless of how many resources Terraform operates on
+// in parallel.  Calls beyond the cap wait for a running one to finish.
+// Zero or less removes the cap.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.slots = nil
+		return
+	}
+	c.slots = make(semaphore, n)
+}
+
+// acquire blocks until a slot is free or ctx is done.
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire.
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
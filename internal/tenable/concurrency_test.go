@@ -0,0 +1,49 @@
+package tenable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_maxConcurrentRequests verifies that no more than the
+// configured number of calls reach the server at once.
+func TestClient_maxConcurrentRequests(t *testing.T) {
+	var running, peak atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	client.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Distinct paths, so the GETs are not shared in flight.
+			if _, err := client.Request(context.Background(), "GET", fmt.Sprintf("users/%d", i), nil); err != nil {
+				t.Errorf("Request: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak concurrent requests = %d, want 2", got)
+	}
+}
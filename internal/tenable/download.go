@@ -0,0 +1,109 @@
+package tenable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// downloadProgressInterval is how often a running download logs its
+// progress.
+const downloadProgressInterval = 5 * time.Second
+
+// Download streams the response of a GET request for path into w
+// without buffering it in memory, logging progress at debug level
+// through ctx.  Exports and scan reports can be hundreds of megabytes.
+// The request is retried like any other GET until the body starts
+// streaming; a failure part way through is returned as an error and
+// leaves w with partial content.  It returns the number of bytes
+// written.
+func (c *Client) Download(ctx context.Context, path string, w io.Writer) (int64, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.slots.acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer c.slots.release()
+	resp, body, err := c.roundTrip(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	progress := &progressWriter{
+		ctx:   ctx,
+		w:     w,
+		path:  req.URL.Path,
+		total: resp.ContentLength,
+		start: time.Now(),
+	}
+	progress.last = progress.start
+	n, err := io.Copy(progress, body)
+	if err != nil {
+		return n, fmt.Errorf("downloading %s: %w", req.URL.Path, err)
+	}
+	tflog.Debug(ctx, "Downloaded from Tenable API", map[string]any{
+		"path":        req.URL.Path,
+		"bytes":       n,
+		"duration_ms": time.Since(progress.start).Milliseconds(),
+	})
+	return n, nil
+}
+
+// DownloadFile streams the response of a GET request for path into
+// filename.  The content is written to a temporary file in the same
+// directory and renamed into place once complete, so a failed download
+// never leaves a truncated file behind.
+func (c *Client) DownloadFile(ctx context.Context, path, filename string) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := c.Download(ctx, path, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, err
+	}
+	return n, os.Rename(tmp.Name(), filename)
+}
+
+// progressWriter counts the bytes written through it and logs the
+// running total every downloadProgressInterval.
+type progressWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	path    string
+	total   int64
+	written int64
+	start   time.Time
+	last    time.Time
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if now := time.Now(); now.Sub(p.last) >= downloadProgressInterval {
+		p.last = now
+		fields := map[string]any{
+			"path":       p.path,
+			"bytes":      p.written,
+			"elapsed_ms": now.Sub(p.start).Milliseconds(),
+		}
+		if p.total > 0 {
+			fields["total_bytes"] = p.total
+		}
+		tflog.Debug(p.ctx, "Downloading from Tenable API", fields)
+	}
+	return n, err
+}
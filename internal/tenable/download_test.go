@@ -0,0 +1,50 @@
+package tenable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClient_Download(t *testing.T) {
+	payload := bytes.Repeat([]byte("report-line\n"), 100000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/7/export/9/download" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(payload)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	n, err := client.Download(ctx, "scans/7/export/9/download", &buf)
+	if err != nil || n != int64(len(payload)) || !bytes.Equal(buf.Bytes(), payload) {
+		t.Fatalf("Download = %d, %v; got %d bytes", n, err, buf.Len())
+	}
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "report.nessus")
+	if _, err := client.DownloadFile(ctx, "scans/7/export/9/download", filename); err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	if got, _ := os.ReadFile(filename); !bytes.Equal(got, payload) {
+		t.Errorf("file content mismatch: %d bytes", len(got))
+	}
+
+	// A failed download leaves neither the target nor a temporary file.
+	missing := filepath.Join(dir, "missing.nessus")
+	if _, err := client.DownloadFile(ctx, "scans/7/export/10/download", missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DownloadFile error = %v, want ErrNotFound", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only report.nessus in %s, got %v", dir, entries)
+	}
+}
@@ -0,0 +1,62 @@
+package tenable
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors for the API failures resources handle specially.
+// An *APIError matches the sentinel for its status code with
+// errors.Is, so callers do not need to inspect status codes.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// APIError is returned by the client for any non-2xx response.
+type APIError struct {
+	// StatusCode and Status are taken from the HTTP response.
+	StatusCode int
+	Status     string
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+	// Message is the error message from the response body, or the raw
+	// body when it is not a JSON error.
+	Message string
+	// Code is the Tenable error code from the response body, or empty.
+	Code string
+	// RequestID is the X-Request-Uuid response header, which Tenable
+	// support uses to trace a request.
+	RequestID string
+	// Hint is practitioner guidance for common configuration
+	// problems, or empty.
+	Hint string
+}
+
+// Error formats the status and message, followed by the hint on a
+// separate paragraph when there is one.
+func (e *APIError) Error() string {
+	if e.Hint != "" {
+		return fmt.Sprintf("API error: %s: %s\n\n%s", e.Status, e.Message, e.Hint)
+	}
+	return fmt.Sprintf("API error: %s: %s", e.Status, e.Message)
+}
+
+// Is reports whether the error matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+	return false
+}
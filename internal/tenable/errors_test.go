@@ -0,0 +1,64 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAPIError verifies that API failures surface as *APIError and
+// match the sentinel errors for their status codes, also when wrapped.
+func TestAPIError(t *testing.T) {
+	status := http.StatusNotFound
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Uuid", "req-42")
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"error":"User not found","code":40401}`)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	_, err := client.GetUser(context.Background(), 42)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error %v (%T) is not an *APIError", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Method != http.MethodGet || apiErr.Path != "/users/42" ||
+		apiErr.Message != "User not found" || apiErr.Code != "40401" || apiErr.RequestID != "req-42" {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+	if !errors.Is(fmt.Errorf("reading user: %w", err), ErrNotFound) {
+		t.Errorf("wrapped 404 does not match ErrNotFound")
+	}
+	if errors.Is(err, ErrForbidden) {
+		t.Errorf("404 unexpectedly matches ErrForbidden")
+	}
+
+	for code, sentinel := range map[int]error{
+		http.StatusUnauthorized:    ErrUnauthorized,
+		http.StatusForbidden:       ErrForbidden,
+		http.StatusTooManyRequests: ErrRateLimited,
+	} {
+		status = code
+		if _, err := client.GetUser(context.Background(), 42); !errors.Is(err, sentinel) {
+			t.Errorf("status %d: error %v does not match %v", code, err, sentinel)
+		}
+	}
+}
+
+func TestParseErrorBody(t *testing.T) {
+	for body, want := range map[string][2]string{
+		`{"statusCode":403,"error":"Forbidden","message":"Insufficient permissions"}`: {"Insufficient permissions", ""},
+		`{"error":"Invalid filter","code":"INVALID_FILTER"}`:                          {"Invalid filter", "INVALID_FILTER"},
+		`{"error":"Duplicate","code":409}`:                                            {"Duplicate", "409"},
+		"upstream timed out\n":                                                        {"upstream timed out", ""},
+	} {
+		message, code := parseErrorBody([]byte(body))
+		if message != want[0] || code != want[1] {
+			t.Errorf("parseErrorBody(%s) = %q, %q; want %q, %q", body, message, code, want[0], want[1])
+		}
+	}
+}
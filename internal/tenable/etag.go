@@ -0,0 +1,105 @@
+package tenable
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// etagCacheSize is the default number of responses etagCache keeps.
+// It covers the lists a large configuration refreshes while bounding
+// the memory held by a long-running provider process.
+const etagCacheSize = 256
+
+// etagCache remembers the bodies of GET responses that carried an
+// ETag, keyed by URL.  Later GETs of the same URL send the ETag in
+// If-None-Match, and a 304 Not Modified answer is served from the
+// remembered body, so unchanged lists are not transferred again on
+// every plan and apply.  Because the server validates every request,
+// entries never go stale and need no expiry; the least recently used
+// entry is evicted once the cache is full.
+type etagCache struct {
+	mu sync.Mutex
+	// size caps the number of entries; zero means etagCacheSize.
+	size    int
+	order   *list.List // of *etagEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type etagEntry struct {
+	key  string
+	etag string
+	body []byte
+}
+
+// prepare adds If-None-Match to a GET whose URL has a remembered
+// response and returns the cache key for resolve.  The key is taken
+// before sending, since round trippers may rewrite the URL.
+func (c *etagCache) prepare(req *http.Request) string {
+	if req.Method != http.MethodGet {
+		return ""
+	}
+	key := req.URL.String()
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	var etag string
+	if ok {
+		etag = el.Value.(*etagEntry).etag
+	}
+	c.mu.Unlock()
+	if ok {
+		req.Header.Set("If-None-Match", etag)
+	}
+	return key
+}
+
+// resolve returns the body to use for the response to the GET with
+// the given key: the remembered one for 304 Not Modified, otherwise
+// body, which is remembered when the response carries an ETag.  An
+// empty key, for other methods, returns body unchanged.  It reports
+// false for a 304 whose entry was evicted after prepare, in which
+// case the request must be sent again without If-None-Match.
+func (c *etagCache) resolve(key string, resp *http.Response, body []byte) ([]byte, bool) {
+	if key == "" {
+		return body, true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if resp.StatusCode == http.StatusNotModified {
+		if !ok {
+			return nil, false
+		}
+		c.order.MoveToFront(el)
+		return el.Value.(*etagEntry).body, true
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		if ok {
+			c.order.Remove(el)
+			delete(c.entries, key)
+		}
+		return body, true
+	}
+	if ok {
+		e := el.Value.(*etagEntry)
+		e.etag, e.body = etag, body
+		c.order.MoveToFront(el)
+		return body, true
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+		c.order = list.New()
+	}
+	c.entries[key] = c.order.PushFront(&etagEntry{key: key, etag: etag, body: body})
+	size := c.size
+	if size <= 0 {
+		size = etagCacheSize
+	}
+	for c.order.Len() > size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagEntry).key)
+	}
+	return body, true
+}
@@ -0,0 +1,111 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_etagRevalidation verifies that a GET with an ETag is
+// revalidated with If-None-Match and a 304 is served from the earlier
+// response.
+func TestClient_etagRevalidation(t *testing.T) {
+	var conditional []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id":1,"username":"alice"}]`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	for i := 0; i < 2; i++ {
+		users, err := client.ListUsers(context.Background())
+		if err != nil {
+			t.Fatalf("ListUsers #%d: %v", i+1, err)
+		}
+		if len(users) != 1 || users[0].Username != "alice" {
+			t.Errorf("ListUsers #%d = %+v", i+1, users)
+		}
+	}
+	if len(conditional) != 2 || conditional[0] != "" || conditional[1] != `"v1"` {
+		t.Errorf("If-None-Match headers = %q, want none then \"v1\"", conditional)
+	}
+
+	// Writes are never conditional.
+	if _, err := client.Request(context.Background(), http.MethodPut, "users", []byte(`{}`)); err != nil {
+		t.Fatalf("PUT: %v", err)
+	}
+	if got := conditional[len(conditional)-1]; got != "" {
+		t.Errorf("PUT If-None-Match = %q, want none", got)
+	}
+}
+
+// TestClient_etagEviction verifies that the cache keeps at most its
+// configured number of responses, evicting the least recently used.
+func TestClient_etagEviction(t *testing.T) {
+	var conditional []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.URL.Path+" "+r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	client.etags.size = 1
+
+	for _, p := range []string{"users", "groups", "users"} {
+		if _, err := client.Request(context.Background(), http.MethodGet, p, nil); err != nil {
+			t.Fatalf("GET %s: %v", p, err)
+		}
+	}
+	want := []string{"/users ", "/groups ", "/users "}
+	if !reflect.DeepEqual(conditional, want) {
+		t.Errorf("requests = %q, want %q", conditional, want)
+	}
+	if n := len(client.etags.entries); n != 1 {
+		t.Errorf("cache holds %d entries, want 1", n)
+	}
+}
+
+// TestClient_etagNotModifiedAfterEviction verifies that a 304 whose
+// remembered body was evicted in flight is retried without
+// If-None-Match rather than returning an empty body.
+func TestClient_etagNotModifiedAfterEviction(t *testing.T) {
+	var client *Client
+	var conditional []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			client.etags.mu.Lock()
+			client.etags.entries = nil
+			client.etags.mu.Unlock()
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id":1,"username":"alice"}]`))
+	}))
+	defer ts.Close()
+	client = newTestClient(ts)
+
+	for i := 0; i < 2; i++ {
+		users, err := client.ListUsers(context.Background())
+		if err != nil {
+			t.Fatalf("ListUsers #%d: %v", i+1, err)
+		}
+		if len(users) != 1 || users[0].Username != "alice" {
+			t.Errorf("ListUsers #%d = %+v", i+1, users)
+		}
+	}
+	want := []string{"", `"v1"`, ""}
+	if !reflect.DeepEqual(conditional, want) {
+		t.Errorf("If-None-Match headers = %q, want %q", conditional, want)
+	}
+}
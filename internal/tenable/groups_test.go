@@ -0,0 +1,101 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestClient_GroupCRUD verifies the group requests and that changes
+// invalidate the cached group list.
+func TestClient_GroupCRUD(t *testing.T) {
+	groups := map[int]string{}
+	nextID := 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/groups":
+			list := []map[string]interface{}{}
+			for id, name := range groups {
+				list = append(list, map[string]interface{}{"id": id, "name": name})
+			}
+			json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPost && r.URL.Path == "/groups":
+			groups[nextID] = body["name"]
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": nextID, "uuid": "g-uuid", "name": body["name"]})
+			nextID++
+		case r.Method == http.MethodPut && r.URL.Path == "/groups/1":
+			groups[1] = body["name"]
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "uuid": "g-uuid", "name": body["name"]})
+		case r.Method == http.MethodDelete && r.URL.Path == "/groups/1":
+			delete(groups, 1)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	client.ListCacheTTL = time.Minute
+	ctx := context.Background()
+
+	if _, err := client.GetGroup(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetGroup before create = %v, want ErrNotFound", err)
+	}
+	created, err := client.CreateGroup(ctx, "Analysts")
+	if err != nil || created.ID != 1 || created.UUID != "g-uuid" {
+		t.Fatalf("CreateGroup = %+v, %v", created, err)
+	}
+	if g, err := client.GetGroup(ctx, 1); err != nil || g.Name != "Analysts" {
+		t.Fatalf("GetGroup after create = %+v, %v", g, err)
+	}
+	if g, err := client.RenameGroup(ctx, 1, "SOC Analysts"); err != nil || g.Name != "SOC Analysts" {
+		t.Fatalf("RenameGroup = %+v, %v", g, err)
+	}
+	if g, err := client.GetGroup(ctx, 1); err != nil || g.Name != "SOC Analysts" {
+		t.Fatalf("GetGroup after rename = %+v, %v", g, err)
+	}
+	if err := client.DeleteGroup(ctx, 1); err != nil {
+		t.Fatalf("DeleteGroup: %v", err)
+	}
+	if _, err := client.GetGroup(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetGroup after delete = %v, want ErrNotFound", err)
+	}
+}
+
+// TestClient_GroupUsers verifies the membership requests and that the
+// member list is read from the users key.
+func TestClient_GroupUsers(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"users":[{"id":4,"username":"alice@example.com"}]}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	if err := client.AddGroupUser(ctx, 2, 4); err != nil {
+		t.Fatalf("AddGroupUser: %v", err)
+	}
+	users, err := client.ListGroupUsers(ctx, 2)
+	if err != nil || len(users) != 1 || users[0].ID != 4 {
+		t.Fatalf("ListGroupUsers = %v, %v", users, err)
+	}
+	if err := client.RemoveGroupUser(ctx, 2, 4); err != nil {
+		t.Fatalf("RemoveGroupUser: %v", err)
+	}
+	want := []string{"POST /groups/2/users/4", "GET /groups/2/users", "DELETE /groups/2/users/4"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
@@ -0,0 +1,99 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedHeaders carry credentials and are never logged verbatim.
+var redactedHeaders = []string{"X-Apikeys", "X-Cookie", "Authorization", "Proxy-Authorization"}
+
+// LoggingTransport logs the method, path, status and duration of every
+// API call at debug level, so slow or failing endpoints show up in
+// TF_LOG output without enabling full request logging.  Retried
+// attempts are logged individually.  Calls are logged through the
+// request's context, so that they carry the fields of the resource
+// that made them; Ctx, which the provider sets to the context of
+// Configure, is only used for requests whose context has no logger.
+type LoggingTransport struct {
+	Ctx  context.Context
+	Next http.RoundTripper
+}
+
+// RoundTrip forwards the request and logs its outcome.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	fields := map[string]any{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"headers":     RedactHeaders(req.Header),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(RequestLogContext(req, t.Ctx), "Tenable API call failed", fields)
+		return nil, err
+	}
+	fields["status"] = resp.StatusCode
+	fields["request_id"] = resp.Header.Get("X-Request-Uuid")
+	tflog.Debug(RequestLogContext(req, t.Ctx), "Tenable API call", fields)
+	return resp, nil
+}
+
+// RequestLogContext returns the context to log req through: its own,
+// or fallback when it carries no provider logger, as for requests made
+// outside of a Terraform RPC.
+func RequestLogContext(req *http.Request, fallback context.Context) context.Context {
+	ctx := req.Context()
+	// NewSubsystem returns its argument unchanged when there is no
+	// provider root logger to derive the subsystem from.
+	if fallback != nil && tflog.NewSubsystem(ctx, "tenable") == ctx {
+		return fallback
+	}
+	return ctx
+}
+
+// RedactHeaders flattens headers for logging with credential headers
+// replaced by a placeholder.
+func RedactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		out[name] = strings.Join(values, ", ")
+	}
+	for _, name := range redactedHeaders {
+		if _, ok := out[name]; ok {
+			out[name] = "[REDACTED]"
+		}
+	}
+	return out
+}
+
+// RedactJSON replaces the values of password, secret and token fields
+// at any depth of a decoded JSON value, in place, and returns it.
+func RedactJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			key := strings.ToLower(k)
+			if strings.Contains(key, "password") || strings.Contains(key, "secret") || strings.Contains(key, "token") {
+				val[k] = "[REDACTED]"
+				continue
+			}
+			val[k] = RedactJSON(child)
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = RedactJSON(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+package tenable
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// TestLoggingTransport verifies that each call is logged with its
+// method, path, status and duration, and that credentials are masked.
+func TestLoggingTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Uuid", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	client := newTestClient(ts)
+	client.Http.Transport = &LoggingTransport{Ctx: ctx, Next: client.Http.Transport}
+
+	if _, err := client.GetUser(context.Background(), 7); err == nil {
+		t.Fatal("expected an error for a 404")
+	}
+	output := logs.String()
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one log entry, got %d (%v): %s", len(entries), err, output)
+	}
+	entry := entries[0]
+	if entry["@message"] != "Tenable API call" || entry["method"] != "GET" || entry["path"] != "/users/7" ||
+		entry["status"] != float64(404) || entry["request_id"] != "req-123" {
+		t.Errorf("unexpected log entry: %v", entry)
+	}
+	if _, ok := entry["duration_ms"]; !ok {
+		t.Errorf("log entry missing duration_ms: %v", entry)
+	}
+	if strings.Contains(output, "secret") {
+		t.Errorf("log output contains the secret key: %s", output)
+	}
+}
+
+// TestLoggingTransport_requestContext verifies that calls are logged
+// through the logger of the request's context, with its fields, rather
+// than the fallback context.
+func TestLoggingTransport_requestContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var fallbackLogs, requestLogs bytes.Buffer
+	client := newTestClient(ts)
+	client.Http.Transport = &LoggingTransport{Ctx: tflogtest.RootLogger(context.Background(), &fallbackLogs), Next: client.Http.Transport}
+
+	ctx := tflog.SetField(tflogtest.RootLogger(context.Background(), &requestLogs), "tf_resource_type", "tenablevm_user")
+	if _, err := client.GetUser(ctx, 7); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&requestLogs)
+	if err != nil || len(entries) != 1 || entries[0]["tf_resource_type"] != "tenablevm_user" {
+		t.Errorf("expected one entry with the request's fields, got %v (%v)", entries, err)
+	}
+	if fallbackLogs.Len() != 0 {
+		t.Errorf("fallback context used for a request with a logger: %s", fallbackLogs.String())
+	}
+}
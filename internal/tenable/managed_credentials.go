@@ -0,0 +1,135 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Permission levels of a managed credential.  Users and groups with
+// CredentialPermissionUse may attach the credential to their scans;
+// CredentialPermissionEdit also lets them change it.  The owner holds
+// CredentialPermissionOwner, which cannot be granted.
+const (
+	CredentialPermissionUse   = 32
+	CredentialPermissionEdit  = 64
+	CredentialPermissionOwner = 128
+)
+
+// Grantee types of a credential permission.
+const (
+	GranteeUser  = "user"
+	GranteeGroup = "group"
+)
+
+// CredentialPermission grants a user or group, given by Type and the
+// grantee's UUID, a permission level on a managed credential.
+type CredentialPermission struct {
+	GranteeUUID string `json:"grantee_uuid"`
+	Type        string `json:"type"`
+	Permissions int    `json:"permissions"`
+}
+
+// ManagedCredential represents a Tenable VM managed credential, which
+// scans reference by UUID.  Settings holds the credential settings as
+// strings; Tenable masks or leaves out secrets such as passwords.
+// Permissions lists who the credential is shared with, including its
+// owner.
+type ManagedCredential struct {
+	UUID        string
+	Name        string
+	Description string
+	Category    string
+	Type        string
+	Settings    map[string]string
+	Permissions []CredentialPermission
+	RawJSON     json.RawMessage
+}
+
+// ManagedCredentialConfig is the editable configuration of a managed
+// credential.  Type, e.g. SSH or Windows, is only sent on create, as a
+// credential cannot change its type.  Settings that an update leaves
+// out, such as secrets that are not rotated, keep their values.
+// Permissions replaces the grants other than the owner's and is only
+// sent when it is non-nil.
+type ManagedCredentialConfig struct {
+	Name        string
+	Description string
+	Type        string
+	Settings    map[string]string
+	Permissions []CredentialPermission
+}
+
+// payload returns the body of credential create and update requests.
+func (cfg ManagedCredentialConfig) payload(create bool) map[string]interface{} {
+	settings := make(map[string]string, len(cfg.Settings))
+	for k, v := range cfg.Settings {
+		settings[k] = v
+	}
+	body := map[string]interface{}{"name": cfg.Name, "description": cfg.Description, "settings": settings}
+	if create {
+		body["type"] = cfg.Type
+	}
+	if cfg.Permissions != nil {
+		body["permissions"] = cfg.Permissions
+	}
+	return body
+}
+
+// GetManagedCredential returns the managed credential with the given
+// UUID.  A missing credential fails with an error wrapping ErrNotFound.
+func (c *Client) GetManagedCredential(ctx context.Context, uuid string) (*ManagedCredential, error) {
+	raw, err := get[json.RawMessage](ctx, c, "credentials/"+uuid)
+	if err != nil {
+		return nil, err
+	}
+	var wire struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Category    struct {
+			ID string `json:"id"`
+		} `json:"category"`
+		Type struct {
+			ID string `json:"id"`
+		} `json:"type"`
+		Settings    map[string]interface{} `json:"settings"`
+		Permissions []CredentialPermission `json:"permissions"`
+	}
+	cred := &ManagedCredential{UUID: uuid}
+	if err := decodeRecord(raw, &wire, &cred.RawJSON); err != nil {
+		return nil, fmt.Errorf("credential %s: %w", uuid, err)
+	}
+	cred.Name, cred.Description = wire.Name, wire.Description
+	cred.Category, cred.Type = wire.Category.ID, wire.Type.ID
+	cred.Permissions = wire.Permissions
+	cred.Settings = make(map[string]string, len(wire.Settings))
+	for k, v := range wire.Settings {
+		cred.Settings[k] = settingString(v)
+	}
+	return cred, nil
+}
+
+// CreateManagedCredential creates a managed credential from cfg and
+// returns its UUID.
+func (c *Client) CreateManagedCredential(ctx context.Context, cfg ManagedCredentialConfig) (string, error) {
+	resp, err := post[struct {
+		UUID string `json:"uuid"`
+	}](ctx, c, "credentials", cfg.payload(true))
+	if err != nil {
+		return "", err
+	}
+	return resp.UUID, nil
+}
+
+// UpdateManagedCredential changes the configuration of a managed
+// credential.
+func (c *Client) UpdateManagedCredential(ctx context.Context, uuid string, cfg ManagedCredentialConfig) error {
+	return call(ctx, c, http.MethodPut, "credentials/"+uuid, cfg.payload(false))
+}
+
+// DeleteManagedCredential removes a managed credential.  Scans that use
+// it lose the credential.
+func (c *Client) DeleteManagedCredential(ctx context.Context, uuid string) error {
+	return call(ctx, c, http.MethodDelete, "credentials/"+uuid, nil)
+}
@@ -0,0 +1,86 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_ManagedCredentialCRUD verifies the credential requests and
+// their bodies, and that the category and type are read from their
+// IDs.  Permissions are only sent when they are set.
+func TestClient_ManagedCredentialCRUD(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if json.NewDecoder(r.Body).Decode(&body) == nil {
+			bodies = append(bodies, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /credentials":
+			w.Write([]byte(`{"uuid":"cred-uuid"}`))
+		case "GET /credentials/cred-uuid":
+			w.Write([]byte(`{"name":"Linux","description":"","category":{"id":"Host","name":"Host"},"type":{"id":"SSH","name":"SSH"},` +
+				`"settings":{"auth_method":"password","username":"scan","password":"********","elevate_privileges_with":"sudo","port":22},` +
+				`"permissions":[{"grantee_uuid":"owner-uuid","type":"user","permissions":128,"name":"owner@example.com"},{"grantee_uuid":"group-uuid","type":"group","permissions":32,"name":"Scanning"}]}`))
+		case "GET /credentials/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Credential not found"}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	cfg := ManagedCredentialConfig{Name: "Linux", Type: "SSH", Settings: map[string]string{"auth_method": "password", "username": "scan", "password": "s3cret"}}
+	uuid, err := client.CreateManagedCredential(ctx, cfg)
+	if err != nil || uuid != "cred-uuid" {
+		t.Fatalf("CreateManagedCredential = %q, %v", uuid, err)
+	}
+	cred, err := client.GetManagedCredential(ctx, "cred-uuid")
+	if err != nil {
+		t.Fatalf("GetManagedCredential: %v", err)
+	}
+	if cred.UUID != "cred-uuid" || cred.Category != "Host" || cred.Type != "SSH" || cred.Settings["port"] != "22" || cred.Settings["username"] != "scan" || len(cred.RawJSON) == 0 {
+		t.Errorf("GetManagedCredential = %+v", cred)
+	}
+	wantPermissions := []CredentialPermission{
+		{GranteeUUID: "owner-uuid", Type: GranteeUser, Permissions: CredentialPermissionOwner},
+		{GranteeUUID: "group-uuid", Type: GranteeGroup, Permissions: CredentialPermissionUse},
+	}
+	if !reflect.DeepEqual(cred.Permissions, wantPermissions) {
+		t.Errorf("permissions = %+v, want %+v", cred.Permissions, wantPermissions)
+	}
+	if _, err := client.GetManagedCredential(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetManagedCredential of a missing credential = %v, want ErrNotFound", err)
+	}
+	cfg.Settings = map[string]string{"auth_method": "password", "username": "scan2"}
+	cfg.Permissions = []CredentialPermission{{GranteeUUID: "user-uuid", Type: GranteeUser, Permissions: CredentialPermissionEdit}}
+	if err := client.UpdateManagedCredential(ctx, "cred-uuid", cfg); err != nil {
+		t.Fatalf("UpdateManagedCredential: %v", err)
+	}
+	if err := client.DeleteManagedCredential(ctx, "cred-uuid"); err != nil {
+		t.Fatalf("DeleteManagedCredential: %v", err)
+	}
+
+	wantRequests := []string{"POST /credentials", "GET /credentials/cred-uuid", "GET /credentials/missing", "PUT /credentials/cred-uuid", "DELETE /credentials/cred-uuid"}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	// The type is only sent on create.
+	wantBodies := []map[string]interface{}{
+		{"name": "Linux", "description": "", "type": "SSH", "settings": map[string]interface{}{"auth_method": "password", "username": "scan", "password": "s3cret"}},
+		{"name": "Linux", "description": "", "settings": map[string]interface{}{"auth_method": "password", "username": "scan2"},
+			"permissions": []interface{}{map[string]interface{}{"grantee_uuid": "user-uuid", "type": "user", "permissions": float64(64)}}},
+	}
+	if !reflect.DeepEqual(bodies, wantBodies) {
+		t.Errorf("bodies = %v, want %v", bodies, wantBodies)
+	}
+}
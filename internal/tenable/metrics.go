@@ -0,0 +1,97 @@
+package tenable
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// idSegment matches path segments that identify a single object:
+// numeric IDs and UUIDs (with or without dashes).
+var idSegment = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12,})$`)
+
+// metrics counts the API calls a client makes, so the provider can
+// report how much of the API quota a run consumed.
+type metrics struct {
+	mu           sync.Mutex
+	requests     map[string]int
+	retries      int
+	clientErrors int
+	serverErrors int
+}
+
+// MetricsSnapshot is a copy of a client's call counters.
+type MetricsSnapshot struct {
+	// Requests counts attempts per endpoint, keyed by method and path
+	// with object IDs replaced by {id}, e.g. "GET /users/{id}".
+	// Retried attempts are counted individually.
+	Requests map[string]int
+	// Retries is the number of attempts that were retries of an
+	// earlier one, excluding renewals of an expired session.
+	Retries int
+	// ClientErrors and ServerErrors count 4xx and 5xx responses.
+	ClientErrors int
+	ServerErrors int
+}
+
+// Total returns the number of requests sent.
+func (s MetricsSnapshot) Total() int {
+	total := 0
+	for _, n := range s.Requests {
+		total += n
+	}
+	return total
+}
+
+// recordResponse counts an attempt and its response status.  A zero
+// status records an attempt that failed without a response.
+func (m *metrics) recordResponse(req *http.Request, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.requests == nil {
+		m.requests = make(map[string]int)
+	}
+	m.requests[req.Method+" "+endpoint(req.URL.Path)]++
+	switch {
+	case status >= 500:
+		m.serverErrors++
+	case status >= 400:
+		m.clientErrors++
+	}
+}
+
+// recordRetry counts an attempt that is about to be retried.
+func (m *metrics) recordRetry() {
+	m.mu.Lock()
+	m.retries++
+	m.mu.Unlock()
+}
+
+// Metrics returns a snapshot of the client's call counters.
+func (c *Client) Metrics() MetricsSnapshot {
+	c.metrics.mu.Lock()
+	defer c.metrics.mu.Unlock()
+	s := MetricsSnapshot{
+		Requests:     make(map[string]int, len(c.metrics.requests)),
+		Retries:      c.metrics.retries,
+		ClientErrors: c.metrics.clientErrors,
+		ServerErrors: c.metrics.serverErrors,
+	}
+	for k, v := range c.metrics.requests {
+		s.Requests[k] = v
+	}
+	return s
+}
+
+// endpoint replaces the object IDs in path with {id}, so calls for
+// different objects of one kind are counted together.
+func endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if idSegment.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,68 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestClient_Metrics verifies that attempts are counted per endpoint
+// with IDs folded together, and that retries and error responses are
+// tallied.
+func TestClient_Metrics(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/1":
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"id":1}`))
+		case "/users/2":
+			w.Write([]byte(`{"id":2}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	client.MaxRetries = 1
+	client.RetryMinWait = time.Millisecond
+	client.RetryMaxWait = time.Millisecond
+	client.GetUser(context.Background(), 1)
+	client.GetUser(context.Background(), 2)
+	client.DeleteUser(context.Background(), 3)
+
+	got := client.Metrics()
+	want := MetricsSnapshot{
+		Requests:     map[string]int{"GET /users/{id}": 3, "DELETE /users/{id}": 1},
+		Retries:      1,
+		ClientErrors: 1,
+		ServerErrors: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics = %+v, want %+v", got, want)
+	}
+	if got.Total() != 4 {
+		t.Errorf("Total = %d, want 4", got.Total())
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	for path, want := range map[string]string{
+		"/users":            "/users",
+		"/users/42/enabled": "/users/{id}/enabled",
+		"/scans/9f8e7d6c-5b4a-3210-fedc-ba9876543210": "/scans/{id}",
+		"/editor/scan/templates":                      "/editor/scan/templates",
+	} {
+		if got := endpoint(path); got != want {
+			t.Errorf("endpoint(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package tenable
+
+import "net/http"
+
+// Middleware wraps the round tripper that sends the client's
+// requests, to add headers, audit calls or otherwise adjust requests
+// before they leave.  Like any round tripper, a middleware must not
+// modify the request it is given; clone it first.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Use adds middlewares to the client's transport.  Requests pass
+// through them in the order given, before the API call logging and
+// the underlying transport, so headers they add are logged.  Use must
+// be called before the client is shared between goroutines.
+func (c *Client) Use(middlewares ...Middleware) {
+	if c.Http == nil {
+		c.Http = &http.Client{}
+	}
+	rt := c.Http.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	c.Http.Transport = rt
+}
+
+// HeaderMiddleware returns a middleware that sets the given headers
+// on every request, replacing values the request already has.
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for name, values := range headers {
+				req.Header[http.CanonicalHeaderKey(name)] = values
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
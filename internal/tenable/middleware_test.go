@@ -0,0 +1,45 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestClient_middleware verifies that middlewares run in the order
+// given and that HeaderMiddleware adds its headers to every request.
+func TestClient_middleware(t *testing.T) {
+	var got http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer ts.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	client := NewClient("access", "secret",
+		WithBaseURL(ts.URL),
+		WithTransport(ts.Client().Transport),
+		WithMiddleware(trace("first"), HeaderMiddleware(http.Header{"X-Trace-Id": {"abc"}})),
+		WithMiddleware(trace("second")),
+	)
+	if _, err := client.GetUser(context.Background(), 1); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got.Get("X-Trace-Id") != "abc" || got.Get("X-ApiKeys") == "" {
+		t.Errorf("headers = %v, want X-Trace-Id and X-ApiKeys", got)
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("middleware order = %v, want first,second", order)
+	}
+}
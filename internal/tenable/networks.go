@@ -0,0 +1,31 @@
+package tenable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DefaultNetworkUUID is the UUID of the network that every scanner
+// belongs to until it is assigned to another one.  The networks API
+// has no way to unassign a scanner; it is moved back here instead.
+const DefaultNetworkUUID = "00000000-0000-0000-0000-000000000000"
+
+// ListNetworkScanners retrieves the scanners and scanner groups
+// assigned to the network with the given UUID.  The list is wrapped in
+// a "scanners" property.
+func (c *Client) ListNetworkScanners(ctx context.Context, networkUUID string) ([]*Scanner, error) {
+	resp, err := get[struct {
+		Scanners []*Scanner `json:"scanners"`
+	}](ctx, c, fmt.Sprintf("networks/%s/scanners", networkUUID))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Scanners, nil
+}
+
+// AssignNetworkScanner assigns a scanner or scanner group to a
+// network, moving it out of the network it was in.
+func (c *Client) AssignNetworkScanner(ctx context.Context, networkUUID, scannerUUID string) error {
+	return call(ctx, c, http.MethodPost, fmt.Sprintf("networks/%s/scanners/%s", networkUUID, scannerUUID), nil)
+}
@@ -0,0 +1,37 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_NetworkScanners verifies the network assignment requests
+// and that the scanner list is read from the scanners key.
+func TestClient_NetworkScanners(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"scanners":[{"id":3,"uuid":"s-uuid","name":"dc1"}]}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	if err := client.AssignNetworkScanner(ctx, "n-uuid", "s-uuid"); err != nil {
+		t.Fatalf("AssignNetworkScanner: %v", err)
+	}
+	scanners, err := client.ListNetworkScanners(ctx, "n-uuid")
+	if err != nil || len(scanners) != 1 || scanners[0].UUID != "s-uuid" {
+		t.Fatalf("ListNetworkScanners = %v, %v", scanners, err)
+	}
+	want := []string{"POST /networks/n-uuid/scanners/s-uuid", "GET /networks/n-uuid/scanners"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
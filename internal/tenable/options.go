@@ -0,0 +1,153 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Defaults applied by NewClient.
+const (
+	DefaultTimeout      = 60 * time.Second
+	DefaultMaxRetries   = 3
+	DefaultRetryMinWait = 1 * time.Second
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// Option configures a client built by NewClient.
+type Option func(*clientSettings)
+
+// clientSettings collects the options that are only applied once all
+// of them are known: the HTTP client is assembled from the transport,
+// logger and middlewares, and the rate limiter is keyed on the final
+// endpoint and identity.
+type clientSettings struct {
+	client      *Client
+	transport   http.RoundTripper
+	logCtx      context.Context
+	rateLimit   float64
+	middlewares []Middleware
+}
+
+// NewClient returns a client for the Tenable API authenticating with
+// the given API keys.  Without options it talks to DefaultBaseURL
+// through a transport from NewTransport, with the default timeout of
+// each endpoint class and DefaultMaxRetries retries.  Pass empty keys together with
+// WithSession for session authentication.
+func NewClient(accessKey, secretKey string, opts ...Option) *Client {
+	s := &clientSettings{
+		client: &Client{
+			AccessKey:    accessKey,
+			SecretKey:    secretKey,
+			MaxRetries:   DefaultMaxRetries,
+			RetryMinWait: DefaultRetryMinWait,
+			RetryMaxWait: DefaultRetryMaxWait,
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	c := s.client
+	transport := s.transport
+	if transport == nil {
+		transport = NewTransport(TransportOptions{})
+	}
+	if s.logCtx != nil {
+		transport = &LoggingTransport{Ctx: s.logCtx, Next: transport}
+	}
+	c.Http = &http.Client{Transport: transport}
+	c.Use(s.middlewares...)
+	if s.rateLimit > 0 {
+		c.SetRateLimit(s.rateLimit)
+	}
+	return c
+}
+
+// WithBaseURL sends requests to base instead of DefaultBaseURL.
+func WithBaseURL(base string) Option {
+	return func(s *clientSettings) { s.client.BaseURL = base }
+}
+
+// WithSession authenticates with a username and password instead of
+// API keys, see Client.Username.
+func WithSession(username, password string) Option {
+	return func(s *clientSettings) {
+		s.client.Username = username
+		s.client.Password = password
+	}
+}
+
+// WithTransport sends requests through rt instead of a transport from
+// NewTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(s *clientSettings) { s.transport = rt }
+}
+
+// WithTimeout bounds each attempt of a single-object call to d, see
+// Client.Timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(s *clientSettings) { s.client.Timeout = d }
+}
+
+// WithListTimeout bounds each attempt of a collection GET to d, see
+// Client.ListTimeout.
+func WithListTimeout(d time.Duration) Option {
+	return func(s *clientSettings) { s.client.ListTimeout = d }
+}
+
+// WithTransferTimeout bounds each attempt of an export, download or
+// upload to d, see Client.TransferTimeout.
+func WithTransferTimeout(d time.Duration) Option {
+	return func(s *clientSettings) { s.client.TransferTimeout = d }
+}
+
+// WithRetries sets how often transient failures are retried and the
+// bounds of the backoff between attempts.
+func WithRetries(maxRetries int, minWait, maxWait time.Duration) Option {
+	return func(s *clientSettings) {
+		s.client.MaxRetries = maxRetries
+		s.client.RetryMinWait = minWait
+		s.client.RetryMaxWait = maxWait
+	}
+}
+
+// WithRateLimit throttles the client to rate requests per second, see
+// Client.SetRateLimit.  A rate of zero or less disables throttling.
+func WithRateLimit(rate float64) Option {
+	return func(s *clientSettings) { s.rateLimit = rate }
+}
+
+// WithLogger logs every API call, through the logger in ctx for
+// requests whose own context has none, see LoggingTransport.
+func WithLogger(ctx context.Context) Option {
+	return func(s *clientSettings) { s.logCtx = ctx }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(s *clientSettings) { s.client.UserAgent = ua }
+}
+
+// WithImpersonation acts on behalf of username, see
+// Client.ImpersonateUsername.
+func WithImpersonation(username string) Option {
+	return func(s *clientSettings) { s.client.ImpersonateUsername = username }
+}
+
+// WithListCacheTTL caches list results for ttl, see
+// Client.ListCacheTTL.
+func WithListCacheTTL(ttl time.Duration) Option {
+	return func(s *clientSettings) { s.client.ListCacheTTL = ttl }
+}
+
+// WithMaxConcurrentRequests caps the calls running at once, see
+// Client.SetMaxConcurrentRequests.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(s *clientSettings) { s.client.SetMaxConcurrentRequests(n) }
+}
+
+// WithMiddleware adds middlewares to the client's transport, see
+// Client.Use.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(s *clientSettings) { s.middlewares = append(s.middlewares, middlewares...) }
+}
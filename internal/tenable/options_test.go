@@ -0,0 +1,92 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestNewClient_defaults verifies the settings of a client built
+// without options.
+func TestNewClient_defaults(t *testing.T) {
+	c := NewClient("access", "secret")
+	if c.AccessKey != "access" || c.SecretKey != "secret" {
+		t.Errorf("keys = %q/%q, want access/secret", c.AccessKey, c.SecretKey)
+	}
+	if c.MaxRetries != DefaultMaxRetries || c.RetryMinWait != DefaultRetryMinWait || c.RetryMaxWait != DefaultRetryMaxWait {
+		t.Errorf("retries = %d %s %s, want defaults", c.MaxRetries, c.RetryMinWait, c.RetryMaxWait)
+	}
+	if c.Http == nil || c.timeout(TimeoutDefault) != DefaultTimeout {
+		t.Fatalf("Http = %+v, timeout %s; want timeout %s", c.Http, c.timeout(TimeoutDefault), DefaultTimeout)
+	}
+	if _, ok := c.Http.Transport.(*http.Transport); !ok {
+		t.Errorf("Transport = %T, want *http.Transport", c.Http.Transport)
+	}
+	if c.limiter != nil {
+		t.Error("limiter set without WithRateLimit")
+	}
+}
+
+// TestNewClient_options verifies that every option is applied and that
+// WithLogger wraps the configured transport.
+func TestNewClient_options(t *testing.T) {
+	rt := roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	c := NewClient("", "",
+		WithSession("alice", "pw"),
+		WithBaseURL("https://example.test"),
+		WithTransport(rt),
+		WithTimeout(5*time.Second),
+		WithRetries(1, time.Millisecond, time.Second),
+		WithRateLimit(10),
+		WithLogger(context.Background()),
+		WithUserAgent("test-agent"),
+		WithImpersonation("bob"),
+		WithListCacheTTL(time.Minute),
+	)
+	if c.Username != "alice" || c.Password != "pw" {
+		t.Errorf("session = %q/%q, want alice/pw", c.Username, c.Password)
+	}
+	if c.BaseURL != "https://example.test" || c.UserAgent != "test-agent" || c.ImpersonateUsername != "bob" {
+		t.Errorf("BaseURL, UserAgent, ImpersonateUsername = %q, %q, %q", c.BaseURL, c.UserAgent, c.ImpersonateUsername)
+	}
+	if c.MaxRetries != 1 || c.RetryMinWait != time.Millisecond || c.RetryMaxWait != time.Second {
+		t.Errorf("retries = %d %s %s, want 1 1ms 1s", c.MaxRetries, c.RetryMinWait, c.RetryMaxWait)
+	}
+	if c.ListCacheTTL != time.Minute {
+		t.Errorf("ListCacheTTL = %s, want 1m", c.ListCacheTTL)
+	}
+	if c.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %s, want 5s", c.Timeout)
+	}
+	lt, ok := c.Http.Transport.(*LoggingTransport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *LoggingTransport", c.Http.Transport)
+	}
+	if _, ok := lt.Next.(roundTripperFunc); !ok {
+		t.Errorf("Next = %T, want the configured transport", lt.Next)
+	}
+	if c.limiter == nil {
+		t.Error("limiter not set by WithRateLimit")
+	}
+}
+
+// TestNewClient_request verifies that a constructed client sends
+// authenticated requests to the configured base URL.
+func TestNewClient_request(t *testing.T) {
+	var gotKeys string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKeys = r.Header.Get("X-ApiKeys")
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("access", "secret", WithBaseURL(ts.URL), WithTransport(ts.Client().Transport))
+	if _, err := c.ListUsers(context.Background()); err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if gotKeys != "accessKey=access; secretKey=secret;" {
+		t.Errorf("X-ApiKeys = %q", gotKeys)
+	}
+}
@@ -0,0 +1,95 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_PermissionCRUD verifies the v3 permission requests and
+// their bodies.
+func TestClient_PermissionCRUD(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if json.NewDecoder(r.Body).Decode(&body) == nil {
+			bodies = append(bodies, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"permission_uuid":"p-uuid"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/access-control/permissions/p-uuid":
+			w.Write([]byte(`{"permission_uuid":"p-uuid","name":"SOC","actions":["CanView"],"subjects":[{"type":"UserGroup","uuid":"g-uuid","name":"SOC"}],"objects":[{"type":"Tag","uuid":"t-uuid","name":"Location,Tokyo"}]}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	p := Permission{
+		Name:     "SOC",
+		Actions:  []string{"CanView"},
+		Subjects: []PermissionEntity{{Type: PermissionSubjectUserGroup, UUID: "g-uuid"}},
+		Objects:  []PermissionEntity{{Type: PermissionObjectTag, UUID: "t-uuid"}},
+	}
+	uuid, err := client.CreatePermission(ctx, p)
+	if err != nil || uuid != "p-uuid" {
+		t.Fatalf("CreatePermission = %q, %v", uuid, err)
+	}
+	got, err := client.GetPermission(ctx, uuid)
+	if err != nil {
+		t.Fatalf("GetPermission: %v", err)
+	}
+	want := p
+	want.UUID = "p-uuid"
+	want.Subjects = []PermissionEntity{{Type: PermissionSubjectUserGroup, UUID: "g-uuid", Name: "SOC"}}
+	want.Objects = []PermissionEntity{{Type: PermissionObjectTag, UUID: "t-uuid", Name: "Location,Tokyo"}}
+	want.RawJSON = got.RawJSON
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("GetPermission = %+v, want %+v", *got, want)
+	}
+	p.Actions = []string{"CanView", "CanScan"}
+	if err := client.UpdatePermission(ctx, uuid, p); err != nil {
+		t.Fatalf("UpdatePermission: %v", err)
+	}
+	if err := client.DeletePermission(ctx, uuid); err != nil {
+		t.Fatalf("DeletePermission: %v", err)
+	}
+	if _, err := client.GetPermission(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPermission of a missing permission = %v, want ErrNotFound", err)
+	}
+
+	wantRequests := []string{
+		"POST /api/v3/access-control/permissions",
+		"GET /api/v3/access-control/permissions/p-uuid",
+		"PUT /api/v3/access-control/permissions/p-uuid",
+		"DELETE /api/v3/access-control/permissions/p-uuid",
+		"GET /api/v3/access-control/permissions/missing",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("got %d request bodies, want 2", len(bodies))
+	}
+	for _, body := range bodies {
+		if _, ok := body["permission_uuid"]; ok {
+			t.Errorf("request body carries permission_uuid: %v", body)
+		}
+		if subjects := body["subjects"].([]interface{}); subjects[0].(map[string]interface{})["type"] != "UserGroup" {
+			t.Errorf("subjects = %v", subjects)
+		}
+	}
+	if actions := bodies[1]["actions"].([]interface{}); len(actions) != 2 {
+		t.Errorf("updated actions = %v", actions)
+	}
+}
@@ -0,0 +1,172 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Policy represents a Tenable VM scan policy, a user-defined template
+// created from an editor template.  Settings holds the editor settings
+// of the policy as strings; see settingString.  Plugins holds the
+// plugin selection by family name.  Owner is the username of the
+// policy's owner.
+type Policy struct {
+	ID           int                           `json:"id"`
+	TemplateUUID string                        `json:"template_uuid"`
+	Name         string                        `json:"name"`
+	Description  string                        `json:"description"`
+	Owner        string                        `json:"owner"`
+	OwnerID      int                           `json:"owner_id"`
+	Settings     map[string]string             `json:"-"`
+	Plugins      map[string]PolicyPluginFamily `json:"-"`
+	RawJSON      json.RawMessage               `json:"-"`
+}
+
+// UnmarshalJSON decodes a policy record and keeps it in RawJSON.
+func (p *Policy) UnmarshalJSON(b []byte) error {
+	type plain Policy
+	return decodeRecord(b, (*plain)(p), &p.RawJSON)
+}
+
+// Plugin selection states of the editor plugins structure.  A family
+// is mixed when only some of its plugins are enabled.
+const (
+	PluginEnabled  = "enabled"
+	PluginDisabled = "disabled"
+	PluginMixed    = "mixed"
+)
+
+// PolicyPluginFamily is the selection of one plugin family in a
+// policy: either the whole family by Status, or single plugins by ID
+// in Individual, in which case the family is mixed.
+type PolicyPluginFamily struct {
+	Status     string            `json:"status"`
+	Individual map[string]string `json:"individual,omitempty"`
+}
+
+// PolicyConfig is the editable configuration of a policy.  Settings are
+// sent next to the name and description, so they must not contain
+// either key.  Only the plugin families in Plugins are changed.
+type PolicyConfig struct {
+	TemplateUUID string
+	Name         string
+	Description  string
+	Settings     map[string]string
+	Plugins      map[string]PolicyPluginFamily
+}
+
+// payload returns the body of policy create and update requests, which
+// nest the settings under the template UUID like scans.
+func (cfg PolicyConfig) payload() map[string]interface{} {
+	settings := make(map[string]interface{}, len(cfg.Settings)+2)
+	for k, v := range cfg.Settings {
+		settings[k] = v
+	}
+	settings["name"] = cfg.Name
+	settings["description"] = cfg.Description
+	body := map[string]interface{}{"uuid": cfg.TemplateUUID, "settings": settings}
+	if len(cfg.Plugins) > 0 {
+		body["plugins"] = cfg.Plugins
+	}
+	return body
+}
+
+// settingString returns the string form of a policy or credential
+// setting.  Tenable returns settings as strings, numbers and booleans,
+// and accepts strings for all of them, so settings are compared as
+// strings.  Lists and objects are returned as compact JSON.
+func settingString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// ListPolicies returns the policies the user can see.  The list is
+// cached like ListGroups.
+func (c *Client) ListPolicies(ctx context.Context) ([]*Policy, error) {
+	return cachedList(ctx, c, cacheKeyPolicies, func(ctx context.Context) ([]*Policy, error) {
+		items, err := c.listAll(ctx, "policies", "policies")
+		if err != nil {
+			return nil, err
+		}
+		return decodeList[Policy](items)
+	})
+}
+
+// GetPolicy returns the policy with the given ID.  Its settings come
+// from the policy details, which lack the owner, so the policy is also
+// looked up in the list.  A missing policy fails with an error wrapping
+// ErrNotFound.
+func (c *Client) GetPolicy(ctx context.Context, id int) (*Policy, error) {
+	details, err := get[struct {
+		UUID     string                        `json:"uuid"`
+		Settings map[string]interface{}        `json:"settings"`
+		Plugins  map[string]PolicyPluginFamily `json:"plugins"`
+	}](ctx, c, fmt.Sprintf("policies/%d", id))
+	if err != nil {
+		return nil, err
+	}
+	policies, err := c.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{ID: id}
+	for _, p := range policies {
+		if p.ID == id {
+			// Cached records are shared; hand out a copy.
+			copied := *p
+			policy = &copied
+			break
+		}
+	}
+	if details.UUID != "" {
+		policy.TemplateUUID = details.UUID
+	}
+	policy.Settings = make(map[string]string, len(details.Settings))
+	for k, v := range details.Settings {
+		policy.Settings[k] = settingString(v)
+	}
+	policy.Plugins = details.Plugins
+	policy.Name = policy.Settings["name"]
+	policy.Description = policy.Settings["description"]
+	return policy, nil
+}
+
+// CreatePolicy creates a policy from cfg and returns its ID.
+func (c *Client) CreatePolicy(ctx context.Context, cfg PolicyConfig) (int, error) {
+	defer c.cache.invalidate(cacheKeyPolicies)
+	resp, err := post[struct {
+		PolicyID int `json:"policy_id"`
+	}](ctx, c, "policies", cfg.payload())
+	if err != nil {
+		return 0, err
+	}
+	return resp.PolicyID, nil
+}
+
+// UpdatePolicy changes the configuration of a policy.  Settings that
+// cfg leaves out keep their values.
+func (c *Client) UpdatePolicy(ctx context.Context, id int, cfg PolicyConfig) error {
+	defer c.cache.invalidate(cacheKeyPolicies)
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("policies/%d", id), cfg.payload())
+}
+
+// DeletePolicy removes a policy.  Tenable refuses to delete a policy
+// that scans still use.
+func (c *Client) DeletePolicy(ctx context.Context, id int) error {
+	defer c.cache.invalidate(cacheKeyPolicies)
+	return call(ctx, c, http.MethodDelete, fmt.Sprintf("policies/%d", id), nil)
+}
@@ -0,0 +1,77 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_PolicyCRUD verifies the policy requests and their bodies,
+// and that settings are read from the details and the owner from the
+// list.
+func TestClient_PolicyCRUD(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if json.NewDecoder(r.Body).Decode(&body) == nil {
+			bodies = append(bodies, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /policies":
+			w.Write([]byte(`{"policy_id":7,"policy_name":"Hardened"}`))
+		case "GET /policies/7":
+			w.Write([]byte(`{"uuid":"t-uuid","settings":{"name":"Hardened","description":"CIS","safe_checks":true,"max_checks":5,"ports":"1-1024"},"plugins":{"Windows":{"status":"disabled"},"General":{"status":"mixed","individual":{"10180":"enabled","11219":"disabled"}}}}`))
+		case "GET /policies":
+			w.Write([]byte(`{"policies":[{"id":7,"template_uuid":"t-uuid","name":"Hardened","owner":"alice@example.com","owner_id":3}]}`))
+		case "GET /policies/8":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Policy not found"}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	cfg := PolicyConfig{TemplateUUID: "t-uuid", Name: "Hardened", Description: "CIS", Settings: map[string]string{"safe_checks": "yes"},
+		Plugins: map[string]PolicyPluginFamily{"Windows": {Status: PluginDisabled}}}
+	id, err := client.CreatePolicy(ctx, cfg)
+	if err != nil || id != 7 {
+		t.Fatalf("CreatePolicy = %d, %v", id, err)
+	}
+	policy, err := client.GetPolicy(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetPolicy: %v", err)
+	}
+	wantSettings := map[string]string{"name": "Hardened", "description": "CIS", "safe_checks": "true", "max_checks": "5", "ports": "1-1024"}
+	if policy.ID != 7 || policy.TemplateUUID != "t-uuid" || policy.Name != "Hardened" || policy.Description != "CIS" ||
+		policy.Owner != "alice@example.com" || !reflect.DeepEqual(policy.Settings, wantSettings) ||
+		policy.Plugins["Windows"].Status != PluginDisabled || policy.Plugins["General"].Individual["11219"] != PluginDisabled {
+		t.Errorf("GetPolicy = %+v", policy)
+	}
+	if _, err := client.GetPolicy(ctx, 8); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPolicy of a missing policy = %v, want ErrNotFound", err)
+	}
+	if err := client.UpdatePolicy(ctx, 7, cfg); err != nil {
+		t.Fatalf("UpdatePolicy: %v", err)
+	}
+	if err := client.DeletePolicy(ctx, 7); err != nil {
+		t.Fatalf("DeletePolicy: %v", err)
+	}
+
+	wantRequests := []string{"POST /policies", "GET /policies/7", "GET /policies", "GET /policies/8", "PUT /policies/7", "DELETE /policies/7"}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	wantBody := map[string]interface{}{"uuid": "t-uuid", "settings": map[string]interface{}{"name": "Hardened", "description": "CIS", "safe_checks": "yes"},
+		"plugins": map[string]interface{}{"Windows": map[string]interface{}{"status": "disabled"}}}
+	if len(bodies) != 2 || !reflect.DeepEqual(bodies[0], wantBody) || !reflect.DeepEqual(bodies[1], wantBody) {
+		t.Errorf("bodies = %v, want %v twice", bodies, wantBody)
+	}
+}
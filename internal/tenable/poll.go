@@ -0,0 +1,74 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default bounds of the backoff between polls in Poll.
+const (
+	DefaultPollMinWait = 1 * time.Second
+	DefaultPollMaxWait = 30 * time.Second
+)
+
+// ErrJobFailed is returned by JobDone for an asynchronous job that
+// ended without completing, such as an aborted export or a cancelled
+// scan.
+var ErrJobFailed = errors.New("job did not complete")
+
+// PollOptions tunes Poll.  Zero values select the defaults.
+type PollOptions struct {
+	// MinWait is the wait before the second check; it doubles after
+	// every further check up to MaxWait.
+	MinWait time.Duration
+	MaxWait time.Duration
+}
+
+// Poll calls check until it reports done or fails, waiting with
+// exponential backoff between calls, and returns check's last value.
+// It is the waiting loop for Tenable's asynchronous jobs: exports,
+// scan launches and agent bulk operations return a job to poll rather
+// than a result.  When ctx is done first, Poll returns the last value
+// together with ctx's error; callers bound the wait with a deadline on
+// ctx.
+func Poll[T any](ctx context.Context, opts PollOptions, check func(context.Context) (T, bool, error)) (T, error) {
+	minWait, maxWait := opts.MinWait, opts.MaxWait
+	if minWait <= 0 {
+		minWait = DefaultPollMinWait
+	}
+	if maxWait <= 0 {
+		maxWait = DefaultPollMaxWait
+	}
+	wait := minWait
+	for {
+		v, done, err := check(ctx)
+		if err != nil || done {
+			return v, err
+		}
+		if err := sleepContext(ctx, jitter(wait)); err != nil {
+			return v, err
+		}
+		if wait *= 2; wait > maxWait {
+			wait = maxWait
+		}
+	}
+}
+
+// JobDone classifies the status of an asynchronous job.  It reports
+// true for statuses that mean the job completed, and an error wrapping
+// ErrJobFailed for statuses that mean it ended otherwise.  Any other
+// status, such as queued, running or processing, means the job is
+// still in progress.  Statuses are compared case-insensitively, since
+// Tenable's endpoints disagree on their case.
+func JobDone(status string) (bool, error) {
+	switch strings.ToLower(status) {
+	case "completed", "complete", "finished", "done", "ready", "imported":
+		return true, nil
+	case "error", "failed", "cancelled", "canceled", "aborted", "stopped", "expired":
+		return false, fmt.Errorf("%w: status %s", ErrJobFailed, status)
+	}
+	return false, nil
+}
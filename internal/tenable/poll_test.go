@@ -0,0 +1,52 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	opts := PollOptions{MinWait: time.Millisecond, MaxWait: 2 * time.Millisecond}
+	statuses := []string{"QUEUED", "PROCESSING", "FINISHED"}
+	calls := 0
+	status, err := Poll(context.Background(), opts, func(context.Context) (string, bool, error) {
+		s := statuses[calls]
+		calls++
+		done, err := JobDone(s)
+		return s, done, err
+	})
+	if status != "FINISHED" || err != nil || calls != 3 {
+		t.Errorf("Poll = %q, %v after %d calls, want FINISHED after 3", status, err, calls)
+	}
+
+	// A failed job stops polling with ErrJobFailed.
+	if _, err := Poll(context.Background(), opts, func(context.Context) (string, bool, error) {
+		done, err := JobDone("cancelled")
+		return "cancelled", done, err
+	}); !errors.Is(err, ErrJobFailed) {
+		t.Errorf("Poll = %v, want ErrJobFailed", err)
+	}
+
+	// A job that never finishes is abandoned when ctx expires.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	status, err = Poll(ctx, opts, func(context.Context) (string, bool, error) {
+		return "running", false, nil
+	})
+	if status != "running" || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Poll = %q, %v; want running, DeadlineExceeded", status, err)
+	}
+}
+
+func TestJobDone(t *testing.T) {
+	for status, want := range map[string]bool{"completed": true, "Ready": true, "running": false, "": false} {
+		if done, err := JobDone(status); done != want || err != nil {
+			t.Errorf("JobDone(%q) = %v, %v; want %v, nil", status, done, err, want)
+		}
+	}
+	if _, err := JobDone("ERROR"); !errors.Is(err, ErrJobFailed) {
+		t.Errorf("JobDone(ERROR) = %v, want ErrJobFailed", err)
+	}
+}
@@ -0,0 +1,129 @@
+package tenable
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Filter operators accepted in the f= parameter of Tenable's list
+// endpoints.
+var filterOperators = []string{
+	"eq", "neq", "match", "nmatch",
+	"gt", "gte", "lt", "lte",
+	"date-eq", "date-gt", "date-lt",
+	"set-has", "set-hasnot",
+}
+
+// QueryFilter is a single f= condition, encoded as field.operator:value.
+type QueryFilter struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// Query builds the filter, sort and paging parameters of Tenable's
+// list endpoints: f=, ft=, sort=, limit= and offset=.  Build it with
+// the chaining methods and call Encode, which validates it, e.g.
+//
+//	q, err := NewQuery().Filter("status", "eq", "on").SortBy("name", false).Encode()
+type Query struct {
+	filters  []QueryFilter
+	matchAny bool
+	sort     []string
+	limit    int
+	offset   int
+	errs     []error
+}
+
+// NewQuery returns an empty query.  Without filters or paging it
+// encodes to an empty string.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Filter adds an f= condition.  Field must not be empty and must not
+// contain a colon, and operator must be one Tenable accepts.
+func (q *Query) Filter(field, operator, value string) *Query {
+	switch {
+	case field == "" || strings.Contains(field, ":"):
+		q.errs = append(q.errs, fmt.Errorf("invalid filter field %q", field))
+	case !slices.Contains(filterOperators, operator):
+		q.errs = append(q.errs, fmt.Errorf("invalid filter operator %q for %s, expected one of %s", operator, field, strings.Join(filterOperators, ", ")))
+	default:
+		q.filters = append(q.filters, QueryFilter{Field: field, Operator: operator, Value: value})
+	}
+	return q
+}
+
+// MatchAny combines the filters with OR (ft=or) instead of the
+// default AND.
+func (q *Query) MatchAny() *Query {
+	q.matchAny = true
+	return q
+}
+
+// SortBy adds a sort key, ascending unless desc is set.  Keys apply
+// in the order they are added.
+func (q *Query) SortBy(field string, desc bool) *Query {
+	if field == "" || strings.ContainsAny(field, ":,") {
+		q.errs = append(q.errs, fmt.Errorf("invalid sort field %q", field))
+		return q
+	}
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+	q.sort = append(q.sort, field+":"+direction)
+	return q
+}
+
+// Page selects limit records starting at offset.  A zero limit leaves
+// the page size to the API.
+func (q *Query) Page(limit, offset int) *Query {
+	if limit < 0 || offset < 0 {
+		q.errs = append(q.errs, fmt.Errorf("invalid page: limit %d, offset %d must not be negative", limit, offset))
+		return q
+	}
+	q.limit, q.offset = limit, offset
+	return q
+}
+
+// Values returns the query parameters, or the errors of the builder
+// calls that were rejected.
+func (q *Query) Values() (url.Values, error) {
+	if len(q.errs) > 0 {
+		return nil, errors.Join(q.errs...)
+	}
+	v := url.Values{}
+	for _, f := range q.filters {
+		v.Add("f", f.Field+"."+f.Operator+":"+f.Value)
+	}
+	if len(q.filters) > 1 && q.matchAny {
+		v.Set("ft", "or")
+	} else if len(q.filters) > 1 {
+		v.Set("ft", "and")
+	}
+	if len(q.sort) > 0 {
+		v.Set("sort", strings.Join(q.sort, ","))
+	}
+	if q.limit > 0 {
+		v.Set("limit", strconv.Itoa(q.limit))
+	}
+	if q.offset > 0 {
+		v.Set("offset", strconv.Itoa(q.offset))
+	}
+	return v, nil
+}
+
+// Encode returns the query string, without a leading "?".
+func (q *Query) Encode() (string, error) {
+	v, err := q.Values()
+	if err != nil {
+		return "", err
+	}
+	return v.Encode(), nil
+}
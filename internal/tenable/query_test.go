@@ -0,0 +1,42 @@
+package tenable
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	got, err := NewQuery().
+		Filter("status", "eq", "on").
+		Filter("name", "match", "web:01").
+		MatchAny().
+		SortBy("name", false).
+		SortBy("last_connect", true).
+		Page(50, 100).
+		Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	v, _ := url.ParseQuery(got)
+	want := url.Values{
+		"f":      {"status.eq:on", "name.match:web:01"},
+		"ft":     {"or"},
+		"sort":   {"name:asc,last_connect:desc"},
+		"limit":  {"50"},
+		"offset": {"100"},
+	}
+	if v.Encode() != want.Encode() {
+		t.Errorf("Encode = %s, want %s", got, want.Encode())
+	}
+
+	if got, err := NewQuery().Encode(); got != "" || err != nil {
+		t.Errorf("empty query = %q, %v", got, err)
+	}
+	if got, _ := NewQuery().Filter("status", "eq", "on").Encode(); got != "f=status.eq%3Aon" {
+		t.Errorf("single filter = %q, want no ft", got)
+	}
+
+	if _, err := NewQuery().Filter("", "eq", "x").Filter("status", "equals", "on").SortBy("a:b", false).Page(-1, 0).Encode(); err == nil {
+		t.Error("expected errors for invalid field, operator, sort and page")
+	}
+}
@@ -0,0 +1,126 @@
+package tenable
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request a Client
+// makes, so concurrent resource operations stay under a single
+// requests-per-second budget.  The bucket
+// holds up to one second worth of tokens, which lets a short burst
+// through immediately after an idle period.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter allowing rate requests per second.
+func newRateLimiter(rate float64) *rateLimiter {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: burst, tokens: burst}
+}
+
+// sharedLimiters holds one limiter per Tenable identity, so provider
+// aliases that authenticate as the same user (for example with a
+// different impersonate_username each) draw from a single budget.
+// Keys are hashes so credentials are not kept in the map.
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = make(map[string]*rateLimiter)
+)
+
+// rateLimiterKey identifies the Tenable identity a client sends
+// requests as: the API access key, or the username for session
+// authentication, on a given endpoint.
+func rateLimiterKey(c *Client) string {
+	identity := "key:" + c.AccessKey
+	if c.usesSession() {
+		identity = "user:" + c.Username
+	}
+	base := DefaultBaseURL
+	if c.BaseURL != "" {
+		base = c.BaseURL
+	}
+	sum := sha256.Sum256([]byte(base + "\x00" + identity))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetRateLimit throttles the client to rate requests per second.  The
+// budget is shared with every other client that sends requests as the
+// same identity to the same endpoint, so it must be called after the
+// credentials and BaseURL are set.
+func (c *Client) SetRateLimit(rate float64) {
+	c.limiter = sharedRateLimiter(rateLimiterKey(c), rate)
+}
+
+// sharedRateLimiter returns the limiter registered for key, creating
+// it with the given rate on first use.  When aliases configure
+// different rates for the same identity, the lowest one applies to
+// all of them.
+func sharedRateLimiter(key string, rate float64) *rateLimiter {
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+	l, ok := sharedLimiters[key]
+	if !ok {
+		l = newRateLimiter(rate)
+		sharedLimiters[key] = l
+		return l
+	}
+	l.mu.Lock()
+	if rate < l.rate {
+		l.rate = rate
+		l.burst = rate
+		if l.burst < 1 {
+			l.burst = 1
+		}
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.mu.Unlock()
+	return l
+}
+
+// reserve takes a token and returns how long the caller must wait
+// before using it.  Tokens may go negative, so callers that arrive
+// while the bucket is empty queue up behind each other instead of
+// all waking at the same moment.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until the caller may send a request or ctx is done.  A
+// nil limiter never blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	d := l.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, d)
+}
@@ -0,0 +1,66 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRateLimiter_reserve verifies that a burst is let through and
+// that further requests are spaced at the configured rate.
+func TestRateLimiter_reserve(t *testing.T) {
+	l := newRateLimiter(2)
+	now := time.Now()
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if got := l.reserve(now); got != want {
+			t.Errorf("reserve %d = %s, want %s", i, got, want)
+		}
+	}
+
+	// After an idle period the bucket refills, but never beyond the
+	// burst size.
+	later := now.Add(10 * time.Second)
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond} {
+		if got := l.reserve(later); got != want {
+			t.Errorf("reserve after idle %d = %s, want %s", i, got, want)
+		}
+	}
+
+	var nilLimiter *rateLimiter
+	if err := nilLimiter.wait(context.Background()); err != nil {
+		t.Errorf("nil limiter wait = %v", err)
+	}
+
+	// A request cancelled while queued is not held until its turn.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait with cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+// TestSharedRateLimiter verifies that clients sending requests as the
+// same identity share a limiter at the lowest configured rate, while
+// other identities get their own.
+func TestSharedRateLimiter(t *testing.T) {
+	a := &Client{AccessKey: "shared-access", ImpersonateUsername: "child-a"}
+	b := &Client{AccessKey: "shared-access", ImpersonateUsername: "child-b"}
+	other := &Client{AccessKey: "other-access"}
+	fed := &Client{AccessKey: "shared-access", BaseURL: "https://fedcloud.tenable.com"}
+
+	la := sharedRateLimiter(rateLimiterKey(a), 10)
+	lb := sharedRateLimiter(rateLimiterKey(b), 4)
+	if la != lb {
+		t.Fatalf("expected aliases with the same access key to share a limiter")
+	}
+	if la.rate != 4 {
+		t.Errorf("shared rate = %v, want the lower rate 4", la.rate)
+	}
+	if sharedRateLimiter(rateLimiterKey(other), 10) == la {
+		t.Errorf("expected a different access key to get its own limiter")
+	}
+	if sharedRateLimiter(rateLimiterKey(fed), 10) == la {
+		t.Errorf("expected a different endpoint to get its own limiter")
+	}
+}
@@ -0,0 +1,71 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestClient_RoleCRUD verifies the role requests, that the privilege
+// list is always sent and that changes invalidate the cached role list.
+func TestClient_RoleCRUD(t *testing.T) {
+	roles := map[int]map[string]interface{}{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/roles":
+			list := []map[string]interface{}{}
+			for _, role := range roles {
+				list = append(list, role)
+			}
+			json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPost && r.URL.Path == "/roles",
+			r.Method == http.MethodPut && r.URL.Path == "/roles/7":
+			if _, ok := body["privileges"].([]interface{}); !ok {
+				t.Errorf("%s: privileges = %v, want a list", r.Method, body["privileges"])
+			}
+			body["id"] = 7
+			body["uuid"] = "r-uuid"
+			roles[7] = body
+			json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodDelete && r.URL.Path == "/roles/7":
+			delete(roles, 7)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	client.ListCacheTTL = time.Minute
+	ctx := context.Background()
+
+	if _, err := client.GetRole(ctx, 7); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetRole before create = %v, want ErrNotFound", err)
+	}
+	created, err := client.CreateRole(ctx, "Scan Operator", "Runs scans", []string{"scan.launch"})
+	if err != nil || created.ID != 7 || created.UUID != "r-uuid" || !reflect.DeepEqual(created.Privileges, []string{"scan.launch"}) {
+		t.Fatalf("CreateRole = %+v, %v", created, err)
+	}
+	if r, err := client.GetRole(ctx, 7); err != nil || r.Name != "Scan Operator" {
+		t.Fatalf("GetRole after create = %+v, %v", r, err)
+	}
+	if r, err := client.UpdateRole(ctx, 7, "Scan Operator", "", nil); err != nil || len(r.Privileges) != 0 {
+		t.Fatalf("UpdateRole = %+v, %v", r, err)
+	}
+	if r, err := client.GetRole(ctx, 7); err != nil || r.Description != "" || len(r.Privileges) != 0 {
+		t.Fatalf("GetRole after update = %+v, %v", r, err)
+	}
+	if err := client.DeleteRole(ctx, 7); err != nil {
+		t.Fatalf("DeleteRole: %v", err)
+	}
+	if _, err := client.GetRole(ctx, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRole after delete = %v, want ErrNotFound", err)
+	}
+}
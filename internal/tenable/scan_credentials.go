@@ -0,0 +1,93 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ScanCredential is a credential attached to a scan.  A managed
+// credential is referenced by UUID; an inline credential carries its
+// Settings, e.g. auth_method, username and password for SSH.  Category
+// and Type name the credential family the way the scan editor does,
+// e.g. "Host" and "SSH".
+//
+// ID is set on credentials read back from a scan and is what removes
+// the credential from it.  Tenable does not return inline settings, so
+// they are never populated on read.
+type ScanCredential struct {
+	ID       string
+	UUID     string
+	Category string
+	Type     string
+	Settings map[string]string
+}
+
+// ScanCredentials describes the credential changes sent with a scan
+// create or update: the credentials to attach and the IDs of attached
+// credentials to remove.
+type ScanCredentials struct {
+	Add    []ScanCredential
+	Delete []string
+}
+
+// payload returns the credentials object of the scans API, which groups
+// the credentials to add by category and type.
+func (c *ScanCredentials) payload() map[string]interface{} {
+	add := make(map[string]map[string][]interface{})
+	for _, cred := range c.Add {
+		if add[cred.Category] == nil {
+			add[cred.Category] = make(map[string][]interface{})
+		}
+		var entry interface{} = map[string]string{"id": cred.UUID}
+		if cred.UUID == "" {
+			entry = cred.Settings
+		}
+		add[cred.Category][cred.Type] = append(add[cred.Category][cred.Type], entry)
+	}
+	del := c.Delete
+	if del == nil {
+		del = []string{}
+	}
+	return map[string]interface{}{"add": add, "edit": map[string]interface{}{}, "delete": del}
+}
+
+// ListScanCredentials returns the credentials attached to a scan.  They
+// are only reported by the scan editor endpoint, which nests them by
+// category and type.
+func (c *Client) ListScanCredentials(ctx context.Context, scanID int) ([]ScanCredential, error) {
+	resp, err := get[struct {
+		Credentials struct {
+			Data []struct {
+				Name  string `json:"name"`
+				Types []struct {
+					Name      string `json:"name"`
+					Instances []struct {
+						ID   json.RawMessage `json:"id"`
+						UUID string          `json:"uuid"`
+					} `json:"instances"`
+				} `json:"types"`
+			} `json:"data"`
+		} `json:"credentials"`
+	}](ctx, c, fmt.Sprintf("editor/scan/%d", scanID))
+	if err != nil {
+		return nil, err
+	}
+	var creds []ScanCredential
+	for _, category := range resp.Credentials.Data {
+		for _, typ := range category.Types {
+			for _, inst := range typ.Instances {
+				// Inline credentials have numeric IDs and managed ones
+				// string IDs.
+				creds = append(creds, ScanCredential{
+					ID:       strings.Trim(string(inst.ID), `"`),
+					UUID:     inst.UUID,
+					Category: category.Name,
+					Type:     typ.Name,
+				})
+			}
+		}
+	}
+	return creds, nil
+}
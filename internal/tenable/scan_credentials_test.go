@@ -0,0 +1,74 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestScanCredentials_payload verifies that credentials are grouped by
+// category and type, managed credentials being sent by UUID.
+func TestScanCredentials_payload(t *testing.T) {
+	creds := &ScanCredentials{
+		Add: []ScanCredential{
+			{UUID: "cred-uuid", Category: "Host", Type: "Windows"},
+			{Category: "Host", Type: "SSH", Settings: map[string]string{"auth_method": "password", "username": "scan"}},
+		},
+		Delete: []string{"12"},
+	}
+	b, err := json.Marshal(ScanConfig{TemplateUUID: "t-uuid", Name: "Weekly", Credentials: creds}.payload())
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+	var body struct {
+		Credentials map[string]interface{} `json:"credentials"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	want := map[string]interface{}{
+		"add": map[string]interface{}{"Host": map[string]interface{}{
+			"Windows": []interface{}{map[string]interface{}{"id": "cred-uuid"}},
+			"SSH":     []interface{}{map[string]interface{}{"auth_method": "password", "username": "scan"}},
+		}},
+		"edit":   map[string]interface{}{},
+		"delete": []interface{}{"12"},
+	}
+	if !reflect.DeepEqual(body.Credentials, want) {
+		t.Errorf("credentials = %v, want %v", body.Credentials, want)
+	}
+	b, _ = json.Marshal(ScanConfig{Name: "Weekly"}.payload())
+	var unset map[string]interface{}
+	if json.Unmarshal(b, &unset); unset["credentials"] != nil {
+		t.Errorf("credentials sent without being set: %v", unset["credentials"])
+	}
+}
+
+// TestClient_ListScanCredentials verifies that the credentials are read
+// from the scan editor.
+func TestClient_ListScanCredentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/editor/scan/42" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"credentials":{"data":[{"name":"Host","types":[` +
+			`{"name":"SSH","instances":[{"id":12,"summary":"scan"}]},` +
+			`{"name":"Windows","instances":[{"id":"cred-uuid","uuid":"cred-uuid"}]}]}]}}`))
+	}))
+	defer ts.Close()
+	creds, err := newTestClient(ts).ListScanCredentials(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("ListScanCredentials: %v", err)
+	}
+	want := []ScanCredential{
+		{ID: "12", Category: "Host", Type: "SSH"},
+		{ID: "cred-uuid", UUID: "cred-uuid", Category: "Host", Type: "Windows"},
+	}
+	if !reflect.DeepEqual(creds, want) {
+		t.Errorf("ListScanCredentials = %+v, want %+v", creds, want)
+	}
+}
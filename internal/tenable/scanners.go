@@ -0,0 +1,165 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Scanner represents a Tenable VM scanner, including the health
+// details reported by the scanners API.  Only commonly used fields are
+// defined; additional fields are captured in RawJSON.
+type Scanner struct {
+	ID              int             `json:"id"`
+	UUID            string          `json:"uuid"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	LastConnect     int64           `json:"last_connect"`
+	LicenseType     string          `json:"-"`
+	LoadedPluginSet string          `json:"loaded_plugin_set"`
+	ScanCount       int             `json:"scan_count"`
+	RawJSON         json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a scanner record and keeps it in RawJSON.  The
+// licence type is nested as license.type.
+func (s *Scanner) UnmarshalJSON(b []byte) error {
+	type plain Scanner
+	var record struct {
+		*plain
+		License struct {
+			Type string `json:"type"`
+		} `json:"license"`
+	}
+	record.plain = (*plain)(s)
+	if err := decodeRecord(b, &record, &s.RawJSON); err != nil {
+		return err
+	}
+	s.LicenseType = record.License.Type
+	return nil
+}
+
+// ListScanners retrieves all scanners linked to the container.  The
+// scanners API wraps the list in a "scanners" property; each scanner
+// record includes its connection status, last_connect timestamp (Unix
+// seconds), licence information, loaded plugin set and the number of
+// scans currently running on it.
+func (c *Client) ListScanners(ctx context.Context) ([]*Scanner, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "scanners", nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Scanners []*Scanner `json:"scanners"`
+	}
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Scanners, nil
+}
+
+// Agent represents a Tenable Nessus Agent linked to the container.
+// Only commonly used fields are defined; additional fields are captured
+// in RawJSON.
+type Agent struct {
+	ID           int             `json:"id"`
+	UUID         string          `json:"uuid"`
+	Name         string          `json:"name"`
+	Platform     string          `json:"platform"`
+	Distro       string          `json:"distro"`
+	IP           string          `json:"ip"`
+	Status       string          `json:"status"`
+	CoreVersion  string          `json:"core_version"`
+	PluginFeedID string          `json:"plugin_feed_id"`
+	LastConnect  int64           `json:"last_connect"`
+	LastScanned  int64           `json:"last_scanned"`
+	RawJSON      json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes an agent record and keeps it in RawJSON.
+func (a *Agent) UnmarshalJSON(b []byte) error {
+	type plain Agent
+	return decodeRecord(b, (*plain)(a), &a.RawJSON)
+}
+
+// ListAgents retrieves the agents linked to the container.  Agents
+// are listed through the scanners API using the "null" scanner
+// placeholder, and the list is wrapped in an "agents" property.  The
+// endpoint pages its results, so the maximum page size is requested.
+func (c *Client) ListAgents(ctx context.Context) ([]*Agent, error) {
+	items, err := c.listAll(ctx, "scanners/null/agents", "agents")
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[Agent](items)
+}
+
+// AgentGroup represents a Tenable VM agent group.  Only commonly used
+// fields are defined; additional fields are captured in RawJSON.
+type AgentGroup struct {
+	ID          int             `json:"id"`
+	UUID        string          `json:"uuid"`
+	Name        string          `json:"name"`
+	AgentsCount int             `json:"agents_count"`
+	RawJSON     json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes an agent group record and keeps it in RawJSON.
+func (g *AgentGroup) UnmarshalJSON(b []byte) error {
+	type plain AgentGroup
+	return decodeRecord(b, (*plain)(g), &g.RawJSON)
+}
+
+// ListAgentGroups retrieves all agent groups.  Like agents, agent
+// groups are listed through the scanners API using the "null" scanner
+// placeholder, and the list is wrapped in a "groups" property.
+func (c *Client) ListAgentGroups(ctx context.Context) ([]*AgentGroup, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "scanners/null/agent-groups", nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Groups []*AgentGroup `json:"groups"`
+	}
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// ScanTemplate represents an editor template that scans and policies
+// are created from.  Templates are identified by UUID; the title is
+// the human readable name shown in the Tenable UI.
+type ScanTemplate struct {
+	UUID        string          `json:"uuid"`
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"desc"`
+	IsAgent     bool            `json:"is_agent"`
+	RawJSON     json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a template record and keeps it in RawJSON.
+func (t *ScanTemplate) UnmarshalJSON(b []byte) error {
+	type plain ScanTemplate
+	return decodeRecord(b, (*plain)(t), &t.RawJSON)
+}
+
+// ListScanTemplates retrieves the editor templates of the given type,
+// either "scan" or "policy".  The editor API wraps the list in a
+// "templates" property.
+func (c *Client) ListScanTemplates(ctx context.Context, templateType string) ([]*ScanTemplate, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("editor/%s/templates", templateType), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Templates []*ScanTemplate `json:"templates"`
+	}
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Templates, nil
+}
@@ -0,0 +1,219 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Scan represents a Tenable VM scan configuration as returned by the
+// scan details endpoint.  Only the fields the provider manages are
+// defined; the rest of the record is captured in RawJSON.  UUID is the
+// schedule UUID that other APIs use to reference the scan.  Agent scans
+// target AgentGroupIDs, the UUIDs of agent groups, instead of Targets.
+// Notification is nil when the scan emails no one.
+type Scan struct {
+	ID            int               `json:"id"`
+	UUID          string            `json:"schedule_uuid"`
+	TemplateUUID  string            `json:"template_uuid"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	Targets       string            `json:"targets"`
+	ScannerID     string            `json:"scanner_uuid"`
+	FolderID      int               `json:"folder_id"`
+	Enabled       bool              `json:"enabled"`
+	RRules        string            `json:"rrules"`
+	StartTime     string            `json:"starttime"`
+	Timezone      string            `json:"timezone"`
+	AgentGroupIDs []string          `json:"agent_group_id"`
+	Notification  *ScanNotification `json:"-"`
+	RawJSON       json.RawMessage   `json:"-"`
+}
+
+// UnmarshalJSON decodes a scan record and keeps it in RawJSON.  The
+// notification properties are flat settings of the record, so they are
+// collected into Notification.
+func (s *Scan) UnmarshalJSON(b []byte) error {
+	type plain Scan
+	if err := decodeRecord(b, (*plain)(s), &s.RawJSON); err != nil {
+		return err
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return err
+	}
+	s.Notification = scanNotificationFromSettings(settings)
+	return nil
+}
+
+// ScanConfig is the editable configuration of a scan, sent when a scan
+// is created or updated.  Empty optional fields are left out, so that
+// Tenable applies its defaults: the cloud scanner and the My Scans
+// folder; updates still send empty targets and scanner to clear them.  The schedule is always sent, so that an empty RRules removes
+// it; Enabled turns the schedule on and off.  Credentials and
+// notifications are only changed when Credentials and Notification are
+// set; a Notification without emails removes the recipients.
+type ScanConfig struct {
+	TemplateUUID  string
+	Name          string
+	Description   string
+	Targets       string
+	ScannerID     string
+	FolderID      int
+	Enabled       bool
+	RRules        string
+	StartTime     string
+	Timezone      string
+	AgentGroupIDs []string
+	Credentials   *ScanCredentials
+	Notification  *ScanNotification
+}
+
+// payload returns the body of scan create and update requests, which
+// nest the settings under the template UUID.
+func (cfg ScanConfig) payload() map[string]interface{} {
+	settings := map[string]interface{}{
+		"name":        cfg.Name,
+		"description": cfg.Description,
+		"enabled":     cfg.Enabled,
+		"rrules":      cfg.RRules,
+		"starttime":   cfg.StartTime,
+		"timezone":    cfg.Timezone,
+	}
+	if cfg.Targets != "" {
+		settings["text_targets"] = cfg.Targets
+	}
+	if cfg.ScannerID != "" {
+		settings["scanner_id"] = cfg.ScannerID
+	}
+	if cfg.FolderID != 0 {
+		settings["folder_id"] = cfg.FolderID
+	}
+	if len(cfg.AgentGroupIDs) > 0 {
+		settings["agent_group_id"] = cfg.AgentGroupIDs
+	}
+	if cfg.Notification != nil {
+		cfg.Notification.applyTo(settings)
+	}
+	body := map[string]interface{}{"uuid": cfg.TemplateUUID, "settings": settings}
+	if cfg.Credentials != nil {
+		body["credentials"] = cfg.Credentials.payload()
+	}
+	return body
+}
+
+// GetScan retrieves the configuration of a scan by its numeric ID.
+// The details endpoint wraps the configuration in an "info" property
+// that does not repeat the ID.
+func (c *Client) GetScan(ctx context.Context, id int) (*Scan, error) {
+	resp, err := get[struct {
+		Info *Scan `json:"info"`
+	}](ctx, c, fmt.Sprintf("scans/%d", id))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Info == nil {
+		return nil, fmt.Errorf("scan %d: response has no info", id)
+	}
+	resp.Info.ID = id
+	return resp.Info, nil
+}
+
+// CreateScan creates a scan from cfg and returns its numeric ID.  The
+// scan is not launched.
+func (c *Client) CreateScan(ctx context.Context, cfg ScanConfig) (int, error) {
+	resp, err := post[struct {
+		Scan struct {
+			ID int `json:"id"`
+		} `json:"scan"`
+	}](ctx, c, "scans", cfg.payload())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Scan.ID, nil
+}
+
+// UpdateScan replaces the configuration of a scan with cfg.  Targets
+// and scanner are sent even when empty, since the API keeps the old
+// values of settings that are left out; clearing targets to switch to
+// agent groups would otherwise leave them on the scan.
+func (c *Client) UpdateScan(ctx context.Context, id int, cfg ScanConfig) error {
+	body := cfg.payload()
+	settings := body["settings"].(map[string]interface{})
+	settings["text_targets"] = cfg.Targets
+	settings["scanner_id"] = cfg.ScannerID
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("scans/%d", id), body)
+}
+
+// DeleteScan removes a scan together with its history.  Tenable refuses
+// to delete a scan that is running.
+func (c *Client) DeleteScan(ctx context.Context, id int) error {
+	return call(ctx, c, http.MethodDelete, fmt.Sprintf("scans/%d", id), nil)
+}
+
+// ScanNotification describes the email notification settings of a
+// scan configuration: who receives the results and which findings
+// trigger the email.  Tenable stores these as flat properties of the
+// scan settings object, so ScanNotification converts to and from that
+// representation rather than being sent on its own.
+type ScanNotification struct {
+	Emails     []string
+	FilterType string
+	Filters    []ScanNotificationFilter
+}
+
+// ScanNotificationFilter is a single result filter applied before a
+// notification email is sent, e.g. severity eq Critical.
+type ScanNotificationFilter struct {
+	Filter  string `json:"filter"`
+	Quality string `json:"quality"`
+	Value   string `json:"value"`
+}
+
+// applyTo writes the notification properties into a scan settings
+// payload.  Tenable expects the recipient list as a comma separated
+// string and the filter conjunction as "and" or "or".
+func (n *ScanNotification) applyTo(settings map[string]interface{}) {
+	settings["emails"] = strings.Join(n.Emails, ",")
+	filterType := n.FilterType
+	if filterType == "" {
+		filterType = "and"
+	}
+	settings["filter_type"] = filterType
+	filters := make([]ScanNotificationFilter, len(n.Filters))
+	copy(filters, n.Filters)
+	settings["filters"] = filters
+}
+
+// scanNotificationFromSettings extracts the notification properties
+// from the settings object returned by the scan details endpoint.  A
+// nil result means the scan has no recipients configured.
+func scanNotificationFromSettings(settings map[string]interface{}) *ScanNotification {
+	emails, _ := settings["emails"].(string)
+	n := &ScanNotification{}
+	for _, e := range strings.Split(emails, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			n.Emails = append(n.Emails, e)
+		}
+	}
+	if len(n.Emails) == 0 {
+		return nil
+	}
+	n.FilterType, _ = settings["filter_type"].(string)
+	if filters, ok := settings["filters"].([]interface{}); ok {
+		for _, f := range filters {
+			m, ok := f.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var filter ScanNotificationFilter
+			filter.Filter, _ = m["filter"].(string)
+			filter.Quality, _ = m["quality"].(string)
+			filter.Value, _ = m["value"].(string)
+			n.Filters = append(n.Filters, filter)
+		}
+	}
+	return n
+}
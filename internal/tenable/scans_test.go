@@ -0,0 +1,177 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestScanNotification_roundTrip verifies that notification settings
+// written into a scan settings payload are parsed back unchanged once
+// they have been through JSON encoding.
+func TestScanNotification_roundTrip(t *testing.T) {
+	in := &ScanNotification{
+		Emails:     []string{"secops@example.com", "oncall@example.com"},
+		FilterType: "or",
+		Filters:    []ScanNotificationFilter{{Filter: "severity", Quality: "eq", Value: "Critical"}},
+	}
+	settings := map[string]interface{}{}
+	in.applyTo(settings)
+	if got, want := settings["emails"], "secops@example.com,oncall@example.com"; got != want {
+		t.Errorf("emails = %v, want %q", got, want)
+	}
+	b, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	out := scanNotificationFromSettings(decoded)
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip mismatch\n got: %+v\nwant: %+v", out, in)
+	}
+	if scanNotificationFromSettings(map[string]interface{}{"emails": ""}) != nil {
+		t.Errorf("expected nil notification for empty recipient list")
+	}
+}
+
+// TestClient_ScanCRUD verifies the scan requests and their bodies, and
+// that the scan details are read from the info property.
+func TestClient_ScanCRUD(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if json.NewDecoder(r.Body).Decode(&body) == nil {
+			bodies = append(bodies, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.Write([]byte(`{"scan":{"id":42,"uuid":"template-uuid-42","name":"Weekly"}}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"info":{"schedule_uuid":"s-uuid","template_uuid":"t-uuid","name":"Weekly","targets":"10.0.0.0/24","folder_id":3,"enabled":true,"rrules":"FREQ=DAILY;INTERVAL=1","starttime":"20240401T093000","timezone":"Asia/Tokyo"},"hosts":[]}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	cfg := ScanConfig{TemplateUUID: "t-uuid", Name: "Weekly", Targets: "10.0.0.0/24", Enabled: true}
+	id, err := client.CreateScan(ctx, cfg)
+	if err != nil || id != 42 {
+		t.Fatalf("CreateScan = %d, %v", id, err)
+	}
+	scan, err := client.GetScan(ctx, 42)
+	if err != nil || scan.ID != 42 || scan.UUID != "s-uuid" || scan.FolderID != 3 || !scan.Enabled || scan.RRules != "FREQ=DAILY;INTERVAL=1" || scan.Timezone != "Asia/Tokyo" || len(scan.RawJSON) == 0 {
+		t.Fatalf("GetScan = %+v, %v", scan, err)
+	}
+	cfg.ScannerID, cfg.FolderID = "scanner-uuid", 3
+	cfg.RRules, cfg.StartTime, cfg.Timezone = "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO", "20240401T093000", "Asia/Tokyo"
+	if err := client.UpdateScan(ctx, 42, cfg); err != nil {
+		t.Fatalf("UpdateScan: %v", err)
+	}
+	if err := client.DeleteScan(ctx, 42); err != nil {
+		t.Fatalf("DeleteScan: %v", err)
+	}
+
+	wantRequests := []string{"POST /scans", "GET /scans/42", "PUT /scans/42", "DELETE /scans/42"}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	// Optional settings are only sent when set, except that updates
+	// always send targets and scanner so that they can be cleared.
+	wantBodies := []map[string]interface{}{
+		{"uuid": "t-uuid", "settings": map[string]interface{}{"name": "Weekly", "description": "", "enabled": true, "text_targets": "10.0.0.0/24",
+			"rrules": "", "starttime": "", "timezone": ""}},
+		{"uuid": "t-uuid", "settings": map[string]interface{}{"name": "Weekly", "description": "", "enabled": true, "text_targets": "10.0.0.0/24", "scanner_id": "scanner-uuid", "folder_id": float64(3),
+			"rrules": "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO", "starttime": "20240401T093000", "timezone": "Asia/Tokyo"}},
+	}
+	if !reflect.DeepEqual(bodies, wantBodies) {
+		t.Errorf("bodies = %v, want %v", bodies, wantBodies)
+	}
+}
+
+// TestClient_UpdateScanClearsTargets verifies that an update sends
+// empty targets and scanner so that clearing them reaches the API.
+func TestClient_UpdateScanClearsTargets(t *testing.T) {
+	var settings map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Settings map[string]interface{} `json:"settings"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		settings = body.Settings
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	cfg := ScanConfig{TemplateUUID: "t-uuid", Name: "Agents", AgentGroupIDs: []string{"group-uuid"}}
+	if err := client.UpdateScan(context.Background(), 42, cfg); err != nil {
+		t.Fatalf("UpdateScan: %v", err)
+	}
+	targets, ok := settings["text_targets"]
+	if !ok || targets != "" {
+		t.Errorf("text_targets = %v (sent %t), want empty string", targets, ok)
+	}
+	scanner, ok := settings["scanner_id"]
+	if !ok || scanner != "" {
+		t.Errorf("scanner_id = %v (sent %t), want empty string", scanner, ok)
+	}
+	if !reflect.DeepEqual(settings["agent_group_id"], []interface{}{"group-uuid"}) {
+		t.Errorf("agent_group_id = %v", settings["agent_group_id"])
+	}
+}
+
+// TestScanConfig_agentGroups verifies that agent scans send their
+// agent groups instead of text targets.
+func TestScanConfig_agentGroups(t *testing.T) {
+	settings := ScanConfig{TemplateUUID: "agent-uuid", Name: "Agents", AgentGroupIDs: []string{"g-1", "g-2"}}.payload()["settings"].(map[string]interface{})
+	if got := settings["agent_group_id"]; !reflect.DeepEqual(got, []string{"g-1", "g-2"}) {
+		t.Errorf("agent_group_id = %v", got)
+	}
+	if _, ok := settings["text_targets"]; ok {
+		t.Errorf("text_targets sent for an agent scan: %v", settings)
+	}
+	if _, ok := settings["emails"]; ok {
+		t.Errorf("emails sent without a notification: %v", settings)
+	}
+	var scan Scan
+	if err := json.Unmarshal([]byte(`{"name":"Agents","agent_group_id":["g-1"]}`), &scan); err != nil || !reflect.DeepEqual(scan.AgentGroupIDs, []string{"g-1"}) {
+		t.Errorf("decoded agent groups = %v, %v", scan.AgentGroupIDs, err)
+	}
+}
+
+// TestScanConfig_notification verifies that notifications are sent as
+// flat scan settings and read back from the scan details.
+func TestScanConfig_notification(t *testing.T) {
+	cfg := ScanConfig{TemplateUUID: "t-uuid", Name: "Weekly", Notification: &ScanNotification{
+		Emails:  []string{"secops@example.com"},
+		Filters: []ScanNotificationFilter{{Filter: "severity", Quality: "eq", Value: "Critical"}},
+	}}
+	settings := cfg.payload()["settings"].(map[string]interface{})
+	if settings["emails"] != "secops@example.com" || settings["filter_type"] != "and" {
+		t.Errorf("notification settings = %v", settings)
+	}
+	var scan Scan
+	b := []byte(`{"name":"Weekly","emails":"secops@example.com, oncall@example.com","filter_type":"or","filters":[{"filter":"severity","quality":"eq","value":"High"}]}`)
+	if err := json.Unmarshal(b, &scan); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	want := &ScanNotification{
+		Emails:     []string{"secops@example.com", "oncall@example.com"},
+		FilterType: "or",
+		Filters:    []ScanNotificationFilter{{Filter: "severity", Quality: "eq", Value: "High"}},
+	}
+	if !reflect.DeepEqual(scan.Notification, want) || scan.Name != "Weekly" {
+		t.Errorf("decoded scan = %+v, notification %+v", scan, scan.Notification)
+	}
+}
@@ -0,0 +1,59 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Health summarizes a ServerStatus.
+type Health string
+
+const (
+	// HealthOK means the platform is ready to serve requests.
+	HealthOK Health = "ok"
+	// HealthDegraded means the platform answered but reported that it
+	// is not ready, for example during maintenance.
+	HealthDegraded Health = "degraded"
+)
+
+// ServerStatus is the platform status reported by /server/status.
+// Additional fields returned by the API are captured in RawJSON.
+type ServerStatus struct {
+	Code    int             `json:"code"`
+	Status  string          `json:"status"`
+	RawJSON json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a server status and keeps it in RawJSON.
+func (s *ServerStatus) UnmarshalJSON(b []byte) error {
+	type plain ServerStatus
+	return decodeRecord(b, (*plain)(s), &s.RawJSON)
+}
+
+// Health reports HealthOK when the platform is ready and
+// HealthDegraded otherwise.
+func (s *ServerStatus) Health() Health {
+	if strings.EqualFold(s.Status, "ready") && (s.Code == 0 || s.Code == http.StatusOK) {
+		return HealthOK
+	}
+	return HealthDegraded
+}
+
+// Ping fetches the platform status from /server/status, a cheap
+// endpoint that confirms the API is reachable through the configured
+// proxy and TLS settings.  It does not verify the credentials.  An
+// error means the API could not be reached or answered with an error;
+// a degraded platform is reported through the returned status.
+func (c *Client) Ping(ctx context.Context) (*ServerStatus, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "server/status", nil)
+	if err != nil {
+		return nil, err
+	}
+	status := &ServerStatus{}
+	if err := c.do(req, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
@@ -0,0 +1,32 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_Ping(t *testing.T) {
+	status := `{"code":200,"status":"ready"}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/server/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(status))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	s, err := client.Ping(context.Background())
+	if err != nil || s.Health() != HealthOK {
+		t.Fatalf("Ping = %+v, %v; want ok", s, err)
+	}
+	status = `{"code":503,"status":"maintenance","message":"Scheduled upgrade"}`
+	s, err = client.Ping(context.Background())
+	if err != nil || s.Health() != HealthDegraded || !strings.Contains(string(s.RawJSON), `"message":"Scheduled upgrade"`) {
+		t.Fatalf("Ping = %+v, %v; want degraded", s, err)
+	}
+}
@@ -0,0 +1,188 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TagCategory represents a Tenable VM tag category, the first half of
+// a Category:Value asset tag.  Categories are addressed by UUID.  Only
+// the editable fields are defined; the rest of the record is captured
+// in RawJSON.
+type TagCategory struct {
+	UUID        string          `json:"uuid"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	RawJSON     json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a tag category record and keeps it in RawJSON.
+func (t *TagCategory) UnmarshalJSON(b []byte) error {
+	type plain TagCategory
+	return decodeRecord(b, (*plain)(t), &t.RawJSON)
+}
+
+// tagCategoryPayload is the body of tag category create and update
+// requests.
+func tagCategoryPayload(name, description string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "description": description}
+}
+
+// GetTagCategory retrieves a tag category by UUID.
+func (c *Client) GetTagCategory(ctx context.Context, uuid string) (*TagCategory, error) {
+	return get[*TagCategory](ctx, c, fmt.Sprintf("tags/categories/%s", uuid))
+}
+
+// CreateTagCategory creates a tag category.  Category names are unique
+// within the container.
+func (c *Client) CreateTagCategory(ctx context.Context, name, description string) (*TagCategory, error) {
+	return post[*TagCategory](ctx, c, "tags/categories", tagCategoryPayload(name, description))
+}
+
+// UpdateTagCategory changes the name and description of a tag category
+// and returns the updated category.
+func (c *Client) UpdateTagCategory(ctx context.Context, uuid, name, description string) (*TagCategory, error) {
+	return request[*TagCategory](ctx, c, http.MethodPut, fmt.Sprintf("tags/categories/%s", uuid), tagCategoryPayload(name, description))
+}
+
+// DeleteTagCategory removes a tag category together with its values.
+func (c *Client) DeleteTagCategory(ctx context.Context, uuid string) error {
+	return call(ctx, c, http.MethodDelete, fmt.Sprintf("tags/categories/%s", uuid), nil)
+}
+
+// TagValue represents a value within a tag category, the second half of
+// a Category:Value asset tag.  Values are addressed by UUID.  Type is
+// static for values applied to assets by hand and dynamic for values
+// that Tenable VM applies to the assets matching Filters.
+type TagValue struct {
+	UUID         string          `json:"uuid"`
+	CategoryUUID string          `json:"category_uuid"`
+	CategoryName string          `json:"category_name"`
+	Value        string          `json:"value"`
+	Description  string          `json:"description"`
+	Type         string          `json:"type"`
+	Filters      *TagFilter      `json:"-"`
+	RawJSON      json.RawMessage `json:"-"`
+}
+
+// TagFilter is a node of the rule tree of a dynamic tag value: either
+// a condition such as operating_system match Windows, or an and/or
+// combination of nested nodes when And or Or is set.
+type TagFilter struct {
+	Field    string
+	Operator string
+	Value    string
+	And      []TagFilter
+	Or       []TagFilter
+}
+
+// MarshalJSON encodes a condition as {"field", "operator", "value"}
+// and a combination as {"and": [...]} or {"or": [...]}.
+func (f TagFilter) MarshalJSON() ([]byte, error) {
+	switch {
+	case f.And != nil:
+		return json.Marshal(map[string][]TagFilter{"and": f.And})
+	case f.Or != nil:
+		return json.Marshal(map[string][]TagFilter{"or": f.Or})
+	}
+	return json.Marshal(map[string]string{"field": f.Field, "operator": f.Operator, "value": f.Value})
+}
+
+// UnmarshalJSON decodes a rule tree node.  Conditions on list fields
+// may carry a list of values, which is joined with commas as the UI
+// does.
+func (f *TagFilter) UnmarshalJSON(b []byte) error {
+	var node struct {
+		Field    string          `json:"field"`
+		Operator string          `json:"operator"`
+		Value    json.RawMessage `json:"value"`
+		And      []TagFilter     `json:"and"`
+		Or       []TagFilter     `json:"or"`
+	}
+	if err := json.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	*f = TagFilter{Field: node.Field, Operator: node.Operator, And: node.And, Or: node.Or}
+	if len(node.Value) == 0 || string(node.Value) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(node.Value, &f.Value); err == nil {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(node.Value, &values); err != nil {
+		return fmt.Errorf("tag filter %s: unsupported value %s", node.Field, node.Value)
+	}
+	f.Value = strings.Join(values, ",")
+	return nil
+}
+
+// UnmarshalJSON decodes a tag value record and keeps it in RawJSON.
+// The rule tree is nested as filters.asset, which the API returns as a
+// JSON encoded string.
+func (t *TagValue) UnmarshalJSON(b []byte) error {
+	type plain TagValue
+	var record struct {
+		*plain
+		Filters struct {
+			Asset json.RawMessage `json:"asset"`
+		} `json:"filters"`
+	}
+	record.plain = (*plain)(t)
+	if err := decodeRecord(b, &record, &t.RawJSON); err != nil {
+		return err
+	}
+	asset := record.Filters.Asset
+	var encoded string
+	if json.Unmarshal(asset, &encoded) == nil {
+		asset = json.RawMessage(encoded)
+	}
+	if len(asset) == 0 || string(asset) == "null" {
+		t.Filters = nil
+		return nil
+	}
+	t.Filters = &TagFilter{}
+	return json.Unmarshal(asset, t.Filters)
+}
+
+// tagValuePayload adds the rule tree of a dynamic value to payload.
+func tagValuePayload(payload map[string]interface{}, filters *TagFilter) map[string]interface{} {
+	if filters != nil {
+		payload["filters"] = map[string]interface{}{"asset": filters}
+	}
+	return payload
+}
+
+// GetTagValue retrieves a tag value by UUID.
+func (c *Client) GetTagValue(ctx context.Context, uuid string) (*TagValue, error) {
+	return get[*TagValue](ctx, c, fmt.Sprintf("tags/values/%s", uuid))
+}
+
+// CreateTagValue creates a value in the tag category with the given
+// UUID.  A nil filters creates a static value; otherwise the value is
+// dynamic and applied to the assets matching the rule tree.
+func (c *Client) CreateTagValue(ctx context.Context, categoryUUID, value, description string, filters *TagFilter) (*TagValue, error) {
+	return post[*TagValue](ctx, c, "tags/values", tagValuePayload(map[string]interface{}{
+		"category_uuid": categoryUUID,
+		"value":         value,
+		"description":   description,
+	}, filters))
+}
+
+// UpdateTagValue changes the value, description and, for dynamic
+// values, the rule tree of a tag value and returns the updated value.
+// A value cannot move to another category.
+func (c *Client) UpdateTagValue(ctx context.Context, uuid, value, description string, filters *TagFilter) (*TagValue, error) {
+	return request[*TagValue](ctx, c, http.MethodPut, fmt.Sprintf("tags/values/%s", uuid), tagValuePayload(map[string]interface{}{
+		"value":       value,
+		"description": description,
+	}, filters))
+}
+
+// DeleteTagValue removes a tag value and unassigns it from all assets.
+func (c *Client) DeleteTagValue(ctx context.Context, uuid string) error {
+	return call(ctx, c, http.MethodDelete, fmt.Sprintf("tags/values/%s", uuid), nil)
+}
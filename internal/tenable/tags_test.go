@@ -0,0 +1,148 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_TagCategoryCRUD verifies the tag category requests and
+// that the full record is kept in RawJSON.
+func TestClient_TagCategoryCRUD(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/tags/categories",
+			r.Method == http.MethodPut && r.URL.Path == "/tags/categories/c-uuid":
+			json.NewEncoder(w).Encode(map[string]interface{}{"uuid": "c-uuid", "name": body["name"], "description": body["description"], "reserved": false})
+		case r.Method == http.MethodGet && r.URL.Path == "/tags/categories/c-uuid":
+			w.Write([]byte(`{"uuid":"c-uuid","name":"Location","description":"Site"}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	created, err := client.CreateTagCategory(ctx, "Location", "Site")
+	if err != nil || created.UUID != "c-uuid" || created.Description != "Site" || !hasField(created.RawJSON, "reserved") {
+		t.Fatalf("CreateTagCategory = %+v, %v", created, err)
+	}
+	if got, err := client.GetTagCategory(ctx, "c-uuid"); err != nil || got.Name != "Location" {
+		t.Fatalf("GetTagCategory = %+v, %v", got, err)
+	}
+	if got, err := client.UpdateTagCategory(ctx, "c-uuid", "Site", ""); err != nil || got.Name != "Site" || got.Description != "" {
+		t.Fatalf("UpdateTagCategory = %+v, %v", got, err)
+	}
+	if err := client.DeleteTagCategory(ctx, "c-uuid"); err != nil {
+		t.Fatalf("DeleteTagCategory: %v", err)
+	}
+	if _, err := client.GetTagCategory(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetTagCategory of a missing category = %v, want ErrNotFound", err)
+	}
+	want := []string{
+		"POST /tags/categories",
+		"GET /tags/categories/c-uuid",
+		"PUT /tags/categories/c-uuid",
+		"DELETE /tags/categories/c-uuid",
+		"GET /tags/categories/missing",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+// TestClient_TagValueCRUD verifies the tag value requests, including
+// the category sent on create.
+func TestClient_TagValueCRUD(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodPost:
+			if body["category_uuid"] != "c-uuid" {
+				t.Errorf("category_uuid = %q, want c-uuid", body["category_uuid"])
+			}
+			fallthrough
+		case r.Method == http.MethodPut:
+			json.NewEncoder(w).Encode(map[string]interface{}{"uuid": "v-uuid", "category_uuid": "c-uuid", "category_name": "Location", "value": body["value"], "description": body["description"], "type": "static"})
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"uuid":"v-uuid","category_uuid":"c-uuid","category_name":"Location","value":"Tokyo","type":"static"}`))
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	ctx := context.Background()
+
+	created, err := client.CreateTagValue(ctx, "c-uuid", "Tokyo", "HQ", nil)
+	if err != nil || created.UUID != "v-uuid" || created.CategoryName != "Location" || created.Type != "static" {
+		t.Fatalf("CreateTagValue = %+v, %v", created, err)
+	}
+	if got, err := client.GetTagValue(ctx, "v-uuid"); err != nil || got.Value != "Tokyo" || got.CategoryUUID != "c-uuid" {
+		t.Fatalf("GetTagValue = %+v, %v", got, err)
+	}
+	if got, err := client.UpdateTagValue(ctx, "v-uuid", "Osaka", "", nil); err != nil || got.Value != "Osaka" {
+		t.Fatalf("UpdateTagValue = %+v, %v", got, err)
+	}
+	if err := client.DeleteTagValue(ctx, "v-uuid"); err != nil {
+		t.Fatalf("DeleteTagValue: %v", err)
+	}
+	want := []string{
+		"POST /tags/values",
+		"GET /tags/values/v-uuid",
+		"PUT /tags/values/v-uuid",
+		"DELETE /tags/values/v-uuid",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+// TestClient_TagValueFilters verifies that the rule tree is sent as
+// filters.asset and decoded from the JSON encoded string the API
+// returns, including list values.
+func TestClient_TagValueFilters(t *testing.T) {
+	var sent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Filters json.RawMessage `json:"filters"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		sent = string(body.Filters)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"v-uuid","type":"dynamic","filters":{"asset":"{\"or\":[{\"field\":\"operating_system\",\"operator\":\"match\",\"value\":\"Windows\"},{\"and\":[{\"field\":\"ipv4\",\"operator\":\"eq\",\"value\":[\"10.0.0.1\",\"10.0.0.2\"]}]}]}"}}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	filters := &TagFilter{Or: []TagFilter{
+		{Field: "operating_system", Operator: "match", Value: "Windows"},
+		{And: []TagFilter{{Field: "ipv4", Operator: "eq", Value: "10.0.0.1,10.0.0.2"}}},
+	}}
+	got, err := client.CreateTagValue(context.Background(), "c-uuid", "Windows", "", filters)
+	if err != nil {
+		t.Fatalf("CreateTagValue: %v", err)
+	}
+	wantSent := `{"asset":{"or":[{"field":"operating_system","operator":"match","value":"Windows"},{"and":[{"field":"ipv4","operator":"eq","value":"10.0.0.1,10.0.0.2"}]}]}}`
+	if sent != wantSent {
+		t.Errorf("sent filters = %s, want %s", sent, wantSent)
+	}
+	if got.Type != "dynamic" || !reflect.DeepEqual(got.Filters, filters) {
+		t.Errorf("decoded filters = %+v, want %+v", got.Filters, filters)
+	}
+	if _, err := client.UpdateTagValue(context.Background(), "v-uuid", "Windows", "", nil); err != nil || sent != "" {
+		t.Errorf("UpdateTagValue without filters sent %q, %v", sent, err)
+	}
+}
@@ -0,0 +1,101 @@
+package tenable
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default per-attempt timeouts of the endpoint classes.  Single-object
+// calls should fail fast, while paging through a large tenant's assets
+// or streaming an export can legitimately take minutes.
+const (
+	DefaultListTimeout     = 3 * time.Minute
+	DefaultTransferTimeout = 15 * time.Minute
+)
+
+// TimeoutClass groups endpoints by how long their calls may take.
+type TimeoutClass int
+
+const (
+	// TimeoutDefault covers calls that read or change a single object.
+	TimeoutDefault TimeoutClass = iota
+	// TimeoutList covers GETs of collections.
+	TimeoutList
+	// TimeoutTransfer covers exports, downloads and uploads.
+	TimeoutTransfer
+)
+
+type timeoutKey struct{}
+
+// ContextWithTimeout overrides the timeout of each attempt of the
+// calls made with ctx, regardless of their endpoint class.  Unlike a
+// deadline on ctx itself, the timeout restarts for every retry.
+func ContextWithTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// timeout returns the per-attempt timeout of an endpoint class.
+// Timeout applies to TimeoutDefault; unset list and transfer timeouts
+// fall back to their defaults, but never to less than Timeout.
+func (c *Client) timeout(class TimeoutClass) time.Duration {
+	base := c.Timeout
+	if base <= 0 {
+		base = DefaultTimeout
+	}
+	switch class {
+	case TimeoutList:
+		if c.ListTimeout > 0 {
+			return c.ListTimeout
+		}
+		return max(DefaultListTimeout, base)
+	case TimeoutTransfer:
+		if c.TransferTimeout > 0 {
+			return c.TransferTimeout
+		}
+		return max(DefaultTransferTimeout, base)
+	}
+	return base
+}
+
+// timeoutClass classifies a request by its endpoint: export, download
+// and upload paths are transfers, GETs of paths not ending in an
+// object ID are lists, and everything else is a single-object call.
+func timeoutClass(req *http.Request) TimeoutClass {
+	path := endpoint(req.URL.Path)
+	switch {
+	case strings.Contains(path, "/export") || strings.HasSuffix(path, "/download") || strings.HasPrefix(path, "/file/upload"):
+		return TimeoutTransfer
+	case req.Method == http.MethodGet && !strings.HasSuffix(path, "{id}"):
+		return TimeoutList
+	}
+	return TimeoutDefault
+}
+
+// withAttemptTimeout returns a copy of req bounded by its timeout,
+// from ContextWithTimeout or its endpoint class, and the function that
+// releases the timer.  The deadline must cover reading the response,
+// so cancel is attached to the body with cancelOnClose.
+func (c *Client) withAttemptTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	d, ok := req.Context().Value(timeoutKey{}).(time.Duration)
+	if !ok || d <= 0 {
+		d = c.timeout(timeoutClass(req))
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+	return req.WithContext(ctx), cancel
+}
+
+// cancelOnClose releases an attempt's timeout once its response body
+// is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
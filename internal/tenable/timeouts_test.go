@@ -0,0 +1,60 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutClass(t *testing.T) {
+	for _, tc := range []struct {
+		method, path string
+		want         TimeoutClass
+	}{
+		{http.MethodGet, "/users/12", TimeoutDefault},
+		{http.MethodPut, "/users/12", TimeoutDefault},
+		{http.MethodPost, "/users", TimeoutDefault},
+		{http.MethodGet, "/users", TimeoutList},
+		{http.MethodGet, "/scans/7/export/9/download", TimeoutTransfer},
+		{http.MethodPost, "/vulns/export", TimeoutTransfer},
+		{http.MethodPost, "/file/upload", TimeoutTransfer},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if got := timeoutClass(req); got != tc.want {
+			t.Errorf("timeoutClass(%s %s) = %d, want %d", tc.method, tc.path, got, tc.want)
+		}
+	}
+
+	c := &Client{Timeout: 5 * time.Minute, TransferTimeout: time.Hour}
+	if c.timeout(TimeoutList) != 5*time.Minute || c.timeout(TimeoutTransfer) != time.Hour {
+		t.Errorf("timeouts = %s, %s; want 5m, 1h", c.timeout(TimeoutList), c.timeout(TimeoutTransfer))
+	}
+}
+
+// TestClient_timeouts verifies that a slow single-object call times
+// out while the same delay is allowed for a list, and that
+// ContextWithTimeout overrides the class.
+func TestClient_timeouts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id":1,"code":200,"status":"ready"}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	client.Timeout = 10 * time.Millisecond
+	client.ListTimeout = time.Second
+
+	if _, err := client.GetUser(context.Background(), 1); err == nil {
+		t.Error("GetUser succeeded despite its 10ms timeout")
+	}
+	if _, err := client.Request(context.Background(), http.MethodGet, "scanners", nil); err != nil {
+		t.Errorf("list call: %v", err)
+	}
+	ctx := ContextWithTimeout(context.Background(), 10*time.Millisecond)
+	if _, err := client.Ping(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Ping with overridden timeout = %v, want DeadlineExceeded", err)
+	}
+}
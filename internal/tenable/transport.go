@@ -0,0 +1,55 @@
+package tenable
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// Connection pool defaults used by NewTransport.  Every request goes to
+// the same Tenable host, so the per-host idle limit matters most: Go's
+// default of two makes parallel plans on large tenants open, and
+// TLS-handshake, a new connection for almost every call.
+const (
+	DefaultMaxIdleConns    = 100
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// TransportOptions tunes the connection pool of the transport built by
+// NewTransport.  Zero values select the defaults.
+type TransportOptions struct {
+	// MaxIdleConns is the number of idle keep-alive connections kept
+	// open to the API host.
+	MaxIdleConns int
+	// IdleConnTimeout is how long an idle connection is kept before it
+	// is closed.
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 restricts the transport to HTTP/1.1, for proxies
+	// that mishandle HTTP/2.
+	DisableHTTP2 bool
+}
+
+// NewTransport returns a transport for the Tenable API with the given
+// pool settings.  It starts from a clone of http.DefaultTransport so
+// the standard HTTPS_PROXY/NO_PROXY handling and dial timeouts are
+// kept; callers may further adjust Proxy and TLSClientConfig.  Clients
+// that need a different round tripper can set Http directly instead.
+func NewTransport(opts TransportOptions) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	maxIdle := opts.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	t.MaxIdleConns = maxIdle
+	t.MaxIdleConnsPerHost = maxIdle
+	t.IdleConnTimeout = DefaultIdleConnTimeout
+	if opts.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto disables HTTP/2 negotiation.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return t
+}
@@ -0,0 +1,24 @@
+package tenable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	tr := NewTransport(TransportOptions{})
+	if tr.MaxIdleConnsPerHost != DefaultMaxIdleConns || tr.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("defaults not applied: per host %d, idle timeout %s", tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+	}
+	if !tr.ForceAttemptHTTP2 || tr.Proxy == nil {
+		t.Errorf("expected the default transport's HTTP/2 and proxy settings to be kept")
+	}
+
+	tr = NewTransport(TransportOptions{MaxIdleConns: 8, IdleConnTimeout: time.Minute, DisableHTTP2: true})
+	if tr.MaxIdleConns != 8 || tr.MaxIdleConnsPerHost != 8 || tr.IdleConnTimeout != time.Minute {
+		t.Errorf("options not applied: %d/%d, %s", tr.MaxIdleConns, tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Errorf("expected HTTP/2 to be disabled")
+	}
+}
@@ -0,0 +1,46 @@
+package tenable
+
+import (
+	"context"
+	"net/http"
+)
+
+// request sends a request bound to ctx with the given JSON payload,
+// which may be nil, and decodes the response into a T.  Pointer and
+// slice types are allocated by the decoder, so request[*User] returns
+// a new user.  get, post and call cover the common cases, so that
+// modelling an endpoint takes a line per call instead of building
+// requests by hand.
+func request[T any](ctx context.Context, c *Client, method, path string, payload interface{}) (T, error) {
+	var result T
+	req, err := c.newRequest(ctx, method, path, payload)
+	if err != nil {
+		return result, err
+	}
+	if err := c.do(req, &result); err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+// get decodes the response of a GET request for path into a T.
+func get[T any](ctx context.Context, c *Client, path string) (T, error) {
+	return request[T](ctx, c, http.MethodGet, path, nil)
+}
+
+// post sends payload with a POST request to path and decodes the
+// response into a T.
+func post[T any](ctx context.Context, c *Client, path string, payload interface{}) (T, error) {
+	return request[T](ctx, c, http.MethodPost, path, payload)
+}
+
+// call sends a request whose response carries no data, such as most
+// updates and deletions, and discards the body.
+func call(ctx context.Context, c *Client, method, path string, payload interface{}) error {
+	req, err := c.newRequest(ctx, method, path, payload)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
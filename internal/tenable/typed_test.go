@@ -0,0 +1,42 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestTypedHelpers verifies that get and post decode into the
+// requested type and that errors yield the zero value.
+func TestTypedHelpers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /roles":
+			w.Write([]byte(`[{"id":1,"name":"Auditor"},{"id":2,"name":"Operator"}]`))
+		case "POST /users":
+			w.Write([]byte(`{"id":7,"username":"alice","tenant":"t1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	roles, err := get[[]*Role](context.Background(), client, "roles")
+	if err != nil || len(roles) != 2 || roles[1].Name != "Operator" {
+		t.Errorf("get roles = %+v, %v", roles, err)
+	}
+	user, err := post[*User](context.Background(), client, "users", map[string]string{"username": "alice"})
+	if err != nil || user.ID != 7 || !hasField(user.RawJSON, "tenant") {
+		t.Errorf("post user = %+v, %v", user, err)
+	}
+	missing, err := get[*User](context.Background(), client, "users/8")
+	if missing != nil || !errors.Is(err, ErrNotFound) {
+		t.Errorf("get missing user = %+v, %v; want nil, ErrNotFound", missing, err)
+	}
+	if err := call(context.Background(), client, http.MethodDelete, "users/8", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("call = %v, want ErrNotFound", err)
+	}
+}
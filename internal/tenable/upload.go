@@ -0,0 +1,65 @@
+package tenable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Upload sends content to POST /file/upload as a multipart form and
+// returns the name Tenable stored it under.  The name is what later
+// calls reference: exclusion and policy imports, audit file
+// attachments and scan result imports.  The form is assembled in
+// memory so the request can be resent when it is rate limited.
+func (c *Client) Upload(ctx context.Context, filename string, content io.Reader) (string, error) {
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	part, err := mw.CreateFormFile("Filedata", filepath.Base(filename))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return "", fmt.Errorf("reading %s: %w", filename, err)
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "file/upload", nil)
+	if err != nil {
+		return "", err
+	}
+	data := form.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var result struct {
+		FileUploaded string `json:"fileuploaded"`
+	}
+	if err := c.do(req, &result); err != nil {
+		return "", err
+	}
+	if result.FileUploaded == "" {
+		return "", fmt.Errorf("uploading %s: response did not contain a file name", filename)
+	}
+	return result.FileUploaded, nil
+}
+
+// UploadFile uploads the local file at filename, see Upload.
+func (c *Client) UploadFile(ctx context.Context, filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return c.Upload(ctx, filename, f)
+}
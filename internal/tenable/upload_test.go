@@ -0,0 +1,53 @@
+package tenable
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestClient_Upload verifies the multipart form sent to /file/upload
+// and that a rate limited upload is resent with the same content.
+func TestClient_Upload(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/file/upload" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		f, header, err := r.FormFile("Filedata")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		content, _ := io.ReadAll(f)
+		if header.Filename != "exclusions.csv" || string(content) != "10.0.0.1\n" {
+			t.Errorf("file = %q %q", header.Filename, content)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"fileuploaded":"exclusions-1.csv"}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	client.MaxRetries = 1
+	client.RetryMinWait = time.Millisecond
+
+	filename := filepath.Join(t.TempDir(), "exclusions.csv")
+	if err := os.WriteFile(filename, []byte("10.0.0.1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	name, err := client.UploadFile(context.Background(), filename)
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if name != "exclusions-1.csv" || calls != 2 {
+		t.Errorf("UploadFile = %q after %d calls, want exclusions-1.csv after 2", name, calls)
+	}
+}
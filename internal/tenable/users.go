@@ -0,0 +1,298 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// User represents a Tenable VM user resource.  Only a subset of
+// fields are defined here; additional fields returned by the API
+// are available in the complete record kept in RawJSON.
+//
+// LastLogin is the time of the last successful login in milliseconds
+// since the Unix epoch, or zero if the user never logged in.
+// LoginFailCount counts the failed logins since then, and Lockout is
+// non-zero while too many failures keep the account locked.  Type is
+// the account type, local or saml.
+type User struct {
+	ID             int             `json:"id"`
+	UUID           string          `json:"uuid"`
+	Username       string          `json:"username"`
+	Name           string          `json:"name"`
+	Email          string          `json:"email"`
+	Permissions    int             `json:"permissions"`
+	Enabled        bool            `json:"enabled"`
+	Type           string          `json:"type"`
+	LastLogin      int64           `json:"lastlogin"`
+	LoginFailCount int             `json:"login_fail_count"`
+	Lockout        int             `json:"lockout"`
+	RawJSON        json.RawMessage `json:"-"`
+}
+
+// Role represents a Tenable VM role (custom role).  Only a subset
+// of fields are defined here; additional fields returned by the API
+// are captured in RawJSON.  Roles define a set of privileges and can be
+// assigned to users or groups.  Privileges lists the privilege names
+// the role grants.
+type Role struct {
+	ID          int             `json:"id"`
+	UUID        string          `json:"uuid"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Privileges  []string        `json:"privileges"`
+	RawJSON     json.RawMessage `json:"-"`
+}
+
+// Group represents a Tenable VM user group.  Groups are used to
+// manage collections of users and their access.  Only common fields
+// are explicitly defined; other fields are stored in RawJSON.
+type Group struct {
+	ID          int             `json:"id"`
+	UUID        string          `json:"uuid"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	RawJSON     json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a user record and keeps it in RawJSON.
+func (u *User) UnmarshalJSON(b []byte) error {
+	type plain User
+	return decodeRecord(b, (*plain)(u), &u.RawJSON)
+}
+
+// UnmarshalJSON decodes a role record and keeps it in RawJSON.
+func (r *Role) UnmarshalJSON(b []byte) error {
+	type plain Role
+	return decodeRecord(b, (*plain)(r), &r.RawJSON)
+}
+
+// UnmarshalJSON decodes a group record and keeps it in RawJSON.
+func (g *Group) UnmarshalJSON(b []byte) error {
+	type plain Group
+	return decodeRecord(b, (*plain)(g), &g.RawJSON)
+}
+
+// CreateUser creates a new user in Tenable VM.  The returned user
+// structure includes the generated user ID which is used to set the
+// Terraform resource ID.  See Tenable's API documentation for
+// supported permissions values【946957473917885†L60-L74】.  An empty
+// password is left out of the request, as SAML users have none.
+//
+// When the user is created but setting its enabled flag afterwards
+// fails, CreateUser returns the created user together with the error,
+// so that callers can record the user instead of orphaning it.
+func (c *Client) CreateUser(ctx context.Context, username, password string, permissions int, name, email, accountType string, enabled bool) (*User, error) {
+	payload := map[string]interface{}{
+		"username":    username,
+		"permissions": permissions,
+		"type":        accountType,
+	}
+	if password != "" {
+		payload["password"] = password
+	}
+	if name != "" {
+		payload["name"] = name
+	}
+	if email != "" {
+		payload["email"] = email
+	}
+	user, err := post[*User](ctx, c, "users", payload)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.invalidate(cacheKeyUsers)
+	// The API returns the created user record.  Some Tenable
+	// deployments may not include an explicit 'enabled' field on
+	// creation, so default to true.
+	if !hasField(user.RawJSON, "enabled") {
+		user.Enabled = true
+	}
+	// If the enabled flag in the payload differs from the API
+	// response, update it accordingly using the dedicated endpoint.
+	if user.ID != 0 && user.Enabled != enabled {
+		if err := c.SetUserEnabled(ctx, user.ID, enabled); err != nil {
+			return user, fmt.Errorf("user %d was created, but setting enabled to %t failed: %w", user.ID, enabled, err)
+		}
+		user.Enabled = enabled
+	}
+	return user, nil
+}
+
+// GetUser retrieves the details of a user by ID【946957473917885†L95-L113】.
+func (c *Client) GetUser(ctx context.Context, id int) (*User, error) {
+	return get[*User](ctx, c, fmt.Sprintf("users/%d", id))
+}
+
+// CurrentUser retrieves the user the client is authenticated as, that
+// is the owner of the API keys or the session, with GET /session.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	return get[*User](ctx, c, "session")
+}
+
+// ListUsers retrieves all users from Tenable VM.  The returned slice
+// contains basic information for each user.  This method is used by
+// data sources to locate a user by username when only the username
+// is known.  The API returns a list of user objects; each user
+// record may include only a subset of fields depending on the
+// requesting user's permissions【515179993953485†L793-L802】.
+func (c *Client) ListUsers(ctx context.Context) ([]*User, error) {
+	return cachedList(ctx, c, cacheKeyUsers, func(ctx context.Context) ([]*User, error) { return c.listUsers(ctx) })
+}
+
+func (c *Client) listUsers(ctx context.Context) ([]*User, error) {
+	// According to Tenable's API documentation, the list endpoint
+	// returns a JSON array of user objects【515179993953485†L793-L802】.
+	// Each object may contain fields such as id, uuid, username, name,
+	// email, permissions and enabled, though not all fields are
+	// guaranteed to be present.
+	items, err := c.listAll(ctx, "users", "")
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[User](items)
+}
+
+// ListRoles retrieves all roles from Tenable VM.  The roles API
+// returns an array of role objects representing custom roles.  Each
+// object may include fields such as id, uuid, name, and description.
+// See the pyTenable documentation which notes that list() returns
+// "the list of roles objects"【730874566695972†L238-L245】.
+func (c *Client) ListRoles(ctx context.Context) ([]*Role, error) {
+	return cachedList(ctx, c, cacheKeyRoles, func(ctx context.Context) ([]*Role, error) { return c.listRoles(ctx) })
+}
+
+func (c *Client) listRoles(ctx context.Context) ([]*Role, error) {
+	return get[[]*Role](ctx, c, "roles")
+}
+
+// ListGroups retrieves all user groups from Tenable VM.  The groups
+// API returns an array of group objects.  The pyTenable
+// documentation for groups.list() states that it "lists all of the
+// available user groups" and returns a list of group resource
+// records【308594680530685†L327-L334】.  Each group may include id,
+// uuid, name and description fields.
+func (c *Client) ListGroups(ctx context.Context) ([]*Group, error) {
+	return cachedList(ctx, c, cacheKeyGroups, func(ctx context.Context) ([]*Group, error) { return c.listGroups(ctx) })
+}
+
+func (c *Client) listGroups(ctx context.Context) ([]*Group, error) {
+	items, err := c.listAll(ctx, "groups", "")
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[Group](items)
+}
+
+// UpdateUser modifies an existing user.  Only non‑zero/non‑empty
+// attributes are applied.  Permissions and enabled state are
+// optional.  The Tenable API requires a PUT request to
+// /users/{id} to update name, email, permissions and enabled
+// properties as described in the pyTenable implementation【946957473917885†L143-L165】.
+func (c *Client) UpdateUser(ctx context.Context, id int, permissions *int, name, email *string, enabled *bool) (*User, error) {
+	// Build payload by merging existing values with desired
+	current, err := c.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{}
+	// Always send current permissions, enabled, email, name; then override
+	payload["permissions"] = current.Permissions
+	payload["enabled"] = current.Enabled
+	payload["email"] = current.Email
+	payload["name"] = current.Name
+	if permissions != nil {
+		payload["permissions"] = *permissions
+	}
+	if enabled != nil {
+		payload["enabled"] = *enabled
+	}
+	if email != nil {
+		payload["email"] = *email
+	}
+	if name != nil {
+		payload["name"] = *name
+	}
+	if err := call(ctx, c, http.MethodPut, fmt.Sprintf("users/%d", id), payload); err != nil {
+		return nil, err
+	}
+	c.cache.invalidate(cacheKeyUsers)
+	// update and return user
+	return c.GetUser(ctx, id)
+}
+
+// DeleteUser removes a user from Tenable VM【946957473917885†L76-L93】.
+func (c *Client) DeleteUser(ctx context.Context, id int) error {
+	// Tenable's delete endpoint returns empty body on success
+	defer c.cache.invalidate(cacheKeyUsers)
+	return call(ctx, c, http.MethodDelete, fmt.Sprintf("users/%d", id), nil)
+}
+
+// ChangeUserPassword sets a new password for a user with
+// PUT /users/{id}/chpasswd, keeping the account and everything it
+// owns.  Administrators changing another user's password do not need
+// to supply the current one.
+func (c *Client) ChangeUserPassword(ctx context.Context, id int, password string) error {
+	payload := map[string]interface{}{
+		"password": password,
+	}
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("users/%d/chpasswd", id), payload)
+}
+
+// APIKeys is a user's API key pair as returned by the keys endpoint.
+// The secret key is only ever returned at generation time.
+type APIKeys struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// GenerateAPIKeys generates a new API key pair for a user with
+// PUT /users/{id}/keys.  Any keys the user already has are revoked.
+func (c *Client) GenerateAPIKeys(ctx context.Context, id int) (*APIKeys, error) {
+	return request[*APIKeys](ctx, c, http.MethodPut, fmt.Sprintf("users/%d/keys", id), nil)
+}
+
+// UserAuthorizations controls how a user may authenticate: with API
+// keys, with a password, or through SAML single sign-on.
+type UserAuthorizations struct {
+	APIPermitted      bool `json:"api_permitted"`
+	PasswordPermitted bool `json:"password_permitted"`
+	SAMLPermitted     bool `json:"saml_permitted"`
+}
+
+// GetUserAuthorizations retrieves the authentication methods a user
+// is permitted to use.
+func (c *Client) GetUserAuthorizations(ctx context.Context, id int) (*UserAuthorizations, error) {
+	return get[*UserAuthorizations](ctx, c, fmt.Sprintf("users/%d/authorizations", id))
+}
+
+// UpdateUserAuthorizations replaces the authentication methods a user
+// is permitted to use.  The API requires all three flags, so callers
+// should start from GetUserAuthorizations to change a single one.
+func (c *Client) UpdateUserAuthorizations(ctx context.Context, id int, auth UserAuthorizations) error {
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("users/%d/authorizations", id), auth)
+}
+
+// SetUserForcePasswordChange sets whether the user must change the
+// password at the next login, so that temporary passwords handed out
+// during onboarding cannot stay in use.  The user clears the flag by
+// changing the password.
+func (c *Client) SetUserForcePasswordChange(ctx context.Context, id int, force bool) error {
+	payload := map[string]interface{}{
+		"force_password_change": force,
+	}
+	defer c.cache.invalidate(cacheKeyUsers)
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("users/%d", id), payload)
+}
+
+// SetUserEnabled toggles a user's enabled status using the dedicated
+// endpoint.  This helper is used after creation to ensure the
+// resource reflects the desired enabled flag【946957473917885†L167-L193】.
+func (c *Client) SetUserEnabled(ctx context.Context, id int, enabled bool) error {
+	payload := map[string]interface{}{
+		"enabled": enabled,
+	}
+	defer c.cache.invalidate(cacheKeyUsers)
+	return call(ctx, c, http.MethodPut, fmt.Sprintf("users/%d/enabled", id), payload)
+}
@@ -0,0 +1,400 @@
+package tenable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestClient_ListUsers verifies that ListUsers parses a list of users
+// correctly from the API and returns the expected slice of User structs.
+func TestClient_ListUsers(t *testing.T) {
+	// Sample JSON response representing two users
+	sample := []map[string]interface{}{
+		{
+			"id":          1,
+			"uuid":        "uuid-1",
+			"username":    "alice",
+			"name":        "Alice",
+			"email":       "alice@example.com",
+			"permissions": 16,
+			"enabled":     true,
+		},
+		{
+			"id":          2,
+			"uuid":        "uuid-2",
+			"username":    "bob",
+			"name":        "Bob",
+			"email":       "bob@example.com",
+			"permissions": 32,
+			"enabled":     false,
+		},
+	}
+	// Create a test server that returns the sample response
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	users, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers error: %v", err)
+	}
+	if len(users) != len(sample) {
+		t.Fatalf("got %d users, want %d", len(users), len(sample))
+	}
+	// Compare each user
+	for i, u := range users {
+		if u.ID != sample[i]["id"].(int) {
+			t.Errorf("user %d ID mismatch: got %d, want %d", i, u.ID, sample[i]["id"].(int))
+		}
+		// We'll compare all fields manually using reflect.DeepEqual on a map
+		expected := &User{
+			ID:          int(sample[i]["id"].(int)),
+			UUID:        sample[i]["uuid"].(string),
+			Username:    sample[i]["username"].(string),
+			Name:        sample[i]["name"].(string),
+			Email:       sample[i]["email"].(string),
+			Permissions: int(sample[i]["permissions"].(int)),
+			Enabled:     sample[i]["enabled"].(bool),
+		}
+		if !reflect.DeepEqual(u.ID, expected.ID) || u.UUID != expected.UUID || u.Username != expected.Username || u.Name != expected.Name || u.Email != expected.Email || u.Permissions != expected.Permissions || u.Enabled != expected.Enabled {
+			t.Errorf("user %d mismatch\n got: %+v\nwant: %+v", i, u, expected)
+		}
+	}
+}
+
+// TestClient_GetUser verifies that GetUser retrieves and parses a single user.
+func TestClient_GetUser(t *testing.T) {
+	sample := map[string]interface{}{
+		"id":          1,
+		"uuid":        "uuid-1",
+		"username":    "alice",
+		"name":        "Alice",
+		"email":       "alice@example.com",
+		"permissions": 16,
+		"enabled":     true,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	user, err := client.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser error: %v", err)
+	}
+	expected := &User{
+		ID:          int(sample["id"].(int)),
+		UUID:        sample["uuid"].(string),
+		Username:    sample["username"].(string),
+		Name:        sample["name"].(string),
+		Email:       sample["email"].(string),
+		Permissions: int(sample["permissions"].(int)),
+		Enabled:     sample["enabled"].(bool),
+	}
+	// Ignore the raw record when comparing
+	user.RawJSON = nil
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("GetUser mismatch\n got: %+v\nwant: %+v", user, expected)
+	}
+}
+
+// TestClient_typedDecoding verifies that records decode into the typed
+// models while the complete record remains available in RawJSON, and that
+// nested fields such as the scanner licence type are picked up.
+func TestClient_typedDecoding(t *testing.T) {
+	var user User
+	if err := json.Unmarshal([]byte(`{"id":7,"username":"bob","enabled":false,"login_fail_count":2}`), &user); err != nil {
+		t.Fatalf("unmarshal user: %v", err)
+	}
+	if user.ID != 7 || user.Username != "bob" || user.Enabled {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if string(user.RawJSON) != `{"id":7,"username":"bob","enabled":false,"login_fail_count":2}` {
+		t.Errorf("RawJSON = %s, want the complete record", user.RawJSON)
+	}
+
+	var scanner Scanner
+	if err := json.Unmarshal([]byte(`{"id":3,"name":"cloud","license":{"type":"cloud","ips":0}}`), &scanner); err != nil {
+		t.Fatalf("unmarshal scanner: %v", err)
+	}
+	if scanner.ID != 3 || scanner.LicenseType != "cloud" {
+		t.Errorf("unexpected scanner: %+v", scanner)
+	}
+
+	var job AssetDeletionJob
+	if err := json.Unmarshal([]byte(`{"uuid":"job-1","response":{"data":{"asset_count":12}}}`), &job); err != nil {
+		t.Fatalf("unmarshal job: %v", err)
+	}
+	if job.UUID != "job-1" || job.AssetCount != 12 {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"not-a-number"}`), &user); err == nil {
+		t.Error("expected an error for a mistyped field")
+	}
+}
+
+// TestClient_ListRoles verifies that ListRoles parses role arrays correctly.
+func TestClient_ListRoles(t *testing.T) {
+	sample := []map[string]interface{}{
+		{
+			"id":          1,
+			"uuid":        "role-uuid1",
+			"name":        "Reader",
+			"description": "Read only access",
+		},
+		{
+			"id":          2,
+			"uuid":        "role-uuid2",
+			"name":        "Admin",
+			"description": "Admin access",
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/roles" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	roles, err := client.ListRoles(context.Background())
+	if err != nil {
+		t.Fatalf("ListRoles error: %v", err)
+	}
+	if len(roles) != len(sample) {
+		t.Fatalf("got %d roles, want %d", len(roles), len(sample))
+	}
+	for i, r := range roles {
+		expected := &Role{
+			ID:          int(sample[i]["id"].(int)),
+			UUID:        sample[i]["uuid"].(string),
+			Name:        sample[i]["name"].(string),
+			Description: sample[i]["description"].(string),
+		}
+		r.RawJSON = nil
+		if !reflect.DeepEqual(r, expected) {
+			t.Errorf("role %d mismatch\n got: %+v\nwant: %+v", i, r, expected)
+		}
+	}
+}
+
+// TestClient_ListGroups verifies that ListGroups parses group arrays correctly.
+func TestClient_ListGroups(t *testing.T) {
+	sample := []map[string]interface{}{
+		{
+			"id":          10,
+			"uuid":        "group-uuid1",
+			"name":        "Developers",
+			"description": "Dev group",
+		},
+		{
+			"id":          20,
+			"uuid":        "group-uuid2",
+			"name":        "Admins",
+			"description": "Admin group",
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/groups" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sample)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	groups, err := client.ListGroups(context.Background())
+	if err != nil {
+		t.Fatalf("ListGroups error: %v", err)
+	}
+	if len(groups) != len(sample) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(sample))
+	}
+	for i, g := range groups {
+		expected := &Group{
+			ID:          int(sample[i]["id"].(int)),
+			UUID:        sample[i]["uuid"].(string),
+			Name:        sample[i]["name"].(string),
+			Description: sample[i]["description"].(string),
+		}
+		g.RawJSON = nil
+		if !reflect.DeepEqual(g, expected) {
+			t.Errorf("group %d mismatch\n got: %+v\nwant: %+v", i, g, expected)
+		}
+	}
+}
+
+// TestClient_ChangeUserPassword verifies that ChangeUserPassword sends
+// the new password to the chpasswd endpoint.
+func TestClient_ChangeUserPassword(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/users/5/chpasswd" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	if err := client.ChangeUserPassword(context.Background(), 5, "n3w-Secret"); err != nil {
+		t.Fatalf("ChangeUserPassword error: %v", err)
+	}
+	if !reflect.DeepEqual(body, map[string]interface{}{"password": "n3w-Secret"}) {
+		t.Errorf("unexpected payload: %v", body)
+	}
+}
+
+// TestClient_SetUserForcePasswordChange verifies the payload that
+// requires a password change at the next login.
+func TestClient_SetUserForcePasswordChange(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/users/5" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	if err := client.SetUserForcePasswordChange(context.Background(), 5, true); err != nil {
+		t.Fatalf("SetUserForcePasswordChange error: %v", err)
+	}
+	if !reflect.DeepEqual(body, map[string]interface{}{"force_password_change": true}) {
+		t.Errorf("unexpected payload: %v", body)
+	}
+}
+
+// TestClient_GenerateAPIKeys verifies that GenerateAPIKeys decodes the
+// key pair returned by the keys endpoint.
+func TestClient_GenerateAPIKeys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/users/5/keys" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accessKey":"ak","secretKey":"sk"}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	keys, err := client.GenerateAPIKeys(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GenerateAPIKeys error: %v", err)
+	}
+	if *keys != (APIKeys{AccessKey: "ak", SecretKey: "sk"}) {
+		t.Errorf("unexpected keys: %+v", keys)
+	}
+}
+
+// TestClient_UserAuthorizations verifies that authorizations are read
+// from and written to the authorizations endpoint.
+func TestClient_UserAuthorizations(t *testing.T) {
+	var put UserAuthorizations
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/5/authorizations" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"account_uuid":"a","user_uuid":"u","api_permitted":true,"password_permitted":false,"saml_permitted":true}`))
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&put)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	auth, err := client.GetUserAuthorizations(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetUserAuthorizations error: %v", err)
+	}
+	if *auth != (UserAuthorizations{APIPermitted: true, SAMLPermitted: true}) {
+		t.Errorf("unexpected authorizations: %+v", auth)
+	}
+	want := UserAuthorizations{SAMLPermitted: true}
+	if err := client.UpdateUserAuthorizations(context.Background(), 5, want); err != nil {
+		t.Fatalf("UpdateUserAuthorizations error: %v", err)
+	}
+	if put != want {
+		t.Errorf("unexpected payload: %+v", put)
+	}
+}
+
+// TestClient_CreateUserWithoutPassword verifies that SAML users are
+// created without a password field.
+func TestClient_CreateUserWithoutPassword(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":3,"username":"sso@example.com","enabled":true}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	if _, err := client.CreateUser(context.Background(), "sso@example.com", "", 16, "", "", "saml", true); err != nil {
+		t.Fatalf("CreateUser error: %v", err)
+	}
+	if _, ok := body["password"]; ok || body["type"] != "saml" {
+		t.Errorf("unexpected payload: %v", body)
+	}
+}
+
+// TestClient_CreateUserEnableFails verifies that the created user is
+// returned along with the error when disabling it afterwards fails.
+func TestClient_CreateUserEnableFails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":5,"username":"new@example.com","enabled":true}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	user, err := client.CreateUser(context.Background(), "new@example.com", "secret", 16, "", "", "local", false)
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("CreateUser error = %v, want ErrForbidden", err)
+	}
+	if user == nil || user.ID != 5 || !user.Enabled {
+		t.Errorf("expected the created, still enabled user, got %+v", user)
+	}
+}
+
+// TestClient_CurrentUser verifies that CurrentUser reads the session
+// user.
+func TestClient_CurrentUser(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/session" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":9,"username":"svc@example.com","permissions":64}`))
+	}))
+	defer ts.Close()
+	client := newTestClient(ts)
+	user, err := client.CurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentUser error: %v", err)
+	}
+	if user.ID != 9 || user.Permissions != 64 {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
@@ -0,0 +1,44 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Bounds of the backoff between polls in WaitUntilVisible, and how
+// long it polls when ctx has no deadline of its own.  Tenable usually
+// serves a new object within a few seconds of creating it.
+const (
+	visibilityMinWait        = 500 * time.Millisecond
+	visibilityMaxWait        = 5 * time.Second
+	DefaultVisibilityTimeout = 30 * time.Second
+)
+
+// WaitUntilVisible calls get until it stops failing with ErrNotFound,
+// backing off between calls, and returns its result.  Tenable answers
+// reads of an object with 404 for a short while after creating it, so
+// resources call this after a create before treating a 404 as a
+// deletion.  Other errors are returned immediately.  When ctx expires
+// first, the last not-found error is returned.  A ctx without deadline
+// is bounded by DefaultVisibilityTimeout.
+func WaitUntilVisible[T any](ctx context.Context, get func() (T, error)) (T, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultVisibilityTimeout)
+		defer cancel()
+	}
+	var lastErr error
+	v, err := Poll(ctx, PollOptions{MinWait: visibilityMinWait, MaxWait: visibilityMaxWait}, func(context.Context) (T, bool, error) {
+		v, err := get()
+		if errors.Is(err, ErrNotFound) {
+			lastErr = err
+			return v, false, nil
+		}
+		return v, true, err
+	})
+	if err != nil && lastErr != nil && ctx.Err() != nil {
+		return v, lastErr
+	}
+	return v, err
+}
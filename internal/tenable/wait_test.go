@@ -0,0 +1,45 @@
+package tenable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWaitUntilVisible(t *testing.T) {
+	notFound := &APIError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	ctx := context.Background()
+
+	calls := 0
+	v, err := WaitUntilVisible(ctx, func() (int, error) {
+		if calls++; calls < 2 {
+			return 0, notFound
+		}
+		return 42, nil
+	})
+	if v != 42 || err != nil || calls != 2 {
+		t.Errorf("WaitUntilVisible = %d, %v after %d calls, want 42 after 2", v, err, calls)
+	}
+
+	// Errors other than not found are not retried.
+	calls = 0
+	forbidden := &APIError{StatusCode: http.StatusForbidden, Status: "403 Forbidden"}
+	if _, err := WaitUntilVisible(ctx, func() (int, error) {
+		calls++
+		return 0, forbidden
+	}); err != forbidden || calls != 1 {
+		t.Errorf("WaitUntilVisible = %v after %d calls, want 403 after 1", err, calls)
+	}
+
+	// An object that never appears yields the not-found error once the
+	// context expires.
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := WaitUntilVisible(short, func() (int, error) {
+		return 0, notFound
+	}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("WaitUntilVisible = %v, want ErrNotFound", err)
+	}
+}
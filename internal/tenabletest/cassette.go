@@ -0,0 +1,236 @@
+// Package tenabletest provides helpers for testing code that talks to
+// the Tenable API.  Its Recorder records real API interactions into a
+// cassette file once, with credentials scrubbed, and replays them in
+// CI, so tests can exercise realistic pagination, error bodies and
+// export flows without live credentials.
+package tenabletest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// RecordEnv is the environment variable that switches ModeFromEnv to
+// recording when set to 1.
+const RecordEnv = "TENABLE_RECORD"
+
+// Mode selects whether a Recorder talks to the API or to a cassette.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and fails requests
+	// it has no recording for.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the API and writes the
+	// interactions to the cassette on Stop.
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord when RecordEnv is set to 1 and
+// ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) == "1" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as stored in a cassette.  URI is the
+// path and query only, so a cassette recorded against one tenant
+// replays against any base URL.
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	URI     string            `json:"uri"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as stored in a cassette.
+type RecordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records or replays API
+// interactions.  Credential headers and password, secret and token
+// fields of JSON bodies are scrubbed before they are stored.
+type Recorder struct {
+	mode Mode
+	path string
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder returns a recorder for the cassette at path.  In
+// ModeRecord requests are sent through next, or http.DefaultTransport
+// when nil; in ModeReplay the cassette must exist.
+func NewRecorder(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, next: next}
+	if r.next == nil {
+		r.next = http.DefaultTransport
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(b, &r.interactions); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// RoundTrip records or replays the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    tenable.RedactHeaders(resp.Header),
+			Body:       scrubBody(body),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers req with the first unused interaction that matches
+// its method, URI and scrubbed body.  Repeated identical requests are
+// answered in recording order.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request.Method != recorded.Method || in.Request.URI != recorded.URI || in.Request.Body != recorded.Body {
+			continue
+		}
+		r.used[i] = true
+		header := make(http.Header, len(in.Response.Headers))
+		for name, value := range in.Response.Headers {
+			header.Set(name, value)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no recorded interaction for %s %s", r.path, recorded.Method, recorded.URI)
+}
+
+// Stop writes the recorded interactions to the cassette in
+// ModeRecord.  It does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// recordRequest captures req for storing or matching, leaving its
+// body readable.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method:  req.Method,
+		URI:     req.URL.RequestURI(),
+		Headers: tenable.RedactHeaders(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return recorded, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(b))
+		recorded.Body = scrubBody(b)
+	}
+	return recorded, nil
+}
+
+// readBody reads and closes the response body, decompressing it when
+// the client asked for gzip itself.
+func readBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = zr
+	}
+	return io.ReadAll(body)
+}
+
+// scrubBody returns b with sensitive JSON fields redacted.  Bodies
+// that are not JSON are kept verbatim.
+func scrubBody(b []byte) string {
+	var v interface{}
+	if json.Unmarshal(b, &v) != nil {
+		return string(b)
+	}
+	scrubbed, err := json.Marshal(tenable.RedactJSON(v))
+	if err != nil {
+		return string(b)
+	}
+	return string(scrubbed)
+}
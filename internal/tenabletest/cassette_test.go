@@ -0,0 +1,77 @@
+package tenabletest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestRecorder records a session against a fake API, checks that the
+// cassette is scrubbed and replays it without the server.
+func TestRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /users":
+			w.Write([]byte(`{"id":7,"username":"alice"}`))
+		case "GET /users/8":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"User not found"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+	cassette := filepath.Join(t.TempDir(), "users.json")
+
+	run := func(rec *Recorder, base string) {
+		t.Helper()
+		client := tenable.NewClient("access", "secret-key",
+			tenable.WithBaseURL(base),
+			tenable.WithTransport(rec),
+			tenable.WithRetries(0, 0, 0),
+		)
+		user, err := client.CreateUser(context.Background(), "alice", "hunter2", 16, "", "", "local", true)
+		if err != nil || user.ID != 7 {
+			t.Fatalf("CreateUser = %+v, %v", user, err)
+		}
+		var apiErr *tenable.APIError
+		if _, err := client.GetUser(context.Background(), 8); !errors.As(err, &apiErr) || apiErr.Message != "User not found" {
+			t.Fatalf("GetUser = %v, want the recorded 404", err)
+		}
+	}
+
+	rec, err := NewRecorder(cassette, ModeRecord, ts.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(rec, ts.URL)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	b, _ := os.ReadFile(cassette)
+	for _, secret := range []string{"secret-key", "hunter2", "127.0.0.1"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, b)
+		}
+	}
+
+	ts.Close()
+	rec, err = NewRecorder(cassette, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(rec, "https://cloud.tenable.com")
+
+	// Requests that were never recorded fail instead of reaching the
+	// network.
+	if _, err := rec.RoundTrip(httptest.NewRequest(http.MethodGet, "https://cloud.tenable.com/scans", nil)); err == nil {
+		t.Error("RoundTrip of an unrecorded request succeeded")
+	}
+}
@@ -5,34 +5,70 @@ import (
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 )
 
-// main is the entrypoint for the Terraform provider plugin.  It
-// delegates to the plugin framework's providerserver to serve the
-// provider over RPC.  The debug flag enables support for
+// providerAddress identifies the provider in Terraform configurations.
+// When publishing to the Terraform Registry this should follow the
+// registry namespace pattern.  For local development any address may
+// be used as long as it matches the CLI configuration.
+const providerAddress = "registry.terraform.io/tenable/tenablevm"
+
+// protocol5Servers lists additional protocol version 5 provider servers
+// (for example an SDKv2 shim during a migration) that are upgraded to
+// protocol version 6 and muxed with the framework provider.  Each
+// resource and data source type must be served by exactly one server.
+var protocol5Servers []func() tfprotov5.ProviderServer
+
+// main is the entrypoint for the Terraform provider plugin.  It serves
+// the framework provider over protocol version 6, muxed with any
+// protocol 5 servers, so framework-only features such as provider
+// functions are available.  The debug flag enables support for
 // debugging tools such as delve when set.
 func main() {
 	var debug bool
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	// Serve the provider.  The address identifies the provider in
-	// Terraform configurations.  When publishing to the Terraform
-	// Registry this should follow the registry namespace pattern
-	// (e.g. registry.terraform.io/tenable/tenablevm).  For local
-	// development any address may be used as long as it matches the
-	// CLI configuration.
-	err := providerserver.Serve(
-		context.Background(),
-		func() provider.Provider { return NewProvider("dev") },
-		providerserver.ServeOpts{
-			Address: "registry.terraform.io/tenable/tenablevm",
-			Debug:   debug,
-		},
-	)
+	ctx := context.Background()
+	serverFactory, err := newProviderServer(ctx, "dev")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+	err = tf6server.Serve(providerAddress, serverFactory, serveOpts...)
+	// Serve returns once Terraform shuts the provider down.
+	logAPIMetrics()
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// newProviderServer combines the framework provider with the upgraded
+// protocol 5 servers into a single protocol 6 server.
+func newProviderServer(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
+	servers := []func() tfprotov6.ProviderServer{
+		providerserver.NewProtocol6(NewProvider(version)),
+	}
+	for _, server := range protocol5Servers {
+		upgraded, err := tf5to6server.UpgradeServer(ctx, server)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, func() tfprotov6.ProviderServer { return upgraded })
+	}
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// TestNewProviderServer verifies that the muxed protocol 6 server
+// exposes the framework provider's resources and functions.
+func TestNewProviderServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := newProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatalf("newProviderServer error: %v", err)
+	}
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema error: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if _, ok := resp.ResourceSchemas["tenablevm_user"]; !ok {
+		t.Errorf("tenablevm_user resource missing from provider schema")
+	}
+	if _, ok := resp.Functions["severity_name"]; !ok {
+		t.Errorf("severity_name function missing from provider schema")
+	}
+}
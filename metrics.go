@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// configuredClient is an API client together with the context of the
+// Configure call that created it, whose logger is used to report the
+// client's usage.
+type configuredClient struct {
+	ctx    context.Context
+	client *tenable.Client
+}
+
+// apiClients records every client configured by this provider process,
+// one per provider alias, so their call counts can be logged when the
+// provider server stops.
+var (
+	apiClientsMu sync.Mutex
+	apiClients   []configuredClient
+)
+
+// registerAPIClient adds a configured client to apiClients.
+func registerAPIClient(ctx context.Context, client *tenable.Client) {
+	apiClientsMu.Lock()
+	defer apiClientsMu.Unlock()
+	apiClients = append(apiClients, configuredClient{ctx: ctx, client: client})
+}
+
+// logAPIMetrics logs a summary of the API calls made by each configured
+// client, so practitioners can see how much of their API quota a run
+// consumed.  Clients that made no calls are skipped.
+func logAPIMetrics() {
+	apiClientsMu.Lock()
+	defer apiClientsMu.Unlock()
+	for _, c := range apiClients {
+		m := c.client.Metrics()
+		if m.Total() == 0 {
+			continue
+		}
+		base := c.client.BaseURL
+		if base == "" {
+			base = tenable.DefaultBaseURL
+		}
+		tflog.Info(c.ctx, "Tenable API usage", map[string]any{
+			"base_url":       base,
+			"requests_total": m.Total(),
+			"requests":       m.Requests,
+			"retries":        m.Retries,
+			"client_errors":  m.ClientErrors,
+			"server_errors":  m.ServerErrors,
+		})
+	}
+}
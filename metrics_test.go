@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestLogAPIMetrics verifies that the usage of each configured client
+// is logged and that idle clients are skipped.
+func TestLogAPIMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	saved := apiClients
+	apiClients = nil
+	defer func() { apiClients = saved }()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	busy, idle := newTestClient(ts), newTestClient(ts)
+	registerAPIClient(ctx, busy)
+	registerAPIClient(ctx, idle)
+	busy.ListRoles(context.Background())
+	busy.ListRoles(context.Background())
+
+	logAPIMetrics()
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one log entry, got %v (%v)", entries, err)
+	}
+	entry := entries[0]
+	if entry["@message"] != "Tenable API usage" || entry["requests_total"] != float64(2) {
+		t.Errorf("unexpected log entry: %v", entry)
+	}
+	if requests, _ := entry["requests"].(map[string]interface{}); requests["GET /roles"] != float64(2) {
+		t.Errorf("unexpected per-endpoint counts: %v", entry["requests"])
+	}
+	if entry["base_url"] != tenable.DefaultBaseURL {
+		t.Errorf("base_url = %v", entry["base_url"])
+	}
+}
@@ -2,11 +2,22 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -14,12 +25,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	// Add structured logging
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
 )
 
 // Ensure the provider satisfies the expected interfaces. The provider
 // must implement the provider.Provider interface.  The framework
 // enforces these interfaces at compile time.
 var _ provider.Provider = &tenablevmProvider{}
+var _ provider.ProviderWithValidateConfig = &tenablevmProvider{}
+var _ provider.ProviderWithFunctions = &tenablevmProvider{}
 
 // tenablevmProvider models the Terraform provider implementation.  It
 // holds the version string which is set when building the plugin.
@@ -51,13 +66,94 @@ func (p *tenablevmProvider) Metadata(_ context.Context, _ provider.MetadataReque
 
 // tenableProviderModel maps provider configuration schema data into a
 // Go struct.  The `tfsdk` struct tags correspond to the schema
-// attribute names.  All fields are defined as types.String to take
-// advantage of the framework's null/unknown semantics.
+// attribute names.  All fields use the framework's types to take
+// advantage of their null/unknown semantics.
 type tenableProviderModel struct {
 	AccessKey types.String `tfsdk:"access_key"`
 	SecretKey types.String `tfsdk:"secret_key"`
+	ProxyURL  types.String `tfsdk:"proxy_url"`
+
+	Environment types.String `tfsdk:"environment"`
+	BaseURL     types.String `tfsdk:"base_url"`
+
+	AccessKeyFile types.String `tfsdk:"access_key_file"`
+	SecretKeyFile types.String `tfsdk:"secret_key_file"`
+
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+
+	ImpersonateUsername types.String `tfsdk:"impersonate_username"`
+	UserAgentExtra      types.String `tfsdk:"user_agent_extra"`
+	ExtraHeaders        types.Map    `tfsdk:"extra_headers"`
+
+	Profile               types.String `tfsdk:"profile"`
+	SharedCredentialsFile types.String `tfsdk:"shared_credentials_file"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	ListCacheTTL   types.String `tfsdk:"list_cache_ttl"`
+
+	MaxIdleConns    types.Int64  `tfsdk:"max_idle_conns"`
+	IdleConnTimeout types.String `tfsdk:"idle_conn_timeout"`
+	DisableHTTP2    types.Bool   `tfsdk:"disable_http2"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait types.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	RateLimit             types.Float64 `tfsdk:"rate_limit"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	HTTPDebug             types.Bool    `tfsdk:"http_debug"`
+	VerifyConnection      types.Bool    `tfsdk:"verify_connection"`
+	CheckUsernames        types.Bool    `tfsdk:"check_usernames"`
+
+	DefaultTags *providerDefaultTagsModel `tfsdk:"default_tags"`
 }
 
+// providerDefaultTagsModel maps the default_tags block.
+type providerDefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// providerData is handed to every resource and data source by
+// Configure.  It carries the API client together with provider-wide
+// settings that resources apply on top of their own configuration.
+type providerData struct {
+	Client *tenable.Client
+
+	// DefaultTags maps tag categories to values that tag-aware
+	// resources add to the tags they manage.  No resource is tag-aware
+	// yet, so they are only validated for now.
+	DefaultTags map[string]string
+
+	// CheckUsernames makes tenablevm_user look up the usernames of
+	// new users during plan.
+	CheckUsernames bool
+}
+
+// defaultRequestTimeout bounds each attempt of a single-object API
+// call, including reading the response body, when request_timeout is
+// not configured.  The client allows list calls and exports longer.
+const defaultRequestTimeout = 60 * time.Second
+
+// defaultListCacheTTL is how long user, role and group lists are
+// reused, long enough to cover the refresh phase of a typical plan.
+const defaultListCacheTTL = 5 * time.Minute
+
+// Default retry policy applied when the provider configuration does
+// not override it.  Three retries with a 1s to 30s backoff ride out
+// short rate limiting bursts without stalling an apply for long.
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMinWait = 1 * time.Second
+	defaultRetryMaxWait = 30 * time.Second
+)
+
 // Schema defines the provider-level configuration schema. The provider
 // accepts optional access_key and secret_key attributes (falling back to
 // environment variables). Sensitive fields are marked accordingly so
@@ -76,11 +172,679 @@ func (p *tenablevmProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Sensitive:   true,
 				Description: "Tenable Vulnerability Management API secret key. Can also be provided via the TENABLE_SECRET_KEY environment variable.",
 			},
+			"access_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a file containing the API access key, e.g. a mounted Kubernetes or Vault agent secret. Surrounding whitespace is ignored. Conflicts with access_key.",
+			},
+			"secret_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a file containing the API secret key. Surrounding whitespace is ignored. Conflicts with secret_key.",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "Username for session authentication, for accounts without API keys. When set, the provider logs in via /session instead of using access_key and secret_key. Can also be provided via the TENABLE_USERNAME environment variable, which is ignored when access_key, secret_key or their _file variants are configured.",
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for session authentication. Can also be provided via the TENABLE_PASSWORD environment variable.",
+			},
+			"impersonate_username": schema.StringAttribute{
+				Optional:    true,
+				Description: "Username to impersonate on every request via the X-Impersonate header, e.g. to manage an MSSP child account. The authenticated user must be allowed to impersonate. Can also be provided via the TENABLE_IMPERSONATE_USERNAME environment variable.",
+			},
+			"user_agent_extra": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text appended to the User-Agent header of every request, e.g. a team or pipeline name for attributing API traffic.",
+			},
+			"extra_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "HTTP headers added to every request, e.g. an organization's trace or cost-centre headers required by an egress proxy. Authentication, impersonation, User-Agent and Content-Type headers cannot be set here.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Profile in the shared credentials file to read the API keys from when they are not set directly or via environment variables. Can also be provided via the TENABLE_PROFILE environment variable. Defaults to default.",
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the INI-style shared credentials file. Can also be provided via the TENABLE_SHARED_CREDENTIALS_FILE environment variable. Defaults to ~/.tenable/credentials.",
+			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Tenable cloud environment to connect to: us, eu, ap or us-fed (FedRAMP). Selects the matching API endpoint. Conflicts with base_url. Can also be provided via the TENABLE_ENVIRONMENT environment variable.",
+			},
+			"base_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base URL of the Tenable API, e.g. https://cloud.tenable.com. Conflicts with environment. Can also be provided via the TENABLE_BASE_URL environment variable.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of an HTTP(S) proxy used to reach the Tenable API (e.g. http://proxy.example.com:3128). When unset, the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are honoured.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded CA bundle trusted in addition to the system roots, e.g. for a TLS inspecting proxy with a private CA. Conflicts with ca_cert_pem.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA bundle trusted in addition to the system roots. Conflicts with ca_cert_file.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disable TLS certificate verification. Only use this for troubleshooting; prefer ca_cert_file or ca_cert_pem.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded client certificate presented to mutual TLS enforcing gateways. Requires client_key_file.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the PEM encoded private key for client_cert_file.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Timeout for each attempt of an API call that reads or changes a single object, as a duration string (e.g. 90s, 5m). Defaults to 60s. List calls and exports allow at least 3m and 15m respectively, or this timeout if it is longer.",
+			},
+			"list_cache_ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long user, role and group lists are reused by data sources and resources, as a duration string (e.g. 30s, 10m). Changes made through this provider refresh the cache; set to 0s to always query the API. Defaults to 5m.",
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of idle keep-alive connections kept open to the Tenable API. Raise it when large plans with high parallelism keep opening new connections. Defaults to 100.",
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long an idle connection is kept open, as a duration string (e.g. 90s, 5m). Defaults to 90s.",
+			},
+			"disable_http2": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use HTTP/1.1 only. Set this when a proxy between Terraform and Tenable mishandles HTTP/2.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of times a request is retried after a transient failure: rate limiting (429), a server error (500, 502, 503, 504) or a dropped connection. Requests that create objects (POST) are only retried after rate limiting. Set to 0 to disable retries. Defaults to 3.",
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "Wait before the first retry, as a duration string (e.g. 500ms, 2s). The wait doubles on each subsequent retry. Defaults to 1s.",
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum wait between retries, as a duration string (e.g. 30s, 1m). Defaults to 30s.",
+			},
+			"rate_limit": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests per second, shared by all resources and data sources of this provider instance and by any other provider alias that authenticates with the same credentials against the same endpoint (the lowest configured rate applies). Fractional values such as 0.5 are allowed. Unlimited when unset.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests this provider instance runs at once, independent of Terraform's -parallelism. Further requests wait for a running one to finish. Useful on small tenants that are rate limited during a refresh of many resources. Unlimited when unset.",
+			},
+			"verify_connection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Check during provider configuration that the Tenable API is reachable, so proxy, TLS and endpoint problems are reported before any resource is planned. A platform reporting that it is not ready produces a warning. The check does not verify the credentials. Defaults to false.",
+			},
+			"check_usernames": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Look up the username of every new tenablevm_user during plan and again before creating it, and fail when a user with that username already exists, instead of failing halfway through the apply with an opaque API error. Costs one user list per plan, reused for list_cache_ttl. Defaults to false.",
+			},
+			"http_debug": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Log every API request and response (method, path, status, latency, request id, headers and bodies) at debug level. Credentials and password, secret and token fields are redacted. Run with TF_LOG=DEBUG to see the output.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags to merge into the tags of tag-aware resources, with tags set on a resource taking precedence for the same category. Not applied yet: no resource manages tags so far, so the block is validated but has no effect, and setting it produces a warning.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Map of tag category to tag value, e.g. { Owner = \"platform\" }.",
+					},
+				},
+			},
 		},
 		Description: "The Tenable VM provider configures access to the Tenable Vulnerability Management API.",
 	}
 }
 
+// ValidateConfig checks the provider configuration for values that can
+// never work, so that terraform validate reports them against the
+// offending attribute instead of failing later during Configure.
+// Unknown values are skipped because they are only resolved at apply
+// time; environment variable fallbacks are also left to Configure.
+func (p *tenablevmProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config tenableProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An explicitly empty key would silently override the environment
+	// variable fallback and then fail as missing in Configure.
+	if !config.AccessKey.IsNull() && !config.AccessKey.IsUnknown() && config.AccessKey.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_key"),
+			"Empty Tenable API access key",
+			"The access_key attribute is set to an empty string. Remove it to use the TENABLE_ACCESS_KEY environment variable, or set a non-empty value.",
+		)
+	}
+	if !config.SecretKey.IsNull() && !config.SecretKey.IsUnknown() && config.SecretKey.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_key"),
+			"Empty Tenable API secret key",
+			"The secret_key attribute is set to an empty string. Remove it to use the TENABLE_SECRET_KEY environment variable, or set a non-empty value.",
+		)
+	}
+
+	for _, pair := range []struct {
+		key, file       string
+		keySet, fileSet bool
+	}{
+		{"access_key", "access_key_file", !config.AccessKey.IsNull(), !config.AccessKeyFile.IsNull()},
+		{"secret_key", "secret_key_file", !config.SecretKey.IsNull(), !config.SecretKeyFile.IsNull()},
+	} {
+		if pair.keySet && pair.fileSet {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(pair.file),
+				"Conflicting API key settings",
+				fmt.Sprintf("Only one of %s and %s may be set.", pair.key, pair.file),
+			)
+		}
+	}
+
+	keysSet := !config.AccessKey.IsNull() || !config.SecretKey.IsNull() || !config.AccessKeyFile.IsNull() || !config.SecretKeyFile.IsNull()
+	if keysSet && (!config.Username.IsNull() || !config.Password.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Conflicting authentication settings",
+			"Configure either access_key and secret_key, or username and password, but not both.",
+		)
+	}
+
+	if !config.Environment.IsNull() && !config.BaseURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Conflicting endpoint settings",
+			"Only one of environment and base_url may be set.",
+		)
+	}
+	if !config.Environment.IsNull() && !config.Environment.IsUnknown() {
+		if _, err := environmentBaseURL(config.Environment.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("environment"), "Invalid environment", err.Error()+".")
+		}
+	}
+	if !config.BaseURL.IsNull() && !config.BaseURL.IsUnknown() {
+		if err := validateBaseURL(config.BaseURL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Invalid base URL", "The base_url attribute is invalid: "+err.Error()+".")
+		}
+	}
+
+	if !config.ProxyURL.IsNull() && !config.ProxyURL.IsUnknown() {
+		if _, err := parseProxyURL(config.ProxyURL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid proxy URL",
+				"The proxy_url attribute is invalid: "+err.Error()+".",
+			)
+		}
+	}
+
+	caFileSet := !config.CACertFile.IsNull() && !config.CACertFile.IsUnknown()
+	caPEMSet := !config.CACertPEM.IsNull() && !config.CACertPEM.IsUnknown()
+	if caFileSet && caPEMSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Conflicting CA bundle settings",
+			"Only one of ca_cert_file and ca_cert_pem may be set.",
+		)
+	}
+	if (caFileSet || caPEMSet) && config.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"CA bundle ignored",
+			"insecure_skip_verify disables certificate verification, so the configured CA bundle has no effect.",
+		)
+	}
+
+	certSet := !config.ClientCertFile.IsNull() && !config.ClientCertFile.IsUnknown()
+	keySet := !config.ClientKeyFile.IsNull() && !config.ClientKeyFile.IsUnknown()
+	if certSet && !keySet && !config.ClientKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_key_file"),
+			"Missing client key",
+			"The client_key_file attribute must be set when client_cert_file is set.",
+		)
+	}
+	if keySet && !certSet && !config.ClientCertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert_file"),
+			"Missing client certificate",
+			"The client_cert_file attribute must be set when client_key_file is set.",
+		)
+	}
+
+	if !config.RequestTimeout.IsUnknown() {
+		_, diags := requestTimeout(config)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !config.ListCacheTTL.IsUnknown() {
+		_, diags := listCacheTTL(config)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !config.MaxIdleConns.IsUnknown() && !config.IdleConnTimeout.IsUnknown() {
+		_, diags := transportOptions(config)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !config.MaxRetries.IsUnknown() && !config.RetryMinWait.IsUnknown() && !config.RetryMaxWait.IsUnknown() {
+		_, _, _, diags := retrySettings(config)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !config.RateLimit.IsNull() && !config.RateLimit.IsUnknown() && config.RateLimit.ValueFloat64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rate_limit"),
+			"Invalid rate limit",
+			"The rate_limit attribute must be greater than zero. Remove it to disable rate limiting.",
+		)
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() && config.MaxConcurrentRequests.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid maximum concurrent requests",
+			"The max_concurrent_requests attribute must be greater than zero. Remove it to run requests without a cap.",
+		)
+	}
+
+	if !config.ExtraHeaders.IsUnknown() {
+		_, diags := extraHeaders(ctx, config)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if config.DefaultTags != nil && !config.DefaultTags.Tags.IsUnknown() {
+		tags, diags := defaultTags(ctx, config)
+		resp.Diagnostics.Append(diags...)
+		// No resource takes tags yet; say so rather than accept the
+		// block silently.
+		if len(tags) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("default_tags"),
+				"Default tags are not applied yet",
+				"No resource of this provider manages tags yet, so default_tags has no effect. The tags will be merged into tag-aware resources once they exist.",
+			)
+		}
+	}
+}
+
+// defaultTags returns the validated default_tags of the provider
+// configuration.  Tag categories cannot contain a colon, since tags
+// are referenced as Category:Value elsewhere in the provider.
+func defaultTags(ctx context.Context, config tenableProviderModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if config.DefaultTags == nil || config.DefaultTags.Tags.IsNull() {
+		return nil, diags
+	}
+	tagsPath := path.Root("default_tags").AtName("tags")
+	if config.DefaultTags.Tags.IsUnknown() {
+		diags.AddAttributeError(tagsPath, "Unknown default tags", "The provider cannot apply default_tags because their value is not known until apply. Use values that are known at plan time.")
+		return nil, diags
+	}
+	tags := make(map[string]string)
+	diags.Append(config.DefaultTags.Tags.ElementsAs(ctx, &tags, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for category, value := range tags {
+		if strings.TrimSpace(category) == "" || strings.Contains(category, ":") {
+			diags.AddAttributeError(tagsPath.AtMapKey(category), "Invalid default tag category", fmt.Sprintf("Tag category %q must be non-empty and must not contain a colon.", category))
+		}
+		if strings.TrimSpace(value) == "" {
+			diags.AddAttributeError(tagsPath.AtMapKey(category), "Invalid default tag value", fmt.Sprintf("The value of tag category %q must not be empty.", category))
+		}
+	}
+	return tags, diags
+}
+
+// reservedHeaders are set by the client itself and cannot be
+// overridden with extra_headers.
+var reservedHeaders = []string{"X-Apikeys", "X-Cookie", "X-Impersonate", "User-Agent", "Content-Type"}
+
+// extraHeaders resolves the extra_headers attribute.  Header names must
+// be valid and must not be one of reservedHeaders.
+func extraHeaders(ctx context.Context, config tenableProviderModel) (http.Header, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if config.ExtraHeaders.IsNull() {
+		return nil, diags
+	}
+	headersPath := path.Root("extra_headers")
+	if config.ExtraHeaders.IsUnknown() {
+		diags.AddAttributeError(headersPath, "Unknown extra headers", "The provider cannot send extra_headers because their value is not known until apply. Use values that are known at plan time.")
+		return nil, diags
+	}
+	values := make(map[string]string)
+	diags.Append(config.ExtraHeaders.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	headers := make(http.Header, len(values))
+	for name, value := range values {
+		switch {
+		case name == "" || strings.ContainsAny(name, " \t\r\n:"):
+			diags.AddAttributeError(headersPath.AtMapKey(name), "Invalid extra header", fmt.Sprintf("%q is not a valid HTTP header name.", name))
+		case slices.Contains(reservedHeaders, http.CanonicalHeaderKey(name)):
+			diags.AddAttributeError(headersPath.AtMapKey(name), "Reserved extra header", fmt.Sprintf("The %s header is set by the provider and cannot be overridden with extra_headers.", name))
+		default:
+			headers.Set(name, value)
+		}
+	}
+	return headers, diags
+}
+
+// tlsConfig builds the TLS client configuration from the provider
+// configuration.  A configured CA bundle is appended to the system
+// roots rather than replacing them, so the public Tenable endpoint
+// stays reachable when the bundle only covers an inspecting proxy.
+// A configured client certificate is presented to servers that
+// request one, such as mutual TLS enforcing egress gateways.
+func tlsConfig(config tenableProviderModel) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	certFile := config.ClientCertFile.ValueString()
+	keyFile := config.ClientKeyFile.ValueString()
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			diags.AddAttributeError(
+				path.Root("client_cert_file"),
+				"Incomplete client certificate",
+				"Both client_cert_file and client_key_file must be set to use a client certificate.",
+			)
+			return nil, diags
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert_file"),
+				"Unable to load client certificate",
+				fmt.Sprintf("The client certificate %q and key %q could not be loaded: %s", certFile, keyFile, err),
+			)
+			return nil, diags
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	var pemData []byte
+	var attr string
+	switch {
+	case !config.CACertFile.IsNull() && config.CACertFile.ValueString() != "":
+		attr = "ca_cert_file"
+		b, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(attr),
+				"Unable to read CA bundle",
+				fmt.Sprintf("The ca_cert_file %q could not be read: %s", config.CACertFile.ValueString(), err),
+			)
+			return nil, diags
+		}
+		pemData = b
+	case !config.CACertPEM.IsNull() && config.CACertPEM.ValueString() != "":
+		attr = "ca_cert_pem"
+		pemData = []byte(config.CACertPEM.ValueString())
+	default:
+		return cfg, diags
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid CA bundle",
+			"The "+attr+" attribute does not contain any PEM encoded certificates.",
+		)
+		return nil, diags
+	}
+	cfg.RootCAs = pool
+	return cfg, diags
+}
+
+// requestTimeout resolves the per-request timeout from the provider
+// configuration, falling back to the default when unset.
+func requestTimeout(config tenableProviderModel) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if config.RequestTimeout.IsNull() {
+		return defaultRequestTimeout, diags
+	}
+	d, err := time.ParseDuration(config.RequestTimeout.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid request timeout",
+			fmt.Sprintf("The request_timeout attribute must be a positive duration such as 90s or 5m, got %q.", config.RequestTimeout.ValueString()),
+		)
+		return 0, diags
+	}
+	return d, diags
+}
+
+// listCacheTTL resolves list_cache_ttl, falling back to the default.
+func listCacheTTL(config tenableProviderModel) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if config.ListCacheTTL.IsNull() {
+		return defaultListCacheTTL, diags
+	}
+	d, err := time.ParseDuration(config.ListCacheTTL.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			path.Root("list_cache_ttl"),
+			"Invalid list cache TTL",
+			fmt.Sprintf("The list_cache_ttl attribute must be a non-negative duration such as 30s or 10m, got %q.", config.ListCacheTTL.ValueString()),
+		)
+		return 0, diags
+	}
+	return d, diags
+}
+
+// transportOptions resolves the connection pool settings from the
+// provider configuration.  Unset attributes are left zero so the
+// client defaults apply.
+func transportOptions(config tenableProviderModel) (tenable.TransportOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := tenable.TransportOptions{DisableHTTP2: config.DisableHTTP2.ValueBool()}
+	if !config.MaxIdleConns.IsNull() {
+		opts.MaxIdleConns = int(config.MaxIdleConns.ValueInt64())
+		if opts.MaxIdleConns <= 0 {
+			diags.AddAttributeError(
+				path.Root("max_idle_conns"),
+				"Invalid idle connection limit",
+				"The max_idle_conns attribute must be greater than zero.",
+			)
+		}
+	}
+	if !config.IdleConnTimeout.IsNull() {
+		d, err := time.ParseDuration(config.IdleConnTimeout.ValueString())
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(
+				path.Root("idle_conn_timeout"),
+				"Invalid idle connection timeout",
+				fmt.Sprintf("The idle_conn_timeout attribute must be a positive duration such as 90s or 5m, got %q.", config.IdleConnTimeout.ValueString()),
+			)
+		}
+		opts.IdleConnTimeout = d
+	}
+	return opts, diags
+}
+
+// retrySettings resolves the retry policy from the provider
+// configuration, falling back to the defaults for unset attributes.
+// Invalid values are reported as attribute errors.
+func retrySettings(config tenableProviderModel) (int, time.Duration, time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	maxRetries := defaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+		if maxRetries < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid retry count",
+				"The max_retries attribute must not be negative.",
+			)
+		}
+	}
+	minWait, maxWait := defaultRetryMinWait, defaultRetryMaxWait
+	for _, a := range []struct {
+		name  string
+		value types.String
+		dest  *time.Duration
+	}{
+		{"retry_min_wait", config.RetryMinWait, &minWait},
+		{"retry_max_wait", config.RetryMaxWait, &maxWait},
+	} {
+		if a.value.IsNull() {
+			continue
+		}
+		d, err := time.ParseDuration(a.value.ValueString())
+		if err != nil || d < 0 {
+			diags.AddAttributeError(
+				path.Root(a.name),
+				"Invalid retry wait",
+				fmt.Sprintf("The %s attribute must be a non-negative duration such as 500ms or 30s, got %q.", a.name, a.value.ValueString()),
+			)
+			continue
+		}
+		*a.dest = d
+	}
+	if !diags.HasError() && minWait > maxWait {
+		diags.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid retry wait",
+			fmt.Sprintf("The retry_min_wait (%s) must not be greater than retry_max_wait (%s).", minWait, maxWait),
+		)
+	}
+	return maxRetries, minWait, maxWait, diags
+}
+
+// parseProxyURL parses and checks a proxy_url value.  The URL must be
+// absolute with an http, https or socks5 scheme and a host, because
+// http.Transport silently ignores proxies it cannot use.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("proxy URL %q could not be parsed: %s", raw, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy URL %q must use the http, https or socks5 scheme", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q must include a host", raw)
+	}
+	return u, nil
+}
+
+// readKeyFile reads an API key from a secret file, trimming the
+// trailing newline most secret mounts add.  An empty file is an error
+// so a missing secret is not mistaken for an unset key.
+func readKeyFile(filename string) (string, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimSpace(string(b))
+	if key == "" {
+		return "", fmt.Errorf("%s is empty", filename)
+	}
+	return key, nil
+}
+
+// userAgent builds the User-Agent header from the provider version
+// and the optional user_agent_extra suffix.
+func userAgent(version, extra string) string {
+	ua := "terraform-provider-tenablevm/" + version
+	if extra = strings.TrimSpace(extra); extra != "" {
+		ua += " " + extra
+	}
+	return ua
+}
+
+// environmentBaseURL returns the API endpoint for an environment
+// shortcut.
+func environmentBaseURL(environment string) (string, error) {
+	base, ok := tenable.EnvironmentBaseURL(environment)
+	if !ok {
+		return "", fmt.Errorf("unknown environment %q, expected one of: %s", environment, strings.Join(tenable.Environments(), ", "))
+	}
+	return base, nil
+}
+
+// validateBaseURL checks that a base_url is an absolute http(s) URL.
+func validateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("scheme must be https or http, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	return nil
+}
+
+// resolveBaseURL picks the API endpoint from base_url, environment or
+// their environment variables, in that order.  Configured attributes
+// always win over environment variables, and an empty result means
+// the default public endpoint.
+func resolveBaseURL(config tenableProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if config.BaseURL.IsUnknown() || config.Environment.IsUnknown() {
+		diags.AddAttributeError(path.Root("base_url"), "Unknown Tenable API endpoint", "The provider cannot create the Tenable API client because base_url or environment is not known until apply. Use values that are known at plan time.")
+		return "", diags
+	}
+	if !config.BaseURL.IsNull() {
+		if err := validateBaseURL(config.BaseURL.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("base_url"), "Invalid base URL", "The base_url attribute is invalid: "+err.Error()+".")
+		}
+		return config.BaseURL.ValueString(), diags
+	}
+	if !config.Environment.IsNull() {
+		base, err := environmentBaseURL(config.Environment.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("environment"), "Invalid environment", err.Error()+".")
+		}
+		return base, diags
+	}
+	if env := os.Getenv("TENABLE_BASE_URL"); env != "" {
+		if err := validateBaseURL(env); err != nil {
+			diags.AddAttributeError(path.Root("base_url"), "Invalid base URL", "The TENABLE_BASE_URL environment variable is invalid: "+err.Error()+".")
+		}
+		return env, diags
+	}
+	if env := os.Getenv("TENABLE_ENVIRONMENT"); env != "" {
+		base, err := environmentBaseURL(env)
+		if err != nil {
+			diags.AddAttributeError(path.Root("environment"), "Invalid environment", "The TENABLE_ENVIRONMENT environment variable is invalid: "+err.Error()+".")
+		}
+		return base, diags
+	}
+	return "", diags
+}
+
 // Configure prepares a Tenable VM API client for data sources and
 // resources.  It reads the provider configuration, applies
 // environment variable fallbacks, validates required fields, and
@@ -116,6 +880,48 @@ func (p *tenablevmProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
+	if config.AccessKeyFile.IsUnknown() || config.SecretKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_key_file"),
+			"Unknown Tenable API key file",
+			"The provider cannot create the Tenable API client because access_key_file or secret_key_file is not known until apply. Use paths that are known at plan time.",
+		)
+		return
+	}
+	if config.Username.IsUnknown() || config.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unknown Tenable session credentials",
+			"The provider cannot create the Tenable API client because there is an unknown value for the username or password. Either set the values directly in the configuration, or use the TENABLE_USERNAME and TENABLE_PASSWORD environment variables.",
+		)
+		return
+	}
+
+	// Session authentication takes the place of API keys entirely, so
+	// the key resolution below is skipped when a username is given.
+	// Keys configured explicitly win over session credentials from the
+	// environment, which may be exported for unrelated runs.
+	var username, password string
+	keysConfigured := !config.AccessKey.IsNull() || !config.SecretKey.IsNull() || !config.AccessKeyFile.IsNull() || !config.SecretKeyFile.IsNull()
+	if keysConfigured {
+		if os.Getenv("TENABLE_USERNAME") != "" || os.Getenv("TENABLE_PASSWORD") != "" {
+			tflog.Debug(ctx, "Ignoring TENABLE_USERNAME and TENABLE_PASSWORD because API keys are configured")
+		}
+	} else {
+		username = os.Getenv("TENABLE_USERNAME")
+		password = os.Getenv("TENABLE_PASSWORD")
+	}
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+	if username != "" || password != "" {
+		p.configureSession(ctx, config, username, password, resp)
+		return
+	}
+
 	// Default values to environment variables, override with config if provided
 	accessKey := os.Getenv("TENABLE_ACCESS_KEY")
 	secretKey := os.Getenv("TENABLE_SECRET_KEY")
@@ -126,20 +932,89 @@ func (p *tenablevmProvider) Configure(ctx context.Context, req provider.Configur
 	if !config.SecretKey.IsNull() {
 		secretKey = config.SecretKey.ValueString()
 	}
+	for _, f := range []struct {
+		name  string
+		value types.String
+		dest  *string
+	}{
+		{"access_key_file", config.AccessKeyFile, &accessKey},
+		{"secret_key_file", config.SecretKeyFile, &secretKey},
+	} {
+		if f.value.IsNull() {
+			continue
+		}
+		key, err := readKeyFile(f.value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Unable to read API key file",
+				fmt.Sprintf("The %s attribute could not be read: %s", f.name, err),
+			)
+			continue
+		}
+		*f.dest = key
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fall back to the shared credentials file for any key that is
+	// still missing.  A missing file is only an error when a profile
+	// or file was requested explicitly.
+	if accessKey == "" || secretKey == "" {
+		profile, explicit := os.Getenv("TENABLE_PROFILE"), false
+		if profile != "" {
+			explicit = true
+		}
+		if !config.Profile.IsNull() && !config.Profile.IsUnknown() && config.Profile.ValueString() != "" {
+			profile, explicit = config.Profile.ValueString(), true
+		}
+		if profile == "" {
+			profile = defaultProfile
+		}
+		filename := defaultSharedCredentialsFile()
+		if env := os.Getenv("TENABLE_SHARED_CREDENTIALS_FILE"); env != "" {
+			filename, explicit = env, true
+		}
+		if !config.SharedCredentialsFile.IsNull() && !config.SharedCredentialsFile.IsUnknown() && config.SharedCredentialsFile.ValueString() != "" {
+			filename, explicit = config.SharedCredentialsFile.ValueString(), true
+		}
+		creds, err := loadSharedCredentials(filename, profile)
+		switch {
+		case err == nil:
+			tflog.Debug(ctx, "Loaded Tenable API keys from shared credentials file", map[string]any{
+				"file":    filename,
+				"profile": profile,
+			})
+			if accessKey == "" {
+				accessKey = creds.AccessKey
+			}
+			if secretKey == "" {
+				secretKey = creds.SecretKey
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Unable to read shared credentials",
+				fmt.Sprintf("The API keys for profile %q could not be read from the shared credentials file: %s", profile, err),
+			)
+			return
+		}
+	}
 
 	// Validate required credentials
 	if accessKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_key"),
 			"Missing Tenable API access key",
-			"An access_key must be provided either in the configuration or via the TENABLE_ACCESS_KEY environment variable.",
+			"An access_key must be provided either in the configuration, via the TENABLE_ACCESS_KEY environment variable, or in the shared credentials file.",
 		)
 	}
 	if secretKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("secret_key"),
 			"Missing Tenable API secret key",
-			"A secret_key must be provided either in the configuration or via the TENABLE_SECRET_KEY environment variable.",
+			"A secret_key must be provided either in the configuration, via the TENABLE_SECRET_KEY environment variable, or in the shared credentials file.",
 		)
 	}
 	if resp.Diagnostics.HasError() {
@@ -153,47 +1028,235 @@ func (p *tenablevmProvider) Configure(ctx context.Context, req provider.Configur
 	ctx = tflog.SetField(ctx, "tenable_secret_key", secretKey)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "tenable_secret_key")
 
+	p.configureClient(ctx, config, accessKey, secretKey, nil, resp)
+}
+
+// configureSession validates the username and password for session
+// authentication and configures a client that logs in with them.
+func (p *tenablevmProvider) configureSession(ctx context.Context, config tenableProviderModel, username, password string, resp *provider.ConfigureResponse) {
+	if username == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Missing Tenable username",
+			"A username must be provided either in the configuration or via the TENABLE_USERNAME environment variable when a password is set.",
+		)
+	}
+	if password == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Tenable password",
+			"A password must be provided either in the configuration or via the TENABLE_PASSWORD environment variable when a username is set.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "tenable_username", username)
+	tflog.Debug(ctx, "Using session authentication")
+	p.configureClient(ctx, config, "", "", []tenable.Option{tenable.WithSession(username, password)}, resp)
+}
+
+// configureClient builds a client from the API keys, the
+// authentication options in auth and the transport, timeout and retry
+// settings, and makes it available to resources and data sources.
+func (p *tenablevmProvider) configureClient(ctx context.Context, config tenableProviderModel, accessKey, secretKey string, auth []tenable.Option, resp *provider.ConfigureResponse) {
 	// Log a debug message before constructing the API client【301259032402045†L324-L365】.
 	tflog.Debug(ctx, "Creating Tenable VM client")
 
-	// Construct the HTTP client with a reasonable timeout
-	httpClient := &http.Client{Timeout: 60 * time.Second}
-	apiClient := &Client{
-		AccessKey: accessKey,
-		SecretKey: secretKey,
-		Http:      httpClient,
+	// Build the transport from the default one so that the standard
+	// HTTPS_PROXY/NO_PROXY environment handling is preserved, with the
+	// connection pool tuned for a single API host, then apply an
+	// explicit proxy if configured.
+	poolOpts, diags := transportOptions(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	transport := tenable.NewTransport(poolOpts)
+	if !config.ProxyURL.IsNull() && config.ProxyURL.ValueString() != "" {
+		proxyURL, err := parseProxyURL(config.ProxyURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid proxy URL",
+				"The proxy_url attribute is invalid: "+err.Error()+".",
+			)
+			return
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		tflog.Debug(ctx, "Using configured HTTP proxy", map[string]any{"proxy_host": proxyURL.Host})
+	}
+	tlsClientConfig, diags := tlsConfig(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tlsClientConfig.InsecureSkipVerify {
+		tflog.Warn(ctx, "TLS certificate verification is disabled for the Tenable VM client")
+	}
+	transport.TLSClientConfig = tlsClientConfig
+
+	timeout, diags := requestTimeout(config)
+	resp.Diagnostics.Append(diags...)
+	base, diags := resolveBaseURL(config)
+	resp.Diagnostics.Append(diags...)
+	maxRetries, retryMinWait, retryMaxWait, diags := retrySettings(config)
+	resp.Diagnostics.Append(diags...)
+	cacheTTL, diags := listCacheTTL(config)
+	resp.Diagnostics.Append(diags...)
+	headers, diags := extraHeaders(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	opts := append(auth,
+		tenable.WithTimeout(timeout),
+		tenable.WithRetries(maxRetries, retryMinWait, retryMaxWait),
+		tenable.WithListCacheTTL(cacheTTL),
+		tenable.WithUserAgent(userAgent(p.version, config.UserAgentExtra.ValueString())),
+		tenable.WithLogger(ctx),
+	)
+	impersonate := os.Getenv("TENABLE_IMPERSONATE_USERNAME")
+	if !config.ImpersonateUsername.IsNull() && !config.ImpersonateUsername.IsUnknown() {
+		impersonate = config.ImpersonateUsername.ValueString()
+	}
+	if impersonate != "" {
+		opts = append(opts, tenable.WithImpersonation(impersonate))
+		tflog.Debug(ctx, "Impersonating Tenable VM user", map[string]any{"impersonate_username": impersonate})
+	}
+	if base != "" {
+		opts = append(opts, tenable.WithBaseURL(base))
+		tflog.Debug(ctx, "Using Tenable API endpoint", map[string]any{"base_url": base})
+	}
+	if !config.RateLimit.IsNull() && config.RateLimit.ValueFloat64() > 0 {
+		opts = append(opts, tenable.WithRateLimit(config.RateLimit.ValueFloat64()))
+		tflog.Debug(ctx, "Rate limiting API requests", map[string]any{"requests_per_second": config.RateLimit.ValueFloat64()})
+	}
+	if !config.MaxConcurrentRequests.IsNull() && config.MaxConcurrentRequests.ValueInt64() > 0 {
+		opts = append(opts, tenable.WithMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64())))
+		tflog.Debug(ctx, "Capping concurrent API requests", map[string]any{"max_concurrent_requests": config.MaxConcurrentRequests.ValueInt64()})
+	}
+
+	// Every API call is logged at debug level; http_debug additionally
+	// logs the redacted requests and responses.
+	var rt http.RoundTripper = transport
+	if config.HTTPDebug.ValueBool() {
+		rt = &debugTransport{ctx: ctx, rt: rt}
+		tflog.Debug(ctx, "HTTP wire-level debug logging enabled")
+	}
+	opts = append(opts, tenable.WithTransport(rt))
+	if len(headers) > 0 {
+		opts = append(opts, tenable.WithMiddleware(tenable.HeaderMiddleware(headers)))
+		tflog.Debug(ctx, "Adding extra headers to API requests", map[string]any{"headers": slices.Sorted(maps.Keys(headers))})
+	}
+	apiClient := tenable.NewClient(accessKey, secretKey, opts...)
+
 	// Tenable does not provide a lightweight endpoint to validate
 	// credentials without side effects.  As such, we assume the
 	// credentials are valid and defer any errors to resource CRUD
 	// operations.  Diagnostics generated during those operations will
-	// surface to the practitioner.
+	// surface to the practitioner.  verify_connection at least checks
+	// that the API can be reached.
+	if config.VerifyConnection.ValueBool() {
+		verifyConnection(ctx, apiClient, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tags, diags := defaultTags(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	registerAPIClient(ctx, apiClient)
+	data := &providerData{Client: apiClient, DefaultTags: tags, CheckUsernames: config.CheckUsernames.ValueBool()}
 
 	// Make the Tenable client available to resources and data sources
-	resp.ResourceData = apiClient
-	resp.DataSourceData = apiClient
+	resp.ResourceData = data
+	resp.DataSourceData = data
 
 	// Log an info message indicating successful configuration【301259032402045†L324-L365】.
 	tflog.Info(ctx, "Configured Tenable VM client", map[string]any{"success": true})
 }
 
+// verifyConnection pings the API and reports an unreachable API as an
+// error and a platform that is not ready as a warning.
+func verifyConnection(ctx context.Context, client TenableAPI, diags *diag.Diagnostics) {
+	status, err := client.Ping(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to reach the Tenable API",
+			"verify_connection is set and the status check failed. Check base_url, proxy_url and the TLS settings.\n\n"+errorDetail(err),
+		)
+		return
+	}
+	if status.Health() != tenable.HealthOK {
+		diags.AddWarning(
+			"Tenable platform is degraded",
+			fmt.Sprintf("The Tenable API reported status %q (code %d). Operations may fail until the platform is ready.", status.Status, status.Code),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Verified Tenable API connection", map[string]any{"status": status.Status})
+}
+
 // Resources defines the resources implemented in this provider.  The
 // returned slice contains factory functions which instantiate new
-// resource types on demand.  In this provider we expose a single
-// resource for managing Tenable VM users.
+// resource types on demand.  In this provider we expose resources for
+// managing Tenable VM users, groups, roles and permissions, the tag
+// taxonomy, scanner networks, agents and agent group membership, scans,
+// scan policies and managed credentials, and for bulk asset deletion,
+// plus a generic REST resource for endpoints that are not modelled yet.
 func (p *tenablevmProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewUserResource,
+		NewGroupResource,
+		NewGroupMembershipResource,
+		NewRoleResource,
+		NewTagCategoryResource,
+		NewTagValueResource,
+		NewPermissionResource,
+		NewNetworkScannerAssignmentResource,
+		NewAgentResource,
+		NewAgentGroupMembershipResource,
+		NewScanResource,
+		NewPolicyResource,
+		NewManagedCredentialResource,
+		NewAssetDeletionResource,
+		NewRestResource,
 	}
 }
 
 // DataSources defines the data sources implemented in this provider. The
-// provider exposes user, role, and group data sources for Tenable VM.
+// provider exposes user, role, group, scanner, agent, agent group and
+// scan template data sources for Tenable VM.
 func (p *tenablevmProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUserDataSource,
 		NewRoleDataSource,
 		NewGroupDataSource,
+		NewScannersDataSource,
+		NewAgentsDataSource,
+		NewAgentGroupDataSource,
+		NewScanTemplateDataSource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented in this
+// provider.  Functions are pure helpers that do not call the Tenable
+// API and therefore do not require a configured client.
+func (p *tenablevmProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewSeverityNameFunction,
+		NewSeverityNumberFunction,
+		NewParseTagFunction,
+		NewFormatTagFunction,
+		NewPermissionIDFunction,
+		NewPermissionNameFunction,
+		NewNormalizeTargetsFunction,
+		NewScanScheduleFunction,
 	}
 }
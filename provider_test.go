@@ -2,12 +2,60 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
 )
 
+// rewriteTransport sends every request to a test server regardless of
+// the client's base URL.
+type rewriteTransport struct {
+	base *url.URL
+	rt   http.RoundTripper
+}
+
+func (r rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u := *req.URL
+	u.Scheme = r.base.Scheme
+	u.Host = r.base.Host
+	req.URL = &u
+	return r.rt.RoundTrip(req)
+}
+
+// newTestClient returns an API client that talks to ts without
+// retrying failed requests.
+func newTestClient(ts *httptest.Server) *tenable.Client {
+	base, _ := url.Parse(ts.URL)
+	return tenable.NewClient("access", "secret",
+		tenable.WithTransport(rewriteTransport{base: base, rt: ts.Client().Transport}),
+		tenable.WithRetries(0, 0, 0),
+	)
+}
+
 // TestNewProvider_Metadata verifies that Metadata returns the expected
 // type name and version string.
 func TestNewProvider_Metadata(t *testing.T) {
@@ -58,13 +106,33 @@ func TestProvider_Schema(t *testing.T) {
 // resource implementations.
 func TestProvider_Resources(t *testing.T) {
 	p := NewProvider("test").(*tenablevmProvider)
+	want := []string{
+		"tenablevm_user",
+		"tenablevm_group",
+		"tenablevm_group_membership",
+		"tenablevm_role",
+		"tenablevm_tag_category",
+		"tenablevm_tag_value",
+		"tenablevm_permission",
+		"tenablevm_network_scanner_assignment",
+		"tenablevm_agent",
+		"tenablevm_agent_group_membership",
+		"tenablevm_scan",
+		"tenablevm_policy",
+		"tenablevm_managed_credential",
+		"tenablevm_asset_deletion",
+		"tenablevm_rest",
+	}
 	rs := p.Resources(context.Background())
-	if len(rs) != 1 {
-		t.Fatalf("expected 1 resource, got %d", len(rs))
+	if len(rs) != len(want) {
+		t.Fatalf("expected %d resources, got %d", len(want), len(rs))
 	}
-	r := rs[0]()
-	if _, ok := r.(*userResource); !ok {
-		t.Fatalf("first resource type = %T, want *userResource", r)
+	for i, newResource := range rs {
+		var resp resource.MetadataResponse
+		newResource().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "tenablevm"}, &resp)
+		if resp.TypeName != want[i] {
+			t.Errorf("resource %d type name = %q, want %q", i, resp.TypeName, want[i])
+		}
 	}
 }
 
@@ -73,8 +141,8 @@ func TestProvider_Resources(t *testing.T) {
 func TestProvider_DataSources(t *testing.T) {
 	p := NewProvider("test").(*tenablevmProvider)
 	ds := p.DataSources(context.Background())
-	if len(ds) != 3 {
-		t.Fatalf("expected 3 data sources, got %d", len(ds))
+	if len(ds) != 7 {
+		t.Fatalf("expected 7 data sources, got %d", len(ds))
 	}
 	if _, ok := ds[0]().(*userDataSource); !ok {
 		t.Errorf("first data source = %T, want *userDataSource", ds[0]())
@@ -85,4 +153,704 @@ func TestProvider_DataSources(t *testing.T) {
 	if _, ok := ds[2]().(*groupDataSource); !ok {
 		t.Errorf("third data source = %T, want *groupDataSource", ds[2]())
 	}
+	if _, ok := ds[3]().(*scannersDataSource); !ok {
+		t.Errorf("fourth data source = %T, want *scannersDataSource", ds[3]())
+	}
+	if _, ok := ds[4]().(*agentsDataSource); !ok {
+		t.Errorf("fifth data source = %T, want *agentsDataSource", ds[4]())
+	}
+	if _, ok := ds[5]().(*agentGroupDataSource); !ok {
+		t.Errorf("sixth data source = %T, want *agentGroupDataSource", ds[5]())
+	}
+	if _, ok := ds[6]().(*scanTemplateDataSource); !ok {
+		t.Errorf("seventh data source = %T, want *scanTemplateDataSource", ds[6]())
+	}
+}
+
+func buildProviderConfig(ctx context.Context, sch schema.Schema, attrs map[string]tftypes.Value) tfsdk.Config {
+	attrTypes := make(map[string]tftypes.Type)
+	vals := make(map[string]tftypes.Value)
+	for name, attr := range sch.Attributes {
+		typ := attr.GetType().TerraformType(ctx)
+		attrTypes[name] = typ
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+	for name, block := range sch.Blocks {
+		typ := block.Type().TerraformType(ctx)
+		attrTypes[name] = typ
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+	raw := tftypes.NewValue(tftypes.Object{AttributeTypes: attrTypes}, vals)
+	return tfsdk.Config{Schema: sch, Raw: raw}
+}
+
+// TestProvider_ValidateConfig verifies that explicitly empty credentials
+// are rejected at validate time while omitted ones are accepted.
+func TestProvider_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, nil)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics for empty config: %v", resp.Diagnostics)
+	}
+
+	resp = provider.ValidateConfigResponse{}
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key": tftypes.NewValue(tftypes.String, ""),
+	})}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+
+	resp = provider.ValidateConfigResponse{}
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"max_retries":    tftypes.NewValue(tftypes.Number, -1),
+		"retry_min_wait": tftypes.NewValue(tftypes.String, "10s"),
+		"retry_max_wait": tftypes.NewValue(tftypes.String, "soon"),
+	})}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 errors, got %v", resp.Diagnostics)
+	}
+
+	resp = provider.ValidateConfigResponse{}
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"rate_limit": tftypes.NewValue(tftypes.Number, 0),
+	})}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error for zero rate_limit, got %v", resp.Diagnostics)
+	}
+
+	resp = provider.ValidateConfigResponse{}
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 0),
+	})}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error for zero max_concurrent_requests, got %v", resp.Diagnostics)
+	}
+}
+
+// TestProvider_ConfigureProxy verifies that proxy_url is wired into the
+// HTTP transport of the configured client and that malformed proxy
+// URLs are rejected at validate time.
+func TestProvider_ConfigureProxy(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	config := buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key": tftypes.NewValue(tftypes.String, "access"),
+		"secret_key": tftypes.NewValue(tftypes.String, "secret"),
+		"proxy_url":  tftypes.NewValue(tftypes.String, "http://proxy.example.com:3128"),
+	})
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	data, ok := resp.ResourceData.(*providerData)
+	if !ok {
+		t.Fatalf("ResourceData = %T, want *providerData", resp.ResourceData)
+	}
+	client := data.Client
+	if client.Timeout != defaultRequestTimeout {
+		t.Errorf("Timeout = %s, want default %s", client.Timeout, defaultRequestTimeout)
+	}
+	logging, ok := client.Http.Transport.(*tenable.LoggingTransport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *tenable.LoggingTransport", client.Http.Transport)
+	}
+	transport, ok := logging.Next.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", logging.Next)
+	}
+	req, _ := http.NewRequest(http.MethodGet, tenable.DefaultBaseURL+"/users", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.String() != "http://proxy.example.com:3128" {
+		t.Errorf("proxy = %v, %v; want http://proxy.example.com:3128", proxy, err)
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"proxy_url": tftypes.NewValue(tftypes.String, "proxy.example.com:3128"),
+	})}, &vresp)
+	if !vresp.Diagnostics.HasError() {
+		t.Errorf("expected error for proxy_url without scheme")
+	}
+}
+
+// TestProvider_ConfigureRequestTimeout verifies that request_timeout is
+// applied to the HTTP client and that invalid durations are rejected.
+func TestProvider_ConfigureRequestTimeout(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key":      tftypes.NewValue(tftypes.String, "access"),
+		"secret_key":      tftypes.NewValue(tftypes.String, "secret"),
+		"request_timeout": tftypes.NewValue(tftypes.String, "5m"),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if got := resp.ResourceData.(*providerData).Client.Timeout; got != 5*time.Minute {
+		t.Errorf("Timeout = %s, want 5m", got)
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"request_timeout": tftypes.NewValue(tftypes.String, "0s"),
+	})}, &vresp)
+	if !vresp.Diagnostics.HasError() {
+		t.Errorf("expected error for zero request_timeout")
+	}
+}
+
+// TestProvider_ConfigureListCache verifies the list cache TTL default,
+// that 0s disables it and that negative durations are rejected.
+func TestProvider_ConfigureListCache(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	for value, want := range map[string]time.Duration{"": defaultListCacheTTL, "0s": 0} {
+		attrs := map[string]tftypes.Value{
+			"access_key": tftypes.NewValue(tftypes.String, "access"),
+			"secret_key": tftypes.NewValue(tftypes.String, "secret"),
+		}
+		if value != "" {
+			attrs["list_cache_ttl"] = tftypes.NewValue(tftypes.String, value)
+		}
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, attrs)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%q: unexpected diagnostics: %v", value, resp.Diagnostics)
+		}
+		if got := resp.ResourceData.(*providerData).Client.ListCacheTTL; got != want {
+			t.Errorf("%q: ListCacheTTL = %s, want %s", value, got, want)
+		}
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"list_cache_ttl": tftypes.NewValue(tftypes.String, "-1m"),
+	})}, &vresp)
+	if !vresp.Diagnostics.HasError() {
+		t.Errorf("expected error for negative list_cache_ttl")
+	}
+}
+
+// TestProvider_ConfigureTransport verifies that the connection pool
+// attributes tune the transport and that invalid values are rejected.
+func TestProvider_ConfigureTransport(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key":        tftypes.NewValue(tftypes.String, "access"),
+		"secret_key":        tftypes.NewValue(tftypes.String, "secret"),
+		"max_idle_conns":    tftypes.NewValue(tftypes.Number, 32),
+		"idle_conn_timeout": tftypes.NewValue(tftypes.String, "2m"),
+		"disable_http2":     tftypes.NewValue(tftypes.Bool, true),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	transport := resp.ResourceData.(*providerData).Client.Http.Transport.(*tenable.LoggingTransport).Next.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 32 || transport.IdleConnTimeout != 2*time.Minute || transport.ForceAttemptHTTP2 {
+		t.Errorf("transport not tuned: per host %d, idle timeout %s, HTTP/2 %t", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout, transport.ForceAttemptHTTP2)
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"max_idle_conns":    tftypes.NewValue(tftypes.Number, 0),
+		"idle_conn_timeout": tftypes.NewValue(tftypes.String, "soon"),
+	})}, &vresp)
+	if vresp.Diagnostics.ErrorsCount() != 2 {
+		t.Errorf("expected 2 errors for invalid transport settings, got %v", vresp.Diagnostics)
+	}
+}
+
+// TestProvider_ConfigureProfile verifies that API keys are read from
+// the selected shared credentials profile and that an unknown profile
+// is reported.
+func TestProvider_ConfigureProfile(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("TENABLE_ACCESS_KEY", "")
+	t.Setenv("TENABLE_SECRET_KEY", "")
+	t.Setenv("TENABLE_PROFILE", "")
+	filename := filepath.Join(t.TempDir(), "credentials")
+	content := "[default]\naccess_key = a\nsecret_key = b\n\n[production]\naccess_key = prod-access\nsecret_key = prod-secret\n"
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+	t.Setenv("TENABLE_SHARED_CREDENTIALS_FILE", filename)
+
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"profile": tftypes.NewValue(tftypes.String, "production"),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	client := resp.ResourceData.(*providerData).Client
+	if client.AccessKey != "prod-access" || client.SecretKey != "prod-secret" {
+		t.Errorf("keys = %q/%q, want production profile", client.AccessKey, client.SecretKey)
+	}
+
+	resp = provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"profile": tftypes.NewValue(tftypes.String, "staging"),
+	})}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected error for unknown profile")
+	}
+}
+
+// TestProvider_ConfigureSession verifies that username and password
+// select session authentication and conflict with API keys.
+func TestProvider_ConfigureSession(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"username": tftypes.NewValue(tftypes.String, "breakglass"),
+		"password": tftypes.NewValue(tftypes.String, "hunter2"),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if client := resp.ResourceData.(*providerData).Client; client.Username != "breakglass" || client.Password != "hunter2" {
+		t.Errorf("expected session authenticated client, got %+v", client)
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key": tftypes.NewValue(tftypes.String, "access"),
+		"username":   tftypes.NewValue(tftypes.String, "breakglass"),
+	})}, &vresp)
+	if !vresp.Diagnostics.HasError() {
+		t.Errorf("expected error for API keys combined with username")
+	}
+}
+
+// TestProvider_ConfigureSessionEnvIgnoredWithKeys verifies that keys in
+// the configuration take precedence over session credentials exported
+// in the environment.
+func TestProvider_ConfigureSessionEnvIgnoredWithKeys(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("TENABLE_USERNAME", "breakglass")
+	t.Setenv("TENABLE_PASSWORD", "hunter2")
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key": tftypes.NewValue(tftypes.String, "access"),
+		"secret_key": tftypes.NewValue(tftypes.String, "secret"),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	client := resp.ResourceData.(*providerData).Client
+	if client.Username != "" || client.Password != "" || client.AccessKey != "access" || client.SecretKey != "secret" {
+		t.Errorf("expected API key authenticated client, got %+v", client)
+	}
+
+	// Without keys in the configuration the environment still selects
+	// session authentication.
+	t.Setenv("TENABLE_ACCESS_KEY", "")
+	t.Setenv("TENABLE_SECRET_KEY", "")
+	resp = provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, nil)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if client := resp.ResourceData.(*providerData).Client; client.Username != "breakglass" {
+		t.Errorf("expected session authenticated client, got %+v", client)
+	}
+}
+
+// TestProvider_ConfigureDefaultTags verifies that default_tags are
+// validated and handed to resources through the provider data.
+func TestProvider_ConfigureDefaultTags(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	tagsType := tftypes.Map{ElementType: tftypes.String}
+	blockType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tags": tagsType}}
+	defaultTagsBlock := func(tags map[string]string) tftypes.Value {
+		vals := make(map[string]tftypes.Value)
+		for k, v := range tags {
+			vals[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(blockType, map[string]tftypes.Value{"tags": tftypes.NewValue(tagsType, vals)})
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key":   tftypes.NewValue(tftypes.String, "access"),
+		"secret_key":   tftypes.NewValue(tftypes.String, "secret"),
+		"default_tags": defaultTagsBlock(map[string]string{"Owner": "platform", "Environment": "prod"}),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	got := resp.ResourceData.(*providerData).DefaultTags
+	want := map[string]string{"Owner": "platform", "Environment": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultTags = %v, want %v", got, want)
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"default_tags": defaultTagsBlock(map[string]string{"Owner:Team": "platform", "Empty": ""}),
+	})}, &vresp)
+	if vresp.Diagnostics.ErrorsCount() != 2 {
+		t.Errorf("expected 2 errors for invalid default tags, got %v", vresp.Diagnostics)
+	}
+	// Valid tags are accepted with a warning that nothing applies
+	// them yet.
+	vresp = provider.ValidateConfigResponse{}
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"default_tags": defaultTagsBlock(map[string]string{"Owner": "platform"}),
+	})}, &vresp)
+	if vresp.Diagnostics.HasError() || vresp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a single warning for default tags, got %v", vresp.Diagnostics)
+	}
+}
+
+// TestProvider_ConfigureEndpoint verifies that environment selects the
+// regional endpoint, base_url overrides it, and the two conflict.
+func TestProvider_ConfigureEndpoint(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("TENABLE_BASE_URL", "")
+	t.Setenv("TENABLE_ENVIRONMENT", "")
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	for attr, want := range map[string]string{
+		"environment": "https://fedcloud.tenable.com",
+		"base_url":    "https://tenable.internal.example.com",
+	} {
+		value := "US-Fed"
+		if attr == "base_url" {
+			value = want
+		}
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+			"access_key": tftypes.NewValue(tftypes.String, "access"),
+			"secret_key": tftypes.NewValue(tftypes.String, "secret"),
+			attr:         tftypes.NewValue(tftypes.String, value),
+		})}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", attr, resp.Diagnostics)
+		}
+		if got := resp.ResourceData.(*providerData).Client.BaseURL; got != want {
+			t.Errorf("%s: BaseURL = %s, want %s", attr, got, want)
+		}
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"environment": tftypes.NewValue(tftypes.String, "mars"),
+		"base_url":    tftypes.NewValue(tftypes.String, "ftp://tenable.example.com"),
+	})}, &vresp)
+	if vresp.Diagnostics.ErrorsCount() != 3 {
+		t.Errorf("expected conflict, environment and base_url errors, got %v", vresp.Diagnostics)
+	}
+}
+
+// TestProvider_ConfigureUserAgent verifies that requests carry the
+// provider version and the user_agent_extra suffix.
+func TestProvider_ConfigureUserAgent(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider("1.2.3").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key":       tftypes.NewValue(tftypes.String, "access"),
+		"secret_key":       tftypes.NewValue(tftypes.String, "secret"),
+		"user_agent_extra": tftypes.NewValue(tftypes.String, "team-red/ci"),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if got, want := resp.ResourceData.(*providerData).Client.UserAgent, "terraform-provider-tenablevm/1.2.3 team-red/ci"; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+}
+
+// TestProvider_ConfigureKeyFiles verifies that API keys are read from
+// secret files and that a key and its file conflict.
+func TestProvider_ConfigureKeyFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	accessFile := filepath.Join(dir, "access_key")
+	secretFile := filepath.Join(dir, "secret_key")
+	if err := os.WriteFile(accessFile, []byte("file-access\n"), 0o600); err != nil {
+		t.Fatalf("write access key: %v", err)
+	}
+	if err := os.WriteFile(secretFile, []byte("  file-secret\n"), 0o600); err != nil {
+		t.Fatalf("write secret key: %v", err)
+	}
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key_file": tftypes.NewValue(tftypes.String, accessFile),
+		"secret_key_file": tftypes.NewValue(tftypes.String, secretFile),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	client := resp.ResourceData.(*providerData).Client
+	if client.AccessKey != "file-access" || client.SecretKey != "file-secret" {
+		t.Errorf("keys = %q/%q, want values from files", client.AccessKey, client.SecretKey)
+	}
+
+	resp = provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key_file": tftypes.NewValue(tftypes.String, filepath.Join(dir, "missing")),
+		"secret_key":      tftypes.NewValue(tftypes.String, "secret"),
+	})}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected error for missing key file")
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key":      tftypes.NewValue(tftypes.String, "access"),
+		"access_key_file": tftypes.NewValue(tftypes.String, accessFile),
+	})}, &vresp)
+	if vresp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("expected 1 conflict error, got %v", vresp.Diagnostics)
+	}
+}
+
+// TestProvider_tlsConfig verifies that a custom CA bundle lets the
+// client verify a server signed by a private CA, and that conflicting
+// CA settings are rejected at validate time.
+func TestProvider_tlsConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	cfg, diags := tlsConfig(tenableProviderModel{CACertPEM: types.StringValue(string(caPEM))})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	res, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("request with custom CA failed: %v", err)
+	}
+	res.Body.Close()
+
+	cfg, _ = tlsConfig(tenableProviderModel{})
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	if res, err := client.Get(ts.URL); err == nil {
+		res.Body.Close()
+		t.Errorf("expected verification failure without custom CA")
+	}
+
+	if _, diags := tlsConfig(tenableProviderModel{CACertPEM: types.StringValue("not a certificate")}); !diags.HasError() {
+		t.Errorf("expected error for invalid PEM")
+	}
+
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"ca_cert_file": tftypes.NewValue(tftypes.String, "/etc/ssl/private-ca.pem"),
+		"ca_cert_pem":  tftypes.NewValue(tftypes.String, string(caPEM)),
+	})}, &vresp)
+	if !vresp.Diagnostics.HasError() {
+		t.Errorf("expected error when both ca_cert_file and ca_cert_pem are set")
+	}
+}
+
+// TestProvider_tlsConfigClientCertificate verifies that the configured
+// client certificate is presented to a server that requires mutual TLS.
+func TestProvider_tlsConfigClientCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-runner"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	clientCert, _ := x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform-runner" {
+			t.Errorf("client certificate not presented")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	ts.StartTLS()
+	defer ts.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	cfg, diags := tlsConfig(tenableProviderModel{
+		CACertPEM:      types.StringValue(string(caPEM)),
+		ClientCertFile: types.StringValue(certFile),
+		ClientKeyFile:  types.StringValue(keyFile),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	res, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("mutual TLS request failed: %v", err)
+	}
+	res.Body.Close()
+
+	cfg, _ = tlsConfig(tenableProviderModel{CACertPEM: types.StringValue(string(caPEM))})
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	if res, err := client.Get(ts.URL); err == nil {
+		res.Body.Close()
+		t.Errorf("expected handshake failure without client certificate")
+	}
+
+	if _, diags := tlsConfig(tenableProviderModel{ClientCertFile: types.StringValue(certFile)}); !diags.HasError() {
+		t.Errorf("expected error when client_key_file is missing")
+	}
+}
+
+// TestProvider_ConfigureExtraHeaders verifies that extra_headers are
+// sent with every request and that reserved or malformed header names
+// are rejected.
+func TestProvider_ConfigureExtraHeaders(t *testing.T) {
+	var got http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"id":1,"username":"alice"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	p := NewProvider("test").(*tenablevmProvider)
+	var schResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schResp)
+	headerMap := func(headers map[string]string) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(headers))
+		for name, value := range headers {
+			values[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"access_key":    tftypes.NewValue(tftypes.String, "access"),
+		"secret_key":    tftypes.NewValue(tftypes.String, "secret"),
+		"base_url":      tftypes.NewValue(tftypes.String, ts.URL),
+		"max_retries":   tftypes.NewValue(tftypes.Number, 0),
+		"extra_headers": headerMap(map[string]string{"x-trace-id": "abc"}),
+	})}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if _, err := resp.ResourceData.(*providerData).Client.GetUser(context.Background(), 1); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got.Get("X-Trace-Id") != "abc" {
+		t.Errorf("X-Trace-Id = %q, want abc", got.Get("X-Trace-Id"))
+	}
+
+	var vresp provider.ValidateConfigResponse
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: buildProviderConfig(ctx, schResp.Schema, map[string]tftypes.Value{
+		"extra_headers": headerMap(map[string]string{"x-apikeys": "forged", "bad name": "x", "X-Team": "platform"}),
+	})}, &vresp)
+	if vresp.Diagnostics.ErrorsCount() != 2 {
+		t.Errorf("expected 2 errors for reserved and malformed headers, got %v", vresp.Diagnostics)
+	}
+}
+
+// TestVerifyConnection verifies how the verify_connection check
+// reports a ready, a degraded and an unreachable platform.
+func TestVerifyConnection(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeTenable()
+
+	var diags diag.Diagnostics
+	verifyConnection(ctx, api, &diags)
+	if len(diags) != 0 {
+		t.Errorf("ready platform: unexpected diagnostics %v", diags)
+	}
+
+	api.status = &tenable.ServerStatus{Code: http.StatusServiceUnavailable, Status: "maintenance"}
+	diags = nil
+	verifyConnection(ctx, api, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("degraded platform: want 1 warning, got %v", diags)
+	}
+
+	api.err = errors.New("dial tcp: connection refused")
+	diags = nil
+	verifyConnection(ctx, api, &diags)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("unreachable API: want 1 error, got %v", diags)
+	}
 }
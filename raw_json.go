@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rawJSONAttribute returns the computed raw_json attribute of data
+// sources, which exposes the complete API record of the looked up
+// object so configurations can read fields the provider does not model
+// yet with jsondecode.
+func rawJSONAttribute(object string) schema.StringAttribute {
+	description := "Complete API record of the " + object + " as JSON, including fields without a dedicated attribute. Use jsondecode to read them."
+	return schema.StringAttribute{
+		Computed:            true,
+		Description:         description,
+		MarkdownDescription: "Complete API record of the " + object + " as JSON, including fields without a dedicated attribute. Use `jsondecode` to read them.",
+	}
+}
+
+// rawJSONResourceAttribute is rawJSONAttribute for resources.  The
+// value is refreshed by every read, so it is unknown in the plan of an
+// update.
+func rawJSONResourceAttribute(object string) resourceschema.StringAttribute {
+	data := rawJSONAttribute(object)
+	return resourceschema.StringAttribute{
+		Computed:            true,
+		Description:         data.Description,
+		MarkdownDescription: data.MarkdownDescription,
+	}
+}
+
+// rawJSONValue converts a record's RawJSON to the raw_json attribute,
+// null when the record was not decoded from an API response.
+func rawJSONValue(raw json.RawMessage) types.String {
+	if len(raw) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(string(raw))
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &agentResource{}
+var _ resource.ResourceWithConfigure = &agentResource{}
+var _ resource.ResourceWithImportState = &agentResource{}
+
+// defaultAgentTimeout limits each agent operation unless the timeouts
+// block overrides it.  Operations make one or two API calls.
+const defaultAgentTimeout = 5 * time.Minute
+
+// agentResource manages an agent that is already linked to Tenable VM.
+// Agents link themselves when they are installed, so creating the
+// resource adopts the agent rather than creating one; destroying it
+// unlinks the agent.  Declaring the agent next to the VM it runs on
+// removes it from Tenable VM when the VM is decommissioned.
+type agentResource struct {
+	client TenableAPI
+}
+
+// NewAgentResource returns a new instance of the agent resource.
+func NewAgentResource() resource.Resource {
+	return &agentResource{}
+}
+
+// agentResourceModel maps the resource schema data into a Go struct.
+type agentResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	AgentID     types.String   `tfsdk:"agent_id"`
+	Name        types.String   `tfsdk:"name"`
+	UUID        types.String   `tfsdk:"uuid"`
+	Platform    types.String   `tfsdk:"platform"`
+	IP          types.String   `tfsdk:"ip"`
+	Status      types.String   `tfsdk:"status"`
+	CoreVersion types.String   `tfsdk:"core_version"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_agent`.
+func (r *agentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent"
+}
+
+// Schema defines the attributes of the agent resource.  Changing the
+// agent replaces the resource, which unlinks the previous agent;
+// renaming updates it in place.
+func (r *agentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Numeric identifier of the agent.",
+				MarkdownDescription: "Numeric identifier of the agent.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"agent_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "Numeric ID of the linked agent to manage, e.g. from the tenablevm_agents data source. Changing this unlinks the previous agent.",
+				MarkdownDescription: "Numeric ID of the linked agent to manage, e.g. from the `tenablevm_agents` data source. Changing this unlinks the previous agent.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Name the agent is listed under. Defaults to the name the agent linked with.",
+				MarkdownDescription: "Name the agent is listed under. Defaults to the name the agent linked with.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the agent.",
+				MarkdownDescription: "UUID of the agent.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"platform": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Platform of the agent host (e.g. LINUX, WINDOWS).",
+				MarkdownDescription: "Platform of the agent host (e.g. `LINUX`, `WINDOWS`).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"ip": schema.StringAttribute{
+				Computed:            true,
+				Description:         "IP address the agent last connected from.",
+				MarkdownDescription: "IP address the agent last connected from.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Connection status of the agent.",
+				MarkdownDescription: "Connection status of the agent.",
+			},
+			"core_version": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Version of the agent software.",
+				MarkdownDescription: "Version of the agent software.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a linked Tenable VM agent and unlinks it on destroy.",
+		MarkdownDescription: "Manages a linked Tenable VM agent and unlinks it on destroy.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *agentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_agent resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// setAgent records the API's view of agent in state.
+func setAgent(state *agentResourceModel, agent *tenable.Agent) {
+	state.ID = types.StringValue(strconv.Itoa(agent.ID))
+	state.AgentID = state.ID
+	state.Name = types.StringValue(agent.Name)
+	state.UUID = types.StringValue(agent.UUID)
+	state.Platform = types.StringValue(agent.Platform)
+	state.IP = types.StringValue(agent.IP)
+	state.Status = types.StringValue(agent.Status)
+	state.CoreVersion = types.StringValue(agent.CoreVersion)
+}
+
+// rename renames the agent when name is set and differs from the
+// agent's current name, and returns the agent as it is afterwards.
+func (r *agentResource) rename(ctx context.Context, agent *tenable.Agent, name types.String) (*tenable.Agent, error) {
+	if name.IsNull() || name.IsUnknown() || name.ValueString() == agent.Name {
+		return agent, nil
+	}
+	if err := r.client.RenameAgent(ctx, agent.ID, name.ValueString()); err != nil {
+		return nil, err
+	}
+	tflog.Info(ctx, "Renamed Tenable VM agent", map[string]any{"agent_id": agent.ID, "name": name.ValueString()})
+	return r.client.GetAgent(ctx, agent.ID)
+}
+
+// Create adopts the linked agent and renames it when a name is
+// configured.
+func (r *agentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan agentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultAgentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(plan.AgentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("agent_id"),
+			"Invalid Agent ID",
+			"Expected numeric ID but got: "+plan.AgentID.ValueString(),
+		)
+		return
+	}
+	agent, err := r.client.GetAgent(ctx, id)
+	if err == nil {
+		agent, err = r.rename(ctx, agent, plan.Name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting Tenable VM agent",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Adopted Tenable VM agent", map[string]any{"agent_id": agent.ID})
+	state := plan
+	setAgent(&state, agent)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the agent from the API and removes it from state when
+// it was unlinked outside of Terraform.
+func (r *agentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state agentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultAgentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Agent ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	agent, err := r.client.GetAgent(ctx, id)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM agent not found during read", map[string]any{"agent_id": id})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM agent",
+			errorDetail(err),
+		)
+		return
+	}
+	setAgent(&state, agent)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update renames the agent.
+func (r *agentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state agentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultAgentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Agent ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	agent, err := r.client.GetAgent(ctx, id)
+	if err == nil {
+		agent, err = r.rename(ctx, agent, plan.Name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM agent",
+			errorDetail(err),
+		)
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	setAgent(&state, agent)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete unlinks the agent.  The agent software stays installed on the
+// host, which is usually being destroyed as well.
+func (r *agentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state agentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultAgentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Agent ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	// An agent that is already unlinked has reached the desired state.
+	if err := r.client.UnlinkAgent(ctx, id); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error unlinking Tenable VM agent",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Unlinked Tenable VM agent", map[string]any{"agent_id": id})
+}
+
+// ImportState imports a linked agent by its numeric ID.  The other
+// attributes are populated by the subsequent Read.
+func (r *agentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Agent ID",
+			"Expected the numeric agent ID but got: "+req.ID,
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
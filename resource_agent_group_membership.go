@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &agentGroupMembershipResource{}
+var _ resource.ResourceWithConfigure = &agentGroupMembershipResource{}
+var _ resource.ResourceWithImportState = &agentGroupMembershipResource{}
+
+// defaultAgentGroupTimeout limits each agent group membership
+// operation unless the timeouts block overrides it.  Changes run as
+// asynchronous bulk tasks that are polled until they finish.
+const defaultAgentGroupTimeout = 10 * time.Minute
+
+// agentGroupMembershipResource manages the membership of specific
+// agents in an agent group.  Only the agents in agent_ids are managed:
+// agents added to the group by linking keys or in the UI are left
+// alone, so the resource can share a group with other tooling.
+type agentGroupMembershipResource struct {
+	client TenableAPI
+}
+
+// NewAgentGroupMembershipResource returns a new instance of the agent
+// group membership resource.
+func NewAgentGroupMembershipResource() resource.Resource {
+	return &agentGroupMembershipResource{}
+}
+
+// agentGroupMembershipResourceModel maps the resource schema data into
+// a Go struct.
+type agentGroupMembershipResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	AgentGroupID types.String   `tfsdk:"agent_group_id"`
+	AgentIDs     types.Set      `tfsdk:"agent_ids"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to
+// `tenablevm_agent_group_membership`.
+func (r *agentGroupMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_group_membership"
+}
+
+// Schema defines the attributes of the agent group membership
+// resource.  Changing the group replaces the resource; the agent set
+// is updated in place.
+func (r *agentGroupMembershipResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Numeric ID of the agent group.",
+				MarkdownDescription: "Numeric ID of the agent group.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"agent_group_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "Numeric ID of the agent group, e.g. data.tenablevm_agent_group.example.id. Changing this forces a new resource to be created.",
+				MarkdownDescription: "Numeric ID of the agent group, e.g. `data.tenablevm_agent_group.example.id`. Changing this forces a new resource to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"agent_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				Description:         "Numeric IDs of the agents to keep in the group, e.g. from the tenablevm_agents data source. Other agents in the group are not managed.",
+				MarkdownDescription: "Numeric IDs of the agents to keep in the group, e.g. from the `tenablevm_agents` data source. Other agents in the group are not managed.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages the membership of specific Tenable VM agents in an agent group.",
+		MarkdownDescription: "Manages the membership of specific Tenable VM agents in an agent group.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *agentGroupMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_agent_group_membership resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// agentGroupID parses the numeric ID of the agent group.
+func agentGroupID(value types.String) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	id, err := strconv.Atoi(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("agent_group_id"), "Invalid Agent Group ID", "Expected numeric ID but got: "+value.ValueString())
+	}
+	return id, diags
+}
+
+// agentIDs parses numeric agent IDs.
+func agentIDs(values []string) ([]int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids := make([]int, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			diags.AddAttributeError(path.Root("agent_ids"), "Invalid Agent ID", "Expected numeric ID but got: "+v)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, diags
+}
+
+// Create adds the agents to the group.
+func (r *agentGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan agentGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultAgentGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, diags := agentGroupID(plan.AgentGroupID)
+	resp.Diagnostics.Append(diags...)
+	var values []string
+	resp.Diagnostics.Append(plan.AgentIDs.ElementsAs(ctx, &values, false)...)
+	agents, diags := agentIDs(values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.AddAgentsToGroup(ctx, groupID, agents); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding Tenable VM agents to agent group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Added Tenable VM agents to agent group", map[string]any{"agent_group_id": groupID, "agents": len(agents)})
+	plan.ID = plan.AgentGroupID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read records which of the managed agents are still in the group and
+// removes the resource from state when the group was deleted.  After
+// an import, when no agents are managed yet, every agent in the group
+// is adopted.
+func (r *agentGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state agentGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultAgentGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, diags := agentGroupID(state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var managed map[string]bool
+	if !state.AgentIDs.IsNull() {
+		var values []string
+		resp.Diagnostics.Append(state.AgentIDs.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		managed = make(map[string]bool, len(values))
+		for _, v := range values {
+			managed[v] = true
+		}
+	}
+	members, err := r.client.ListAgentGroupAgents(ctx, groupID)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM agent group not found during read", map[string]any{"agent_group_id": groupID})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM agent group members",
+			errorDetail(err),
+		)
+		return
+	}
+	ids := make([]string, 0, len(members))
+	for _, a := range members {
+		id := strconv.Itoa(a.ID)
+		if managed == nil || managed[id] {
+			ids = append(ids, id)
+		}
+	}
+	state.AgentGroupID = state.ID
+	state.AgentIDs, diags = types.SetValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update adds the agents added to agent_ids and removes the ones
+// taken out of it.
+func (r *agentGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state agentGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultAgentGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, diags := agentGroupID(state.ID)
+	resp.Diagnostics.Append(diags...)
+	var planned, current []string
+	resp.Diagnostics.Append(plan.AgentIDs.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.AgentIDs.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	addedValues, removedValues := diffStrings(current, planned)
+	added, diags := agentIDs(addedValues)
+	resp.Diagnostics.Append(diags...)
+	removed, diags := agentIDs(removedValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.AddAgentsToGroup(ctx, groupID, added); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding Tenable VM agents to agent group",
+			errorDetail(err),
+		)
+		return
+	}
+	if err := r.client.RemoveAgentsFromGroup(ctx, groupID, removed); err != nil {
+		resp.Diagnostics.AddError(
+			"Error removing Tenable VM agents from agent group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM agent group members", map[string]any{
+		"agent_group_id": groupID,
+		"added":          len(added),
+		"removed":        len(removed),
+	})
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the managed agents from the group.  The agents stay
+// linked to Tenable VM.
+func (r *agentGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state agentGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultAgentGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, diags := agentGroupID(state.ID)
+	resp.Diagnostics.Append(diags...)
+	var values []string
+	resp.Diagnostics.Append(state.AgentIDs.ElementsAs(ctx, &values, false)...)
+	agents, diags := agentIDs(values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// A deleted group has already released its agents.
+	if err := r.client.RemoveAgentsFromGroup(ctx, groupID, agents); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error removing Tenable VM agents from agent group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Removed Tenable VM agents from agent group", map[string]any{"agent_group_id": groupID, "agents": len(agents)})
+}
+
+// ImportState imports the membership of an agent group by the group's
+// numeric ID.  Every agent in the group is adopted by the subsequent
+// Read.
+func (r *agentGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Agent Group ID",
+			"Expected the numeric agent group ID but got: "+req.ID,
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
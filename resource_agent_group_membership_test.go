@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestAgentGroupMembershipResourceLifecycle runs create, update,
+// import, read and delete against the in-memory fake, and checks that
+// agents added to the group outside of Terraform are left alone.
+func TestAgentGroupMembershipResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	fake.agents = []*tenable.Agent{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	fake.agentGroups = []*tenable.AgentGroup{{ID: 10, Name: "linux"}}
+	res := &agentGroupMembershipResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	members := func() []int {
+		var ids []int
+		for id := range fake.agentMembers[10] {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		return ids
+	}
+	agentsOf := func(state tfsdk.State) []string {
+		var model agentGroupMembershipResourceModel
+		state.Get(ctx, &model)
+		var ids []string
+		model.AgentIDs.ElementsAs(ctx, &ids, false)
+		sort.Strings(ids)
+		return ids
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"agent_group_id": tftypes.NewValue(tftypes.String, "10"),
+		"agent_ids":      stringSetValue("1", "2"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if got := members(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("members after create = %v", got)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "10"),
+		"agent_group_id": tftypes.NewValue(tftypes.String, "10"),
+		"agent_ids":      stringSetValue("2", "3"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if got := members(); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Errorf("members after update = %v", got)
+	}
+
+	// An agent added outside of Terraform is not managed, while a
+	// managed agent removed outside of Terraform shows up as drift.
+	fake.AddAgentsToGroup(ctx, 10, []int{4})
+	fake.RemoveAgentsFromGroup(ctx, 10, []int{3})
+	readResp := resource.ReadResponse{State: updateResp.State}
+	res.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	if got := agentsOf(readResp.State); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("agent_ids after read = %v, want [2]", got)
+	}
+
+	// Importing adopts every agent in the group.
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "10"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	importRead := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &importRead)
+	if importRead.Diagnostics.HasError() {
+		t.Fatalf("Read after import: %v", importRead.Diagnostics)
+	}
+	if got := agentsOf(importRead.State); !reflect.DeepEqual(got, []string{"2", "4"}) {
+		t.Errorf("agent_ids after import = %v, want [2 4]", got)
+	}
+	invalid := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "linux"}, &invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("expected a non-numeric import ID to be rejected")
+	}
+
+	// Destroying removes only the managed agents.
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if got := members(); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("members after delete = %v, want [4]", got)
+	}
+
+	// A deleted group is removed from state, and deleting its
+	// membership succeeds.
+	fake.agentGroups = nil
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted group to be removed from state, got %v", readResp.Diagnostics)
+	}
+	deleteResp = resource.DeleteResponse{State: updateResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete with a deleted group: %v", deleteResp.Diagnostics)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestAgentResourceLifecycle runs create, update, import, read and
+// delete against the in-memory fake, and checks that destroying the
+// resource unlinks the agent.
+func TestAgentResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	fake.agents = []*tenable.Agent{{ID: 7, UUID: "a-uuid", Name: "ip-10-0-0-7", Platform: "LINUX", Status: "on"}}
+	fake.agentGroups = []*tenable.AgentGroup{{ID: 10}}
+	fake.AddAgentsToGroup(ctx, 10, []int{7})
+	res := &agentResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	// Without a name the agent keeps the name it linked with.
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"agent_id": tftypes.NewValue(tftypes.String, "7"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state agentResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "7" || state.Name.ValueString() != "ip-10-0-0-7" || state.UUID.ValueString() != "a-uuid" {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "7"),
+		"agent_id": tftypes.NewValue(tftypes.String, "7"),
+		"name":     tftypes.NewValue(tftypes.String, "web1"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &state)
+	if fake.agents[0].Name != "web1" || state.Name.ValueString() != "web1" {
+		t.Errorf("agent not renamed: %+v", fake.agents[0])
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "7"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.AgentID.ValueString() != "7" || state.Name.ValueString() != "web1" || state.Platform.ValueString() != "LINUX" {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+	invalid := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "web1"}, &invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("expected a non-numeric import ID to be rejected")
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.agents) != 0 || fake.agentMembers[10][7] {
+		t.Errorf("agent not unlinked: %v", fake.agents)
+	}
+	// Unlinking an agent that is already gone succeeds, and the agent
+	// drops out of state.
+	deleteResp = resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete of an unlinked agent: %v", deleteResp.Diagnostics)
+	}
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the unlinked agent to be removed from state, got %v", readResp.Diagnostics)
+	}
+
+	// Adopting an agent that is not linked fails.
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"agent_id": tftypes.NewValue(tftypes.String, "8"),
+	})
+	createResp = resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("expected adopting a missing agent to fail")
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	// Structured logging for resources
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &assetDeletionResource{}
+var _ resource.ResourceWithConfigure = &assetDeletionResource{}
+
+// defaultAssetDeletionTimeout limits the submission of a bulk asset
+// deletion unless the timeouts block overrides it.  Tenable matches the
+// assets before it answers, which takes a while on large containers.
+const defaultAssetDeletionTimeout = 10 * time.Minute
+
+// assetDeletionResource implements a one-shot resource that deletes
+// every asset matching a filter using Tenable's bulk asset deletion
+// workflow.  Creating the resource submits the deletion; the resource
+// then records the job so the purge is visible in state.  Changing any
+// argument submits a new deletion.  Destroying the resource only
+// removes it from state because deleted assets cannot be restored.
+type assetDeletionResource struct {
+	client *tenable.Client
+}
+
+// NewAssetDeletionResource returns a new instance of the asset
+// deletion resource.
+func NewAssetDeletionResource() resource.Resource {
+	return &assetDeletionResource{}
+}
+
+// assetDeletionResourceModel maps the resource schema data into a Go
+// struct.
+type assetDeletionResourceModel struct {
+	ID         types.String       `tfsdk:"id"`
+	FilterType types.String       `tfsdk:"filter_type"`
+	Filters    []assetFilterModel `tfsdk:"filters"`
+	HardDelete types.Bool         `tfsdk:"hard_delete"`
+	JobUUID    types.String       `tfsdk:"job_uuid"`
+	AssetCount types.Int64        `tfsdk:"asset_count"`
+	Timeouts   timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// assetFilterModel describes a single asset query condition.
+type assetFilterModel struct {
+	Field    types.String `tfsdk:"field"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
+
+// Metadata sets the resource type name to `tenablevm_asset_deletion`.
+func (r *assetDeletionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_deletion"
+}
+
+// Schema defines the asset query and the computed job attributes.
+// Every argument forces replacement because a submitted deletion
+// cannot be amended.
+func (r *assetDeletionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Identifier of the deletion. This is the job UUID when Tenable returns one.",
+				MarkdownDescription: "Identifier of the deletion. This is the job UUID when Tenable returns one.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"filter_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "How the filters are combined: and or or. Defaults to and.",
+				MarkdownDescription: "How the filters are combined: `and` or `or`. Defaults to `and`.",
+				Default:             stringdefault.StaticString("and"),
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringOneOf("and", "or")},
+			},
+			"filters": schema.ListNestedAttribute{
+				Required:            true,
+				Description:         "Asset query conditions. Assets matching the combined conditions are deleted.",
+				MarkdownDescription: "Asset query conditions. Assets matching the combined conditions are deleted.",
+				PlanModifiers:       []planmodifier.List{listplanmodifier.RequiresReplace()},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Required:            true,
+							Description:         "Asset field to filter on (e.g. sources, terminated, last_observed).",
+							MarkdownDescription: "Asset field to filter on (e.g. `sources`, `terminated`, `last_observed`).",
+						},
+						"operator": schema.StringAttribute{
+							Required:            true,
+							Description:         "Filter operator (e.g. eq, neq, match, lt, gt).",
+							MarkdownDescription: "Filter operator (e.g. `eq`, `neq`, `match`, `lt`, `gt`).",
+						},
+						"value": schema.StringAttribute{
+							Required:            true,
+							Description:         "Value to compare the field against.",
+							MarkdownDescription: "Value to compare the field against.",
+						},
+					},
+				},
+			},
+			"hard_delete": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Permanently delete the assets and release their licences instead of marking them as deleted.",
+				MarkdownDescription: "Permanently delete the assets and release their licences instead of marking them as deleted.",
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"job_uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the bulk deletion job, if Tenable processed the request asynchronously.",
+				MarkdownDescription: "UUID of the bulk deletion job, if Tenable processed the request asynchronously.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"asset_count": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "Number of assets matched by the deletion.",
+				MarkdownDescription: "Number of assets matched by the deletion.",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true}),
+		},
+		Description:         "Deletes Tenable VM assets matching a filter using the bulk asset deletion workflow. Destroying this resource does not restore assets.",
+		MarkdownDescription: "Deletes Tenable VM assets matching a filter using the bulk asset deletion workflow. Destroying this resource does not restore assets.",
+	}
+}
+
+// Configure sets the API client on the resource.
+func (r *assetDeletionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_asset_deletion resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// Create submits the bulk deletion and records the resulting job.
+func (r *assetDeletionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan assetDeletionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultAssetDeletionTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	filterType := plan.FilterType.ValueString()
+	if len(plan.Filters) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filters"),
+			"Missing Asset Filters",
+			"At least one filter must be provided. Deleting every asset in the container is not supported.",
+		)
+		return
+	}
+	filters := make([]tenable.AssetFilter, 0, len(plan.Filters))
+	for _, f := range plan.Filters {
+		filters = append(filters, tenable.AssetFilter{
+			Field:    f.Field.ValueString(),
+			Operator: f.Operator.ValueString(),
+			Value:    f.Value.ValueString(),
+		})
+	}
+	tflog.Debug(ctx, "Submitting Tenable VM asset deletion", map[string]any{
+		"filter_type": filterType,
+		"filters":     len(filters),
+		"hard_delete": plan.HardDelete.ValueBool(),
+	})
+
+	job, err := r.client.DeleteAssets(ctx, filterType, filters, plan.HardDelete.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM assets",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Submitted Tenable VM asset deletion", map[string]any{
+		"job_uuid":    job.UUID,
+		"asset_count": job.AssetCount,
+	})
+
+	state := plan
+	state.JobUUID = stringValueOrNull(job.UUID)
+	state.AssetCount = types.Int64Value(int64(job.AssetCount))
+	if job.UUID != "" {
+		state.ID = types.StringValue(job.UUID)
+	} else {
+		// Synchronous deletions have no job to reference, so use the
+		// submission time to give the resource a stable identifier.
+		state.ID = types.StringValue(strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read keeps the recorded job as-is.  A deletion is a point-in-time
+// action, so there is no remote object whose drift could be detected.
+func (r *assetDeletionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state assetDeletionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with real changes because every argument
+// requires replacement; it simply persists the planned state.
+func (r *assetDeletionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan assetDeletionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the resource from state.  Deleted assets cannot be
+// restored, so no API call is made.
+func (r *assetDeletionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state assetDeletionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "Removing Tenable VM asset deletion from state; assets are not restored", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+func TestAssetDeletionResourceCreate(t *testing.T) {
+	ctx := context.Background()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/assets/bulk-jobs/delete" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Query      map[string][]tenable.AssetFilter `json:"query"`
+			HardDelete bool                             `json:"hard_delete"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body.Query["and"]) != 2 || body.Query["and"][0].Field != "sources" || !body.HardDelete {
+			t.Fatalf("unexpected body: %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"uuid":     "job-uuid-1",
+			"response": map[string]interface{}{"data": map[string]interface{}{"asset_count": 42}},
+		})
+	}))
+	defer ts.Close()
+
+	res := &assetDeletionResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	filterType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"field": tftypes.String, "operator": tftypes.String, "value": tftypes.String,
+	}}
+	filter := func(field, op, value string) tftypes.Value {
+		return tftypes.NewValue(filterType, map[string]tftypes.Value{
+			"field":    tftypes.NewValue(tftypes.String, field),
+			"operator": tftypes.NewValue(tftypes.String, op),
+			"value":    tftypes.NewValue(tftypes.String, value),
+		})
+	}
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"filter_type": tftypes.NewValue(tftypes.String, "and"),
+		"filters": tftypes.NewValue(tftypes.List{ElementType: filterType}, []tftypes.Value{
+			filter("sources", "eq", "AWS"),
+			filter("terminated", "eq", "true"),
+		}),
+		"hard_delete": tftypes.NewValue(tftypes.Bool, true),
+		"job_uuid":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"asset_count": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	})
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var state assetDeletionResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if state.ID.ValueString() != "job-uuid-1" || state.JobUUID.ValueString() != "job-uuid-1" || state.AssetCount.ValueInt64() != 42 {
+		t.Errorf("unexpected state: %+v", state)
+	}
+}
+
+// TestAssetDeletionResourceFilterType checks that filter_type is
+// validated at plan time and recorded in state as planned.
+func TestAssetDeletionResourceFilterType(t *testing.T) {
+	ctx := context.Background()
+	var query map[string][]tenable.AssetFilter
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query map[string][]tenable.AssetFilter `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		query = body.Query
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"job-uuid-2"}`))
+	}))
+	defer ts.Close()
+
+	res := &assetDeletionResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	attr := schResp.Schema.Attributes["filter_type"].(schema.StringAttribute)
+	for _, value := range []string{"and", "or", "AND"} {
+		var vresp validator.StringResponse
+		for _, v := range attr.Validators {
+			v.ValidateString(ctx, validator.StringRequest{Path: path.Root("filter_type"), ConfigValue: types.StringValue(value)}, &vresp)
+		}
+		if got, want := vresp.Diagnostics.HasError(), value == "AND"; got != want {
+			t.Errorf("filter_type %q: error = %v, want %v", value, got, want)
+		}
+	}
+
+	filterType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"field": tftypes.String, "operator": tftypes.String, "value": tftypes.String,
+	}}
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"filter_type": tftypes.NewValue(tftypes.String, "or"),
+		"filters": tftypes.NewValue(tftypes.List{ElementType: filterType}, []tftypes.Value{
+			tftypes.NewValue(filterType, map[string]tftypes.Value{
+				"field":    tftypes.NewValue(tftypes.String, "sources"),
+				"operator": tftypes.NewValue(tftypes.String, "eq"),
+				"value":    tftypes.NewValue(tftypes.String, "AWS"),
+			}),
+		}),
+		"hard_delete": tftypes.NewValue(tftypes.Bool, false),
+		"job_uuid":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"asset_count": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	})
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var state assetDeletionResourceModel
+	resp.State.Get(ctx, &state)
+	if state.FilterType.ValueString() != "or" || len(query["or"]) != 1 {
+		t.Errorf("filter type not kept: state %q, query %v", state.FilterType.ValueString(), query)
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &groupResource{}
+var _ resource.ResourceWithConfigure = &groupResource{}
+var _ resource.ResourceWithImportState = &groupResource{}
+
+// defaultGroupTimeout limits each group operation unless the timeouts
+// block overrides it.  Operations make one or two API calls.
+const defaultGroupTimeout = 5 * time.Minute
+
+// groupResource manages a Tenable VM user group.  Membership is
+// managed separately, so that groups can be composed from users
+// defined anywhere in the configuration.
+type groupResource struct {
+	client TenableAPI
+}
+
+// NewGroupResource returns a new instance of the group resource.
+func NewGroupResource() resource.Resource {
+	return &groupResource{}
+}
+
+// groupResourceModel maps the resource schema data into a Go struct.
+type groupResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	UUID     types.String   `tfsdk:"uuid"`
+	Name     types.String   `tfsdk:"name"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_group`.
+func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the attributes of the group resource.  Renaming a
+// group updates it in place.
+func (r *groupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Numeric identifier of the group.",
+				MarkdownDescription: "Numeric identifier of the group.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the group, which other Tenable VM APIs such as permissions use to reference it.",
+				MarkdownDescription: "UUID of the group, which other Tenable VM APIs such as permissions use to reference it.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Name of the group.",
+				MarkdownDescription: "Name of the group.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a Tenable VM user group.",
+		MarkdownDescription: "Manages a Tenable VM user group.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *groupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_group resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// setGroup records the API's view of group in state.
+func setGroup(state *groupResourceModel, group *tenable.Group) {
+	state.ID = types.StringValue(strconv.Itoa(group.ID))
+	state.UUID = types.StringValue(group.UUID)
+	state.Name = types.StringValue(group.Name)
+}
+
+// Create creates the group.
+func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan groupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Creating Tenable VM group", map[string]any{"name": plan.Name.ValueString()})
+	group, err := r.client.CreateGroup(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM group", map[string]any{"group_id": group.ID})
+	state := plan
+	setGroup(&state, group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the group from the API and removes it from state when
+// it was deleted outside of Terraform.
+func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state groupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Group ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	group, err := r.client.GetGroup(ctx, id)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM group not found during read", map[string]any{"group_id": id})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM group",
+			errorDetail(err),
+		)
+		return
+	}
+	setGroup(&state, group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update renames the group.
+func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state groupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Group ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	group, err := r.client.RenameGroup(ctx, id, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Renamed Tenable VM group", map[string]any{"group_id": id, "name": group.Name})
+	state.Timeouts = plan.Timeouts
+	setGroup(&state, group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the group.  Its members stay in Tenable VM.
+func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state groupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Group ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	// A group that is already gone has reached the desired state.
+	if err := r.client.DeleteGroup(ctx, id); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM group", map[string]any{"group_id": id})
+}
+
+// ImportState imports an existing group by its numeric ID.  The other
+// attributes are populated by the subsequent Read.
+func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Group ID",
+			"Expected the numeric group ID but got: "+req.ID,
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
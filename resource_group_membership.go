@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &groupMembershipResource{}
+var _ resource.ResourceWithConfigure = &groupMembershipResource{}
+var _ resource.ResourceWithImportState = &groupMembershipResource{}
+
+// groupMembershipResource manages the membership of a single user in a
+// single group.  Keeping each membership separate lets configurations
+// build groups with for_each, e.g. over an identity provider export,
+// without one resource owning the whole member list.
+type groupMembershipResource struct {
+	client TenableAPI
+}
+
+// NewGroupMembershipResource returns a new instance of the group
+// membership resource.
+func NewGroupMembershipResource() resource.Resource {
+	return &groupMembershipResource{}
+}
+
+// groupMembershipResourceModel maps the resource schema data into a Go
+// struct.  The ID has the form group_id/user_id.
+type groupMembershipResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	GroupID  types.String   `tfsdk:"group_id"`
+	UserID   types.String   `tfsdk:"user_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_group_membership`.
+func (r *groupMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_membership"
+}
+
+// Schema defines the attributes of the group membership resource.
+// Changing the group or the user replaces the membership.
+func (r *groupMembershipResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Identifier of the membership in the form group_id/user_id.",
+				MarkdownDescription: "Identifier of the membership in the form `group_id/user_id`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"group_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "Numeric ID of the group, e.g. tenablevm_group.example.id. Changing this forces a new membership to be created.",
+				MarkdownDescription: "Numeric ID of the group, e.g. `tenablevm_group.example.id`. Changing this forces a new membership to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"user_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "Numeric ID of the user, e.g. tenablevm_user.example.id. Changing this forces a new membership to be created.",
+				MarkdownDescription: "Numeric ID of the user, e.g. `tenablevm_user.example.id`. Changing this forces a new membership to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
+		Description:         "Manages the membership of a Tenable VM user in a group.",
+		MarkdownDescription: "Manages the membership of a Tenable VM user in a group.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *groupMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_group_membership resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// membershipIDs parses the group and user IDs of a membership.
+func membershipIDs(m groupMembershipResourceModel) (groupID, userID int, diags diag.Diagnostics) {
+	groupID, err := strconv.Atoi(m.GroupID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("group_id"), "Invalid Group ID", "Expected numeric ID but got: "+m.GroupID.ValueString())
+	}
+	userID, err = strconv.Atoi(m.UserID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("user_id"), "Invalid User ID", "Expected numeric ID but got: "+m.UserID.ValueString())
+	}
+	return groupID, userID, diags
+}
+
+// Create adds the user to the group.
+func (r *groupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan groupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, userID, diags := membershipIDs(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.AddGroupUser(ctx, groupID, userID); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding Tenable VM user to group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Added Tenable VM user to group", map[string]any{"group_id": groupID, "user_id": userID})
+	plan.ID = types.StringValue(fmt.Sprintf("%d/%d", groupID, userID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read checks that the user is still a member of the group and removes
+// the membership from state when it, the group or the user was removed
+// outside of Terraform.
+func (r *groupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state groupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, userID, diags := membershipIDs(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	members, err := r.client.ListGroupUsers(ctx, groupID)
+	if err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM group members",
+			errorDetail(err),
+		)
+		return
+	}
+	for _, u := range members {
+		if u.ID == userID {
+			state.ID = types.StringValue(fmt.Sprintf("%d/%d", groupID, userID))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	tflog.Info(ctx, "Tenable VM group membership not found during read", map[string]any{"group_id": groupID, "user_id": userID})
+	resp.State.RemoveResource(ctx)
+}
+
+// Update only records new timeouts, as every other change replaces the
+// membership.
+func (r *groupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan groupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the user from the group.
+func (r *groupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state groupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	groupID, userID, diags := membershipIDs(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// A membership that is already gone has reached the desired state.
+	if err := r.client.RemoveGroupUser(ctx, groupID, userID); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error removing Tenable VM user from group",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Removed Tenable VM user from group", map[string]any{"group_id": groupID, "user_id": userID})
+}
+
+// ImportState imports a membership from an ID of the form
+// group_id/user_id.
+func (r *groupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	groupID, userID, ok := strings.Cut(req.ID, "/")
+	_, groupErr := strconv.Atoi(groupID)
+	_, userErr := strconv.Atoi(userID)
+	if !ok || groupErr != nil || userErr != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected an import ID of the form group_id/user_id, e.g. 12/345, but got: "+req.ID,
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
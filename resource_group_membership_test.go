@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestGroupMembershipResourceLifecycle runs create, import, read and
+// delete against the in-memory fake, and checks that memberships
+// removed outside of Terraform drop out of state.
+func TestGroupMembershipResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	group, _ := fake.CreateGroup(ctx, "Analysts")
+	user, _ := fake.CreateUser(ctx, "alice", "pw", 16, "Alice", "alice@example.com", "local", true)
+	res := &groupMembershipResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"group_id": tftypes.NewValue(tftypes.String, "1"),
+		"user_id":  tftypes.NewValue(tftypes.String, "2"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state groupMembershipResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1/2" || !fake.members[group.ID][user.ID] {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "1/2"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.GroupID.ValueString() != "1" || state.UserID.ValueString() != "2" {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+	for _, id := range []string{"1", "1/alice", "analysts/2", "1/2/3"} {
+		invalid := resource.ImportStateResponse{State: emptyState}
+		res.ImportState(ctx, resource.ImportStateRequest{ID: id}, &invalid)
+		if !invalid.Diagnostics.HasError() {
+			t.Errorf("expected import ID %q to be rejected", id)
+		}
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if fake.members[group.ID][user.ID] {
+		t.Error("user still a member after delete")
+	}
+	// Deleting a membership that is already gone succeeds.
+	deleteResp = resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete of a removed membership: %v", deleteResp.Diagnostics)
+	}
+
+	// A removed membership, and one whose group is gone, drop out of
+	// state.
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the removed membership to be removed from state, got %v", readResp.Diagnostics)
+	}
+	fake.AddGroupUser(ctx, group.ID, user.ID)
+	fake.DeleteGroup(ctx, group.ID)
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the membership of a deleted group to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestGroupResourceLifecycle runs create, rename, read, import and
+// delete against the in-memory fake.
+func TestGroupResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &groupResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Analysts"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state groupResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1" || state.UUID.ValueString() != "group-uuid-1" || len(fake.groups) != 1 {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "1"),
+		"name": tftypes.NewValue(tftypes.String, "SOC Analysts"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if fake.groups[0].Name != "SOC Analysts" || fake.groups[0].ID != 1 {
+		t.Errorf("group not renamed in place: %+v", fake.groups[0])
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.Name.ValueString() != "SOC Analysts" || state.UUID.ValueString() != "group-uuid-1" {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+	invalid := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "analysts"}, &invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("expected a non-numeric import ID to be rejected")
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.groups) != 0 {
+		t.Errorf("group not deleted: %v", fake.groups)
+	}
+
+	// A group deleted outside of Terraform is removed from state.
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted group to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
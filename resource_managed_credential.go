@@ -0,0 +1,446 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &managedCredentialResource{}
+var _ resource.ResourceWithConfigure = &managedCredentialResource{}
+var _ resource.ResourceWithImportState = &managedCredentialResource{}
+var _ resource.ResourceWithValidateConfig = &managedCredentialResource{}
+
+// defaultManagedCredentialTimeout limits each managed credential
+// operation unless the timeouts block overrides it.
+const defaultManagedCredentialTimeout = 5 * time.Minute
+
+// Managed credential types the resource supports.
+const (
+	credentialTypeSSH     = "SSH"
+	credentialTypeWindows = "Windows"
+)
+
+// managedCredentialSecretSettings are the settings of the supported
+// credential types that Tenable does not return.  They belong in the
+// write-only secrets attribute rather than in settings.
+var managedCredentialSecretSettings = []string{
+	"password",
+	"private_key",
+	"private_key_passphrase",
+	"escalation_password",
+	"kerberos_password",
+}
+
+// managedCredentialResource manages a Tenable VM managed credential,
+// which scans reference by UUID.
+type managedCredentialResource struct {
+	client TenableAPI
+}
+
+// NewManagedCredentialResource returns a new instance of the managed
+// credential resource.
+func NewManagedCredentialResource() resource.Resource {
+	return &managedCredentialResource{}
+}
+
+// managedCredentialResourceModel maps the resource schema data into a
+// Go struct.  Secrets is write-only and always null in plan and state.
+type managedCredentialResourceModel struct {
+	ID               types.String                `tfsdk:"id"`
+	Name             types.String                `tfsdk:"name"`
+	Description      types.String                `tfsdk:"description"`
+	Type             types.String                `tfsdk:"type"`
+	Category         types.String                `tfsdk:"category"`
+	Settings         types.Map                   `tfsdk:"settings"`
+	Secrets          types.Map                   `tfsdk:"secrets"`
+	SecretsWOVersion types.Int64                 `tfsdk:"secrets_wo_version"`
+	Permissions      []credentialPermissionModel `tfsdk:"permissions"`
+	Timeouts         timeouts.Value              `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to
+// `tenablevm_managed_credential`.
+func (r *managedCredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_credential"
+}
+
+// Schema defines the attributes of the managed credential resource.
+// Changing the type replaces the credential; everything else is
+// updated in place.
+func (r *managedCredentialResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the credential, which scans use to reference it.",
+				MarkdownDescription: "UUID of the credential, which scans use to reference it.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Name of the credential.",
+				MarkdownDescription: "Name of the credential.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the credential.",
+				MarkdownDescription: "Description of the credential.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				Description:         "Credential type: SSH or Windows. Changing this forces a new credential to be created.",
+				MarkdownDescription: "Credential type: `SSH` or `Windows`. Changing this forces a new credential to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringOneOf(credentialTypeSSH, credentialTypeWindows)},
+			},
+			"category": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Category of the credential, e.g. Host.",
+				MarkdownDescription: "Category of the credential, e.g. `Host`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"settings": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Settings of the credential other than secrets, e.g. auth_method, username and elevate_privileges_with. Only the settings given here are checked for drift.",
+				MarkdownDescription: "Settings of the credential other than secrets, e.g. `auth_method`, `username` and `elevate_privileges_with`. Only the settings given here are checked for drift.",
+			},
+			"secrets": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Description:         "Secret settings of the credential, e.g. password or private_key. The values are write-only and never stored in state; they are sent when the credential is created and when secrets_wo_version changes.",
+				MarkdownDescription: "Secret settings of the credential, e.g. `password` or `private_key`. The values are write-only and never stored in state; they are sent when the credential is created and when `secrets_wo_version` changes.",
+			},
+			"secrets_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "Version of the write-only secrets. Changing it sends the configured secrets in place, e.g. to rotate a password.",
+				MarkdownDescription: "Version of the write-only `secrets`. Changing it sends the configured secrets in place, e.g. to rotate a password.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"permissions": credentialPermissionsBlock(),
+			"timeouts":    timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a Tenable VM managed credential.",
+		MarkdownDescription: "Manages a Tenable VM managed credential.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *managedCredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_managed_credential resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// ValidateConfig keeps secrets out of settings, where they would be
+// stored in state and show a diff on every plan as Tenable does not
+// return them, and rejects settings given in both maps and grantees
+// given more than once.
+func (r *managedCredentialResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config managedCredentialResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secrets := config.Secrets.Elements()
+	for key := range config.Settings.Elements() {
+		switch {
+		case slices.Contains(managedCredentialSecretSettings, key):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings").AtMapKey(key),
+				"Secret In Settings",
+				fmt.Sprintf("%s is a secret; set it in the write-only secrets attribute so that it is not stored in state.", key),
+			)
+		case secrets[key] != nil:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings").AtMapKey(key),
+				"Conflicting Credential Settings",
+				fmt.Sprintf("%s is set in both settings and secrets.", key),
+			)
+		}
+	}
+	validateCredentialPermissions(config.Permissions, &resp.Diagnostics)
+}
+
+// managedCredentialConfig builds the credential configuration from the
+// plan, with secrets merged into the settings.  The permissions are
+// always sent, so that grants removed from the plan are revoked.
+func managedCredentialConfig(ctx context.Context, plan managedCredentialResourceModel, secrets map[string]string) (tenable.ManagedCredentialConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := tenable.ManagedCredentialConfig{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Type:        plan.Type.ValueString(),
+		Settings:    map[string]string{},
+		Permissions: credentialPermissionsToAPI(plan.Permissions),
+	}
+	if !plan.Settings.IsNull() && !plan.Settings.IsUnknown() {
+		diags.Append(plan.Settings.ElementsAs(ctx, &cfg.Settings, false)...)
+	}
+	for k, v := range secrets {
+		cfg.Settings[k] = v
+	}
+	return cfg, diags
+}
+
+// configSecrets returns the write-only secrets from the configuration,
+// or nil when none are set.
+func configSecrets(ctx context.Context, config tfsdk.Config) (map[string]string, diag.Diagnostics) {
+	var secrets types.Map
+	diags := config.GetAttribute(ctx, path.Root("secrets"), &secrets)
+	if diags.HasError() || secrets.IsNull() || secrets.IsUnknown() {
+		return nil, diags
+	}
+	var values map[string]string
+	diags.Append(secrets.ElementsAs(ctx, &values, false)...)
+	return values, diags
+}
+
+// setManagedCredential records the API's view of cred in state.  Of
+// the settings, only the keys already in state are recorded, so that
+// defaults and masked secrets do not show a diff; a key the credential
+// no longer has is dropped.  All grants but the owner's are recorded.
+func setManagedCredential(state *managedCredentialResourceModel, cred *tenable.ManagedCredential) {
+	state.ID = types.StringValue(cred.UUID)
+	state.Name = types.StringValue(cred.Name)
+	state.Description = stringValueOrNull(cred.Description)
+	if cred.Type != "" {
+		state.Type = types.StringValue(cred.Type)
+	}
+	state.Category = stringValueOrNull(cred.Category)
+	state.Secrets = types.MapNull(types.StringType)
+	state.Permissions = credentialPermissionsFromAPI(cred.Permissions)
+	if state.Settings.IsNull() || state.Settings.IsUnknown() {
+		state.Settings = types.MapNull(types.StringType)
+		return
+	}
+	settings := make(map[string]attr.Value, len(state.Settings.Elements()))
+	for key := range state.Settings.Elements() {
+		if v, ok := cred.Settings[key]; ok {
+			settings[key] = types.StringValue(v)
+		}
+	}
+	state.Settings = types.MapValueMust(types.StringType, settings)
+}
+
+// Create creates the credential with its secrets and reads it back.
+func (r *managedCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan managedCredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultManagedCredentialTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	secrets, diags := configSecrets(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cfg, diags := managedCredentialConfig(ctx, plan, secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Tenable VM managed credential", map[string]any{"name": cfg.Name, "type": cfg.Type})
+	uuid, err := r.client.CreateManagedCredential(ctx, cfg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM managed credential",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM managed credential", map[string]any{"credential_uuid": uuid})
+	// Tenable may answer reads of the new credential with 404 for a
+	// few seconds, so wait until it is served, within a limit of its
+	// own.
+	waitCtx, cancelWait := context.WithTimeout(ctx, tenable.DefaultVisibilityTimeout)
+	cred, err := tenable.WaitUntilVisible(waitCtx, func() (*tenable.ManagedCredential, error) {
+		return r.client.GetManagedCredential(waitCtx, uuid)
+	})
+	cancelWait()
+	state := plan
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM managed credential after create",
+			errorDetail(err),
+		)
+		// The credential exists; save what is known so that Terraform
+		// taints and replaces it on the next apply instead of
+		// orphaning it.
+		cred = &tenable.ManagedCredential{UUID: uuid, Name: cfg.Name, Description: cfg.Description, Type: cfg.Type}
+	}
+	setManagedCredential(&state, cred)
+	state.Settings = plan.Settings
+	state.Permissions = plan.Permissions
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the credential from the API and removes it from state
+// when it was deleted outside of Terraform.
+func (r *managedCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state managedCredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultManagedCredentialTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	cred, err := r.client.GetManagedCredential(ctx, uuid)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM managed credential not found during read", map[string]any{"credential_uuid": uuid})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM managed credential",
+			errorDetail(err),
+		)
+		return
+	}
+	setManagedCredential(&state, cred)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sends the planned configuration and reads the credential
+// back.  The secrets are write-only, so a new secrets_wo_version is the
+// signal to send them.
+func (r *managedCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state managedCredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultManagedCredentialTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	var secrets map[string]string
+	rotate := !plan.SecretsWOVersion.IsUnknown() && !plan.SecretsWOVersion.Equal(state.SecretsWOVersion)
+	if rotate {
+		secrets, diags = configSecrets(ctx, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(secrets) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secrets"),
+				"Missing secrets",
+				"secrets_wo_version changed but no secrets are configured. Set secrets to the new values.",
+			)
+			return
+		}
+	}
+	cfg, diags := managedCredentialConfig(ctx, plan, secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.UpdateManagedCredential(ctx, uuid, cfg); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM managed credential",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM managed credential", map[string]any{"credential_uuid": uuid, "secrets_sent": rotate})
+	cred, err := r.client.GetManagedCredential(ctx, uuid)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM managed credential after update",
+			errorDetail(err),
+		)
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	state.SecretsWOVersion = plan.SecretsWOVersion
+	setManagedCredential(&state, cred)
+	state.Settings = plan.Settings
+	state.Permissions = plan.Permissions
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the credential.
+func (r *managedCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state managedCredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultManagedCredentialTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	// A credential that is already gone has reached the desired state.
+	if err := r.client.DeleteManagedCredential(ctx, uuid); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM managed credential",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM managed credential", map[string]any{"credential_uuid": uuid})
+}
+
+// ImportState imports an existing credential by its UUID.  The other
+// attributes are populated by the subsequent Read; settings stay unset
+// until they are configured, and secrets are sent once
+// secrets_wo_version is set.
+func (r *managedCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
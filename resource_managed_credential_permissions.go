@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Permission levels of the permissions block.
+const (
+	credentialPermissionUse  = "use"
+	credentialPermissionEdit = "edit"
+)
+
+// credentialPermissionModel is a permissions block of the managed
+// credential resource.
+type credentialPermissionModel struct {
+	GranteeUUID types.String `tfsdk:"grantee_uuid"`
+	Type        types.String `tfsdk:"type"`
+	Permission  types.String `tfsdk:"permission"`
+}
+
+// credentialPermissionsBlock returns the permissions block of the
+// managed credential resource.
+func credentialPermissionsBlock() schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		Description:         "Users and groups the credential is shared with. The blocks list every grant besides the owner's; grants made outside of Terraform show up as drift and are removed.",
+		MarkdownDescription: "Users and groups the credential is shared with. The blocks list every grant besides the owner's; grants made outside of Terraform show up as drift and are removed.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"grantee_uuid": schema.StringAttribute{
+					Required:            true,
+					Description:         "UUID of the user or group.",
+					MarkdownDescription: "UUID of the user or group.",
+				},
+				"type": schema.StringAttribute{
+					Required:            true,
+					Description:         "Grantee type: user or group.",
+					MarkdownDescription: "Grantee type: `user` or `group`.",
+					Validators:          []validator.String{stringOneOf(tenable.GranteeUser, tenable.GranteeGroup)},
+				},
+				"permission": schema.StringAttribute{
+					Required:            true,
+					Description:         "Permission granted: use, to attach the credential to scans, or edit, to also change it.",
+					MarkdownDescription: "Permission granted: `use`, to attach the credential to scans, or `edit`, to also change it.",
+					Validators:          []validator.String{stringOneOf(credentialPermissionUse, credentialPermissionEdit)},
+				},
+			},
+		},
+	}
+}
+
+// validateCredentialPermissions rejects grantees given more than once,
+// which Tenable would resolve silently.  Unknown UUIDs are checked once
+// they are known.
+func validateCredentialPermissions(perms []credentialPermissionModel, diags *diag.Diagnostics) {
+	seen := make(map[string]bool, len(perms))
+	for _, p := range perms {
+		if p.GranteeUUID.IsUnknown() || p.GranteeUUID.IsNull() {
+			continue
+		}
+		uuid := p.GranteeUUID.ValueString()
+		if seen[uuid] {
+			diags.AddAttributeError(
+				path.Root("permissions"),
+				"Duplicate Credential Permission",
+				fmt.Sprintf("%s is granted more than once; give each user or group a single permissions block.", uuid),
+			)
+		}
+		seen[uuid] = true
+	}
+}
+
+// credentialPermissionsToAPI converts the permissions blocks to the
+// grants to send.  The result is never nil, so that removing the last
+// block revokes the last grant.
+func credentialPermissionsToAPI(perms []credentialPermissionModel) []tenable.CredentialPermission {
+	out := make([]tenable.CredentialPermission, 0, len(perms))
+	for _, p := range perms {
+		level := tenable.CredentialPermissionUse
+		if p.Permission.ValueString() == credentialPermissionEdit {
+			level = tenable.CredentialPermissionEdit
+		}
+		out = append(out, tenable.CredentialPermission{
+			GranteeUUID: p.GranteeUUID.ValueString(),
+			Type:        p.Type.ValueString(),
+			Permissions: level,
+		})
+	}
+	return out
+}
+
+// credentialPermissionsFromAPI converts the grants of a credential to
+// permissions blocks, sorted by grantee.  The owner's grant is left
+// out, as it cannot be changed.
+func credentialPermissionsFromAPI(perms []tenable.CredentialPermission) []credentialPermissionModel {
+	out := make([]credentialPermissionModel, 0, len(perms))
+	for _, p := range perms {
+		if p.Permissions >= tenable.CredentialPermissionOwner {
+			continue
+		}
+		level := credentialPermissionUse
+		if p.Permissions >= tenable.CredentialPermissionEdit {
+			level = credentialPermissionEdit
+		}
+		out = append(out, credentialPermissionModel{
+			GranteeUUID: types.StringValue(p.GranteeUUID),
+			Type:        types.StringValue(p.Type),
+			Permission:  types.StringValue(level),
+		})
+	}
+	slices.SortFunc(out, func(a, b credentialPermissionModel) int {
+		return strings.Compare(a.GranteeUUID.ValueString(), b.GranteeUUID.ValueString())
+	})
+	return out
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// stringMapValue builds a map(string) value from alternating keys and
+// values.
+func stringMapValue(kv ...string) tftypes.Value {
+	m := make(map[string]tftypes.Value)
+	for i := 0; i < len(kv); i += 2 {
+		m[kv[i]] = tftypes.NewValue(tftypes.String, kv[i+1])
+	}
+	return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, m)
+}
+
+// TestManagedCredentialResourceValidateConfig checks that secrets are
+// kept out of settings.
+func TestManagedCredentialResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	res := &managedCredentialResource{}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	for name, tc := range map[string]struct {
+		settings, secrets tftypes.Value
+		wantErr           bool
+	}{
+		"valid":            {stringMapValue("username", "scan"), stringMapValue("password", "s3cret"), false},
+		"secret key":       {stringMapValue("username", "scan", "password", "s3cret"), tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil), true},
+		"set in both maps": {stringMapValue("username", "scan"), stringMapValue("username", "root"), true},
+	} {
+		config := configOf(buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, "Linux"),
+			"type":     tftypes.NewValue(tftypes.String, "SSH"),
+			"settings": tc.settings,
+			"secrets":  tc.secrets,
+		}))
+		var resp resource.ValidateConfigResponse
+		res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: diagnostics = %v, want error %v", name, resp.Diagnostics, tc.wantErr)
+		}
+	}
+}
+
+// TestManagedCredentialResourceLifecycle runs create, update, read,
+// import and delete against the in-memory fake, and checks that the
+// secrets are only sent on create and when their version changes.
+func TestManagedCredentialResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &managedCredentialResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	attrs := func(id, description string, version int, secrets ...string) map[string]tftypes.Value {
+		v := map[string]tftypes.Value{
+			"id":                 tftypes.NewValue(tftypes.String, id),
+			"name":               tftypes.NewValue(tftypes.String, "Linux"),
+			"type":               tftypes.NewValue(tftypes.String, "SSH"),
+			"category":           tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"settings":           stringMapValue("auth_method", "password", "username", "scan"),
+			"secrets_wo_version": tftypes.NewValue(tftypes.Number, version),
+		}
+		if id == "" {
+			v["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		} else {
+			v["category"] = tftypes.NewValue(tftypes.String, "Host")
+		}
+		if description != "" {
+			v["description"] = tftypes.NewValue(tftypes.String, description)
+		}
+		if len(secrets) > 0 {
+			v["secrets"] = stringMapValue(secrets...)
+		}
+		return v
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs("", "", 1))
+	config := configOf(buildResourcePlan(ctx, schResp.Schema, attrs("", "", 1, "password", "s3cret")))
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: config, Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state managedCredentialResourceModel
+	createResp.State.Get(ctx, &state)
+	id := state.ID.ValueString()
+	if id == "" || state.Category.ValueString() != "Host" || !state.Secrets.IsNull() || !state.Description.IsNull() {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+	if cred := fake.credentials[id]; cred == nil || cred.Settings["password"] != "s3cret" || cred.Settings["username"] != "scan" {
+		t.Fatalf("credential not created with its secrets: %+v", cred)
+	}
+
+	// An unchanged version leaves the secrets alone.
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs(id, "Linux hosts", 1))
+	config = configOf(buildResourcePlan(ctx, schResp.Schema, attrs(id, "Linux hosts", 1, "password", "rotated")))
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: config, Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if cred := fake.credentials[id]; cred.Description != "Linux hosts" || cred.Settings["password"] != "s3cret" {
+		t.Errorf("unexpected credential after update: %+v", cred)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs(id, "Linux hosts", 2))
+	config = configOf(buildResourcePlan(ctx, schResp.Schema, attrs(id, "Linux hosts", 2, "password", "rotated")))
+	rotateResp := resource.UpdateResponse{State: updateResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: config, Plan: plan, State: updateResp.State}, &rotateResp)
+	if rotateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", rotateResp.Diagnostics)
+	}
+	if fake.credentials[id].Settings["password"] != "rotated" {
+		t.Errorf("secret not rotated: %+v", fake.credentials[id])
+	}
+	rotateResp.State.Get(ctx, &state)
+	if state.SecretsWOVersion.ValueInt64() != 2 || !state.Secrets.IsNull() {
+		t.Errorf("unexpected state after rotation: %+v", state)
+	}
+
+	// A new version without secrets is a configuration error.
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs(id, "Linux hosts", 3))
+	failResp := resource.UpdateResponse{State: rotateResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: rotateResp.State}, &failResp)
+	if !failResp.Diagnostics.HasError() {
+		t.Error("expected an error for a new version without secrets")
+	}
+
+	// A configured setting changed in the UI is drift; the masked
+	// secret is not.
+	fake.credentials[id].Settings["username"] = "root"
+	readResp := resource.ReadResponse{State: rotateResp.State}
+	res.Read(ctx, resource.ReadRequest{State: rotateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	want := stringMapValue("auth_method", "password", "username", "root")
+	if v, err := state.Settings.ToTerraformValue(ctx); err != nil || !v.Equal(want) {
+		t.Errorf("settings after read = %v, want %v", v, want)
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+	importRead := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &importRead)
+	if importRead.Diagnostics.HasError() {
+		t.Fatalf("Read after import: %v", importRead.Diagnostics)
+	}
+	importRead.State.Get(ctx, &state)
+	if state.Name.ValueString() != "Linux" || state.Type.ValueString() != "SSH" || !state.Settings.IsNull() {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.credentials) != 0 {
+		t.Errorf("credential not deleted: %v", fake.credentials)
+	}
+	// Deleting a credential that is already gone succeeds, and the
+	// credential drops out of state.
+	deleteResp = resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete of a deleted credential: %v", deleteResp.Diagnostics)
+	}
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted credential to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
+
+// TestManagedCredentialResourcePermissions checks that the credential
+// is shared as configured, that the owner's grant is not managed and
+// that grants made outside of Terraform are read back as drift.
+func TestManagedCredentialResourcePermissions(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &managedCredentialResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	permType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"grantee_uuid": tftypes.String, "type": tftypes.String, "permission": tftypes.String}}
+	permissions := func(grants ...[3]string) tftypes.Value {
+		var elems []tftypes.Value
+		for _, g := range grants {
+			elems = append(elems, tftypes.NewValue(permType, map[string]tftypes.Value{
+				"grantee_uuid": tftypes.NewValue(tftypes.String, g[0]),
+				"type":         tftypes.NewValue(tftypes.String, g[1]),
+				"permission":   tftypes.NewValue(tftypes.String, g[2]),
+			}))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: permType}, elems)
+	}
+	attrs := func(id string, perms tftypes.Value) map[string]tftypes.Value {
+		v := map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, id),
+			"name":        tftypes.NewValue(tftypes.String, "Windows"),
+			"type":        tftypes.NewValue(tftypes.String, "Windows"),
+			"category":    tftypes.NewValue(tftypes.String, "Host"),
+			"permissions": perms,
+		}
+		if id == "" {
+			v["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			v["category"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		}
+		return v
+	}
+
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(buildResourcePlan(ctx, schResp.Schema, attrs("", permissions(
+		[3]string{"user-uuid", "user", "use"},
+		[3]string{"user-uuid", "user", "edit"},
+	))))}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected a grantee given twice to be rejected")
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs("", permissions(
+		[3]string{"user-uuid", "user", "edit"},
+		[3]string{"group-uuid", "group", "use"},
+	)))
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state managedCredentialResourceModel
+	createResp.State.Get(ctx, &state)
+	id := state.ID.ValueString()
+	want := []tenable.CredentialPermission{
+		fakeCredentialOwner,
+		{GranteeUUID: "user-uuid", Type: tenable.GranteeUser, Permissions: tenable.CredentialPermissionEdit},
+		{GranteeUUID: "group-uuid", Type: tenable.GranteeGroup, Permissions: tenable.CredentialPermissionUse},
+	}
+	if got := fake.credentials[id].Permissions; !reflect.DeepEqual(got, want) {
+		t.Errorf("permissions after create = %+v, want %+v", got, want)
+	}
+
+	// A grant made in the UI is drift; the owner's grant is not.
+	fake.credentials[id].Permissions = append(fake.credentials[id].Permissions,
+		tenable.CredentialPermission{GranteeUUID: "other-uuid", Type: tenable.GranteeUser, Permissions: tenable.CredentialPermissionUse})
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	var grantees []string
+	for _, p := range state.Permissions {
+		grantees = append(grantees, p.GranteeUUID.ValueString()+"="+p.Permission.ValueString())
+	}
+	if wantGrantees := []string{"group-uuid=use", "other-uuid=use", "user-uuid=edit"}; !reflect.DeepEqual(grantees, wantGrantees) {
+		t.Errorf("permissions after read = %v, want %v", grantees, wantGrantees)
+	}
+
+	// Removing the blocks revokes every grant but the owner's.
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs(id, permissions()))
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if got := fake.credentials[id].Permissions; !reflect.DeepEqual(got, []tenable.CredentialPermission{fakeCredentialOwner}) {
+		t.Errorf("permissions after update = %+v, want only the owner", got)
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &networkScannerAssignmentResource{}
+var _ resource.ResourceWithConfigure = &networkScannerAssignmentResource{}
+var _ resource.ResourceWithImportState = &networkScannerAssignmentResource{}
+
+// defaultNetworkAssignmentTimeout limits each assignment operation
+// unless the timeouts block overrides it.  Operations make one call per
+// scanner.
+const defaultNetworkAssignmentTimeout = 10 * time.Minute
+
+// networkScannerAssignmentResource manages the set of scanners and
+// scanner groups assigned to a network.  The API cannot unassign a
+// scanner, so scanners removed from the set, and all of them on
+// destroy, are moved back to the default network.
+type networkScannerAssignmentResource struct {
+	client TenableAPI
+}
+
+// NewNetworkScannerAssignmentResource returns a new instance of the
+// network scanner assignment resource.
+func NewNetworkScannerAssignmentResource() resource.Resource {
+	return &networkScannerAssignmentResource{}
+}
+
+// networkScannerAssignmentResourceModel maps the resource schema data
+// into a Go struct.
+type networkScannerAssignmentResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	NetworkID    types.String   `tfsdk:"network_id"`
+	ScannerUUIDs types.Set      `tfsdk:"scanner_uuids"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to
+// `tenablevm_network_scanner_assignment`.
+func (r *networkScannerAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_scanner_assignment"
+}
+
+// Schema defines the attributes of the network scanner assignment
+// resource.  Changing the network replaces the resource; the scanner
+// set is updated in place.
+func (r *networkScannerAssignmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the network.",
+				MarkdownDescription: "UUID of the network.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "UUID of the network. Changing this forces a new resource to be created.",
+				MarkdownDescription: "UUID of the network. Changing this forces a new resource to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"scanner_uuids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				Description:         "UUIDs of the scanners and scanner groups assigned to the network. Scanners assigned outside of Terraform show up as drift.",
+				MarkdownDescription: "UUIDs of the scanners and scanner groups assigned to the network. Scanners assigned outside of Terraform show up as drift.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages the scanners and scanner groups assigned to a Tenable VM network.",
+		MarkdownDescription: "Manages the scanners and scanner groups assigned to a Tenable VM network.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *networkScannerAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_network_scanner_assignment resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// scannerUUIDs returns the elements of set.
+func scannerUUIDs(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	var uuids []string
+	diags := set.ElementsAs(ctx, &uuids, false)
+	return uuids, diags
+}
+
+// diffStrings returns the elements of planned missing from current and
+// the elements of current missing from planned.
+func diffStrings(current, planned []string) (added, removed []string) {
+	in := func(values []string) map[string]bool {
+		m := make(map[string]bool, len(values))
+		for _, v := range values {
+			m[v] = true
+		}
+		return m
+	}
+	currentSet, plannedSet := in(current), in(planned)
+	for _, v := range planned {
+		if !currentSet[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range current {
+		if !plannedSet[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+// assign assigns each scanner to the network, stopping at the first
+// failure.
+func (r *networkScannerAssignmentResource) assign(ctx context.Context, networkUUID string, scanners []string) error {
+	for _, scanner := range scanners {
+		if err := r.client.AssignNetworkScanner(ctx, networkUUID, scanner); err != nil {
+			return err
+		}
+		tflog.Debug(ctx, "Assigned Tenable VM scanner to network", map[string]any{
+			"network_uuid": networkUUID,
+			"scanner_uuid": scanner,
+		})
+	}
+	return nil
+}
+
+// release moves the scanners that are still assigned to the network
+// back to the default network.  Scanners that have since moved
+// elsewhere are left alone.
+func (r *networkScannerAssignmentResource) release(ctx context.Context, networkUUID string, scanners []string) error {
+	assigned, err := r.client.ListNetworkScanners(ctx, networkUUID)
+	if err != nil {
+		return err
+	}
+	release := make(map[string]bool, len(scanners))
+	for _, s := range scanners {
+		release[s] = true
+	}
+	var moved []string
+	for _, s := range assigned {
+		if release[s.UUID] {
+			moved = append(moved, s.UUID)
+		}
+	}
+	return r.assign(ctx, tenable.DefaultNetworkUUID, moved)
+}
+
+// Create assigns the scanners to the network.
+func (r *networkScannerAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan networkScannerAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultNetworkAssignmentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	scanners, diags := scannerUUIDs(ctx, plan.ScannerUUIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	network := plan.NetworkID.ValueString()
+	if err := r.assign(ctx, network, scanners); err != nil {
+		resp.Diagnostics.AddError(
+			"Error assigning Tenable VM scanners to network",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Assigned Tenable VM scanners to network", map[string]any{"network_uuid": network, "scanners": len(scanners)})
+	plan.ID = plan.NetworkID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read records the scanners actually assigned to the network and
+// removes the resource from state when the network was deleted.
+func (r *networkScannerAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state networkScannerAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultNetworkAssignmentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	network := state.ID.ValueString()
+	assigned, err := r.client.ListNetworkScanners(ctx, network)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM network not found during read", map[string]any{"network_uuid": network})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM network scanners",
+			errorDetail(err),
+		)
+		return
+	}
+	uuids := make([]string, 0, len(assigned))
+	for _, s := range assigned {
+		uuids = append(uuids, s.UUID)
+	}
+	state.NetworkID = types.StringValue(network)
+	state.ScannerUUIDs, diags = types.SetValueFrom(ctx, types.StringType, uuids)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update assigns the added scanners and moves the removed ones back to
+// the default network.
+func (r *networkScannerAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state networkScannerAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultNetworkAssignmentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	planned, diags := scannerUUIDs(ctx, plan.ScannerUUIDs)
+	resp.Diagnostics.Append(diags...)
+	current, diags := scannerUUIDs(ctx, state.ScannerUUIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	added, removed := diffStrings(current, planned)
+	network := state.ID.ValueString()
+	if err := r.assign(ctx, network, added); err != nil {
+		resp.Diagnostics.AddError(
+			"Error assigning Tenable VM scanners to network",
+			errorDetail(err),
+		)
+		return
+	}
+	if len(removed) > 0 {
+		if err := r.release(ctx, network, removed); err != nil {
+			resp.Diagnostics.AddError(
+				"Error moving Tenable VM scanners to the default network",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+	tflog.Info(ctx, "Updated Tenable VM network scanners", map[string]any{
+		"network_uuid": network,
+		"added":        len(added),
+		"removed":      len(removed),
+	})
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete moves the scanners back to the default network.
+func (r *networkScannerAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state networkScannerAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultNetworkAssignmentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	scanners, diags := scannerUUIDs(ctx, state.ScannerUUIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	network := state.ID.ValueString()
+	// A deleted network has already released its scanners.
+	if err := r.release(ctx, network, scanners); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error moving Tenable VM scanners to the default network",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Released Tenable VM network scanners", map[string]any{"network_uuid": network})
+}
+
+// ImportState imports the scanner assignments of a network by its
+// UUID.  The scanners are populated by the subsequent Read.
+func (r *networkScannerAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestNetworkScannerAssignmentResourceLifecycle runs create, update,
+// import, read and delete against the in-memory fake, and checks that
+// removed scanners return to the default network.
+func TestNetworkScannerAssignmentResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	fake.scanners = []*tenable.Scanner{{UUID: "s1"}, {UUID: "s2"}, {UUID: "s3"}}
+	fake.networks = map[string]map[string]bool{"net": {}}
+	res := &networkScannerAssignmentResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	assigned := func() []string {
+		var uuids []string
+		for uuid := range fake.networks["net"] {
+			uuids = append(uuids, uuid)
+		}
+		sort.Strings(uuids)
+		return uuids
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"network_id":    tftypes.NewValue(tftypes.String, "net"),
+		"scanner_uuids": stringSetValue("s1", "s2"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if got := assigned(); !reflect.DeepEqual(got, []string{"s1", "s2"}) {
+		t.Fatalf("assigned after create = %v", got)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "net"),
+		"network_id":    tftypes.NewValue(tftypes.String, "net"),
+		"scanner_uuids": stringSetValue("s2", "s3"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if got := assigned(); !reflect.DeepEqual(got, []string{"s2", "s3"}) {
+		t.Errorf("assigned after update = %v", got)
+	}
+
+	// A scanner assigned in the UI shows up as drift on import.
+	fake.AssignNetworkScanner(ctx, "net", "s1")
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "net"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var state networkScannerAssignmentResourceModel
+	readResp.State.Get(ctx, &state)
+	var uuids []string
+	state.ScannerUUIDs.ElementsAs(ctx, &uuids, false)
+	sort.Strings(uuids)
+	if state.NetworkID.ValueString() != "net" || !reflect.DeepEqual(uuids, []string{"s1", "s2", "s3"}) {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+
+	// Destroying releases only the scanners in state, so s1, assigned
+	// outside of Terraform, stays in the network.
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if got := assigned(); !reflect.DeepEqual(got, []string{"s1"}) {
+		t.Errorf("assigned after delete = %v, want [s1]", got)
+	}
+
+	// A deleted network is removed from state.
+	delete(fake.networks, "net")
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted network to be removed from state, got %v", readResp.Diagnostics)
+	}
+	deleteResp = resource.DeleteResponse{State: updateResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete with a deleted network: %v", deleteResp.Diagnostics)
+	}
+}
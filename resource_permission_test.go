@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// stringSetValue returns a tftypes set of strings.
+func stringSetValue(values ...string) tftypes.Value {
+	elements := make([]tftypes.Value, len(values))
+	for i, v := range values {
+		elements[i] = tftypes.NewValue(tftypes.String, v)
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+}
+
+// TestPermissionResourceLifecycle runs create, update, import, read
+// and delete against the in-memory fake.
+func TestPermissionResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &permissionResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, "SOC Tokyo"),
+		"actions":     stringSetValue("CanView"),
+		"group_uuids": stringSetValue("group-uuid"),
+		"tag_uuids":   stringSetValue("tag-uuid"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state permissionResourceModel
+	createResp.State.Get(ctx, &state)
+	stored := fake.permissions["permission-uuid-1"]
+	want := tenable.Permission{
+		UUID:     "permission-uuid-1",
+		Name:     "SOC Tokyo",
+		Actions:  []string{"CanView"},
+		Subjects: []tenable.PermissionEntity{{Type: "UserGroup", UUID: "group-uuid"}},
+		Objects:  []tenable.PermissionEntity{{Type: "Tag", UUID: "tag-uuid"}},
+	}
+	if state.ID.ValueString() != "permission-uuid-1" || stored == nil || !reflect.DeepEqual(*stored, want) {
+		t.Fatalf("unexpected permission after create: %+v, state %+v", stored, state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "permission-uuid-1"),
+		"name":        tftypes.NewValue(tftypes.String, "SOC Tokyo"),
+		"actions":     stringSetValue("CanView", "CanScan"),
+		"user_uuids":  stringSetValue("user-uuid"),
+		"group_uuids": stringSetValue("group-uuid"),
+		"tag_uuids":   stringSetValue("tag-uuid"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if p := fake.permissions["permission-uuid-1"]; len(p.Actions) != 2 || len(p.Subjects) != 2 {
+		t.Errorf("permission not updated in place: %+v", p)
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "permission-uuid-1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.Equal(updateResp.State.Raw) {
+		t.Errorf("imported state differs from applied state:\n%v\n%v", readResp.State.Raw, updateResp.State.Raw)
+	}
+	readResp.State.Get(ctx, &state)
+	var actions []string
+	state.Actions.ElementsAs(ctx, &actions, false)
+	sort.Strings(actions)
+	if !reflect.DeepEqual(actions, []string{"CanScan", "CanView"}) {
+		t.Errorf("actions after import = %v", actions)
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.permissions) != 0 {
+		t.Errorf("permission not deleted: %v", fake.permissions)
+	}
+
+	// A permission deleted outside of Terraform is removed from state.
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted permission to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
+
+// TestPermissionResourceValidateConfig verifies that a permission
+// without users or groups is rejected.
+func TestPermissionResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	res := &permissionResource{}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	for name, tc := range map[string]struct {
+		attrs map[string]tftypes.Value
+		valid bool
+	}{
+		"group": {map[string]tftypes.Value{"group_uuids": stringSetValue("g")}, true},
+		"user":  {map[string]tftypes.Value{"user_uuids": stringSetValue("u")}, true},
+		"none":  {map[string]tftypes.Value{}, false},
+		"empty": {map[string]tftypes.Value{"user_uuids": stringSetValue()}, false},
+	} {
+		tc.attrs["name"] = tftypes.NewValue(tftypes.String, "p")
+		tc.attrs["actions"] = stringSetValue("CanView")
+		tc.attrs["tag_uuids"] = stringSetValue("t")
+		config := configOf(buildResourcePlan(ctx, schResp.Schema, tc.attrs))
+		var resp resource.ValidateConfigResponse
+		res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", name, tc.valid, resp.Diagnostics)
+		}
+	}
+}
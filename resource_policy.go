@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &policyResource{}
+var _ resource.ResourceWithConfigure = &policyResource{}
+var _ resource.ResourceWithImportState = &policyResource{}
+var _ resource.ResourceWithValidateConfig = &policyResource{}
+
+// defaultPolicyTimeout limits each policy operation unless the timeouts
+// block overrides it.  Reads make two API calls, the policy details and
+// the policy list.
+const defaultPolicyTimeout = 5 * time.Minute
+
+// policyResource manages a Tenable VM scan policy, a user-defined
+// template that scans can be created from.
+type policyResource struct {
+	client TenableAPI
+}
+
+// NewPolicyResource returns a new instance of the policy resource.
+func NewPolicyResource() resource.Resource {
+	return &policyResource{}
+}
+
+// policyResourceModel maps the resource schema data into a Go struct.
+type policyResourceModel struct {
+	ID             types.String                       `tfsdk:"id"`
+	TemplateUUID   types.String                       `tfsdk:"template_uuid"`
+	Name           types.String                       `tfsdk:"name"`
+	Description    types.String                       `tfsdk:"description"`
+	Settings       types.Map                          `tfsdk:"settings"`
+	PluginFamilies map[string]policyPluginFamilyModel `tfsdk:"plugin_families"`
+	Owner          types.String                       `tfsdk:"owner"`
+	Timeouts       timeouts.Value                     `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_policy`.
+func (r *policyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+// Schema defines the attributes of the policy resource.  Changing the
+// template replaces the policy, as its settings depend on the
+// template; everything else is updated in place.
+func (r *policyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Numeric identifier of the policy.",
+				MarkdownDescription: "Numeric identifier of the policy.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"template_uuid": schema.StringAttribute{
+				Required:            true,
+				Description:         "UUID of the editor template the policy is based on, e.g. data.tenablevm_scan_template.example.uuid with type = \"policy\". Changing this forces a new policy to be created.",
+				MarkdownDescription: "UUID of the editor template the policy is based on, e.g. `data.tenablevm_scan_template.example.uuid` with `type = \"policy\"`. Changing this forces a new policy to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Name of the policy.",
+				MarkdownDescription: "Name of the policy.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the policy.",
+				MarkdownDescription: "Description of the policy.",
+			},
+			"settings": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "Editor settings of the policy, keyed by setting name, e.g. safe_checks = \"true\". Only the settings given here are managed and checked for drift, so values must be written the way Tenable returns them. Removing a setting leaves its value in place.",
+				MarkdownDescription: "Editor settings of the policy, keyed by setting name, e.g. `safe_checks = \"true\"`. Only the settings given here are managed and checked for drift, so values must be written the way Tenable returns them. Removing a setting leaves its value in place.",
+			},
+			"plugin_families": policyPluginFamiliesAttribute(),
+			"owner": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Username of the policy's owner.",
+				MarkdownDescription: "Username of the policy's owner.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a Tenable VM scan policy.",
+		MarkdownDescription: "Manages a Tenable VM scan policy.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *policyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_policy resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// ValidateConfig rejects settings that duplicate the name and
+// description attributes and invalid plugin families.
+func (r *policyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config policyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, key := range []string{"name", "description"} {
+		if _, ok := config.Settings.Elements()[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings").AtMapKey(key),
+				"Invalid Policy Setting",
+				"Set "+key+" with the "+key+" attribute rather than in settings.",
+			)
+		}
+	}
+	validatePolicyPluginFamilies(config.PluginFamilies, &resp.Diagnostics)
+}
+
+// policyConfig builds the policy configuration from the plan.
+func policyConfig(ctx context.Context, plan policyResourceModel) (tenable.PolicyConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := tenable.PolicyConfig{
+		TemplateUUID: plan.TemplateUUID.ValueString(),
+		Name:         plan.Name.ValueString(),
+		Description:  plan.Description.ValueString(),
+	}
+	if !plan.Settings.IsNull() && !plan.Settings.IsUnknown() {
+		diags.Append(plan.Settings.ElementsAs(ctx, &cfg.Settings, false)...)
+	}
+	plugins, d := policyPluginsToAPI(ctx, plan.PluginFamilies)
+	diags.Append(d...)
+	cfg.Plugins = plugins
+	return cfg, diags
+}
+
+// setPolicy records the API's view of policy in state.  Of the
+// settings and plugin families, only the keys already in state are
+// recorded, as the policy holds every setting and family of its
+// template; a key the policy no longer has is dropped.
+func setPolicy(state *policyResourceModel, policy *tenable.Policy) {
+	state.ID = types.StringValue(strconv.Itoa(policy.ID))
+	if policy.TemplateUUID != "" {
+		state.TemplateUUID = types.StringValue(policy.TemplateUUID)
+	}
+	state.Name = types.StringValue(policy.Name)
+	state.Description = stringValueOrNull(policy.Description)
+	state.Owner = stringValueOrNull(policy.Owner)
+	state.PluginFamilies = policyPluginFamiliesFromAPI(state.PluginFamilies, policy.Plugins)
+	if state.Settings.IsNull() || state.Settings.IsUnknown() {
+		state.Settings = types.MapNull(types.StringType)
+		return
+	}
+	settings := make(map[string]attr.Value, len(state.Settings.Elements()))
+	for key := range state.Settings.Elements() {
+		if v, ok := policy.Settings[key]; ok {
+			settings[key] = types.StringValue(v)
+		}
+	}
+	state.Settings = types.MapValueMust(types.StringType, settings)
+}
+
+// Create creates the policy and reads it back.  The planned settings
+// and plugin families are kept, so that Tenable's spelling of a value shows up as drift on
+// the next refresh rather than failing the apply.
+func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan policyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cfg, diags := policyConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Tenable VM policy", map[string]any{"name": cfg.Name, "template_uuid": cfg.TemplateUUID})
+	id, err := r.client.CreatePolicy(ctx, cfg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM policy",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM policy", map[string]any{"policy_id": id})
+	// Tenable may answer reads of the new policy with 404 for a few
+	// seconds, so wait until it is served, within a limit of its own.
+	waitCtx, cancelWait := context.WithTimeout(ctx, tenable.DefaultVisibilityTimeout)
+	policy, err := tenable.WaitUntilVisible(waitCtx, func() (*tenable.Policy, error) {
+		return r.client.GetPolicy(waitCtx, id)
+	})
+	cancelWait()
+	state := plan
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM policy after create",
+			errorDetail(err),
+		)
+		// The policy exists; save what is known so that Terraform
+		// taints and replaces it on the next apply instead of
+		// orphaning it.
+		policy = &tenable.Policy{ID: id, TemplateUUID: cfg.TemplateUUID, Name: cfg.Name, Description: cfg.Description}
+	}
+	setPolicy(&state, policy)
+	state.Settings = plan.Settings
+	state.PluginFamilies = plan.PluginFamilies
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the policy from the API and removes it from state
+// when it was deleted outside of Terraform.
+func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state policyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	policy, err := r.client.GetPolicy(ctx, id)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM policy not found during read", map[string]any{"policy_id": id})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM policy",
+			errorDetail(err),
+		)
+		return
+	}
+	setPolicy(&state, policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sends the planned configuration and reads the policy back.
+func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state policyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	cfg, diags := policyConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.UpdatePolicy(ctx, id, cfg); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM policy",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM policy", map[string]any{"policy_id": id})
+	policy, err := r.client.GetPolicy(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM policy after update",
+			errorDetail(err),
+		)
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	setPolicy(&state, policy)
+	state.Settings = plan.Settings
+	state.PluginFamilies = plan.PluginFamilies
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the policy.
+func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state policyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	// A policy that is already gone has reached the desired state.
+	if err := r.client.DeletePolicy(ctx, id); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM policy",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM policy", map[string]any{"policy_id": id})
+}
+
+// ImportState imports an existing policy by its numeric ID.  The other
+// attributes are populated by the subsequent Read; settings stay unset
+// until they are configured.
+func (r *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Policy ID",
+			"Expected the numeric policy ID but got: "+req.ID,
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// policyPluginFamilyModel is the plugin selection of one family of a
+// policy: the whole family through enabled, or single plugins through
+// plugins, keyed by plugin ID.
+type policyPluginFamilyModel struct {
+	Enabled types.Bool `tfsdk:"enabled"`
+	Plugins types.Map  `tfsdk:"plugins"`
+}
+
+// policyPluginFamiliesAttribute returns the plugin_families attribute
+// of the policy resource.
+func policyPluginFamiliesAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Optional:            true,
+		Description:         "Plugin selection keyed by plugin family name, e.g. Windows. Each family sets either enabled, for the whole family, or plugins, for single plugins. Only the families given here are managed and checked for drift; removing a family leaves its selection in place.",
+		MarkdownDescription: "Plugin selection keyed by plugin family name, e.g. `Windows`. Each family sets either `enabled`, for the whole family, or `plugins`, for single plugins. Only the families given here are managed and checked for drift; removing a family leaves its selection in place.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{
+					Optional:            true,
+					Description:         "Whether every plugin of the family is enabled.",
+					MarkdownDescription: "Whether every plugin of the family is enabled.",
+				},
+				"plugins": schema.MapAttribute{
+					ElementType:         types.BoolType,
+					Optional:            true,
+					Description:         "Whether single plugins are enabled, keyed by plugin ID. The other plugins of the family keep their selection.",
+					MarkdownDescription: "Whether single plugins are enabled, keyed by plugin ID. The other plugins of the family keep their selection.",
+				},
+			},
+		},
+	}
+}
+
+// validatePolicyPluginFamilies checks that each family sets exactly one
+// of enabled and plugins, and that plugins are keyed by numeric ID.
+func validatePolicyPluginFamilies(families map[string]policyPluginFamilyModel, diags *diag.Diagnostics) {
+	for name, f := range families {
+		p := path.Root("plugin_families").AtMapKey(name)
+		if f.Enabled.IsUnknown() || f.Plugins.IsUnknown() {
+			continue
+		}
+		if f.Enabled.IsNull() == f.Plugins.IsNull() {
+			diags.AddAttributeError(
+				p,
+				"Invalid Plugin Family",
+				"Set either enabled, to select the whole family, or plugins, to select single plugins, but not both.",
+			)
+		}
+		for id := range f.Plugins.Elements() {
+			if _, err := strconv.Atoi(id); err != nil {
+				diags.AddAttributeError(
+					p.AtName("plugins").AtMapKey(id),
+					"Invalid Plugin ID",
+					"Expected numeric plugin ID but got: "+id,
+				)
+			}
+		}
+	}
+}
+
+// policyPluginsToAPI converts the plugin_families attribute to the
+// editor plugins structure.
+func policyPluginsToAPI(ctx context.Context, families map[string]policyPluginFamilyModel) (map[string]tenable.PolicyPluginFamily, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(families) == 0 {
+		return nil, diags
+	}
+	out := make(map[string]tenable.PolicyPluginFamily, len(families))
+	for name, f := range families {
+		if !f.Enabled.IsNull() {
+			out[name] = tenable.PolicyPluginFamily{Status: pluginStatus(f.Enabled.ValueBool())}
+			continue
+		}
+		var plugins map[string]bool
+		diags.Append(f.Plugins.ElementsAs(ctx, &plugins, false)...)
+		individual := make(map[string]string, len(plugins))
+		for id, enabled := range plugins {
+			individual[id] = pluginStatus(enabled)
+		}
+		out[name] = tenable.PolicyPluginFamily{Status: tenable.PluginMixed, Individual: individual}
+	}
+	return out, diags
+}
+
+// pluginStatus returns the editor status for enabled.
+func pluginStatus(enabled bool) string {
+	if enabled {
+		return tenable.PluginEnabled
+	}
+	return tenable.PluginDisabled
+}
+
+// policyPluginFamiliesFromAPI records the plugin selection of the
+// families in prior.  A family selected as a whole that became mixed
+// is recorded with the plugins Tenable lists, and a plugin is recorded
+// from its family's status unless the family is mixed, so changes made
+// in the UI show up as drift.  Families and plugins the policy no
+// longer has are dropped.
+func policyPluginFamiliesFromAPI(prior map[string]policyPluginFamilyModel, actual map[string]tenable.PolicyPluginFamily) map[string]policyPluginFamilyModel {
+	if prior == nil {
+		return nil
+	}
+	out := make(map[string]policyPluginFamilyModel, len(prior))
+	for name, p := range prior {
+		a, ok := actual[name]
+		if !ok {
+			continue
+		}
+		f := policyPluginFamilyModel{Enabled: types.BoolNull(), Plugins: types.MapNull(types.BoolType)}
+		plugins := make(map[string]attr.Value)
+		switch {
+		case !p.Enabled.IsNull() && a.Status != tenable.PluginMixed:
+			f.Enabled = types.BoolValue(a.Status == tenable.PluginEnabled)
+		case !p.Enabled.IsNull():
+			for id, status := range a.Individual {
+				plugins[id] = types.BoolValue(status == tenable.PluginEnabled)
+			}
+			f.Plugins = types.MapValueMust(types.BoolType, plugins)
+		default:
+			for id := range p.Plugins.Elements() {
+				status, ok := a.Individual[id]
+				if a.Status != tenable.PluginMixed {
+					status, ok = a.Status, true
+				}
+				if ok {
+					plugins[id] = types.BoolValue(status == tenable.PluginEnabled)
+				}
+			}
+			f.Plugins = types.MapValueMust(types.BoolType, plugins)
+		}
+		out[name] = f
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestPolicyResourceLifecycle runs create, update, import, read and
+// delete against the in-memory fake, and checks that only the
+// configured settings are checked for drift.
+func TestPolicyResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &policyResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	settings := func(kv ...string) tftypes.Value {
+		m := make(map[string]tftypes.Value)
+		for i := 0; i < len(kv); i += 2 {
+			m[kv[i]] = tftypes.NewValue(tftypes.String, kv[i+1])
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, m)
+	}
+
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "Hardened"),
+		"settings": settings("name", "Other"),
+	}))}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected name in settings to be rejected")
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"template_uuid": tftypes.NewValue(tftypes.String, "advanced-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Hardened"),
+		"settings":      settings("ping_the_remote_host", "no"),
+		"owner":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state policyResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1" || state.Owner.ValueString() != "owner@example.com" || !state.Description.IsNull() || len(state.Settings.Elements()) != 1 {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "1"),
+		"template_uuid": tftypes.NewValue(tftypes.String, "advanced-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Hardened"),
+		"description":   tftypes.NewValue(tftypes.String, "CIS level 1"),
+		"settings":      settings("ping_the_remote_host", "no", "safe_checks", "false"),
+		"owner":         tftypes.NewValue(tftypes.String, "owner@example.com"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if p := fake.policies[1]; p.Description != "CIS level 1" || p.Settings["safe_checks"] != "false" || p.Settings["max_checks"] != "5" {
+		t.Errorf("policy not updated in place: %+v", p)
+	}
+
+	// A configured setting changed in the UI is drift; others are not
+	// managed.
+	fake.policies[1].Settings["safe_checks"] = "true"
+	fake.policies[1].Settings["max_checks"] = "10"
+	readResp := resource.ReadResponse{State: updateResp.State}
+	res.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	want := settings("ping_the_remote_host", "no", "safe_checks", "true")
+	if v, err := state.Settings.ToTerraformValue(ctx); err != nil || !v.Equal(want) {
+		t.Errorf("settings after read = %v, want %v", v, want)
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	importRead := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &importRead)
+	if importRead.Diagnostics.HasError() {
+		t.Fatalf("Read after import: %v", importRead.Diagnostics)
+	}
+	importRead.State.Get(ctx, &state)
+	if state.TemplateUUID.ValueString() != "advanced-uuid" || state.Name.ValueString() != "Hardened" || !state.Settings.IsNull() {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+	invalid := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "Hardened"}, &invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("expected a non-numeric import ID to be rejected")
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.policies) != 0 {
+		t.Errorf("policy not deleted: %v", fake.policies)
+	}
+	// Deleting a policy that is already gone succeeds, and the policy
+	// drops out of state.
+	deleteResp = resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete of a deleted policy: %v", deleteResp.Diagnostics)
+	}
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted policy to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
+
+// TestPolicyResourcePluginFamilies checks that families and single
+// plugins are selected, and that changes made in the UI are read back
+// as drift.
+func TestPolicyResourcePluginFamilies(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &policyResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	familiesType := schResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["plugin_families"].(tftypes.Map)
+	familyType := familiesType.ElementType.(tftypes.Object)
+	pluginsType := familyType.AttributeTypes["plugins"]
+	family := func(enabled any, plugins map[string]tftypes.Value) tftypes.Value {
+		var pluginsValue any
+		if plugins != nil {
+			pluginsValue = plugins
+		}
+		return tftypes.NewValue(familyType, map[string]tftypes.Value{
+			"enabled": tftypes.NewValue(tftypes.Bool, enabled),
+			"plugins": tftypes.NewValue(pluginsType, pluginsValue),
+		})
+	}
+	planWith := func(families map[string]tftypes.Value) tfsdk.Plan {
+		return buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+			"template_uuid":   tftypes.NewValue(tftypes.String, "advanced-uuid"),
+			"name":            tftypes.NewValue(tftypes.String, "Hardened"),
+			"plugin_families": tftypes.NewValue(familiesType, families),
+			"owner":           tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		})
+	}
+	general := map[string]tftypes.Value{
+		"10180": tftypes.NewValue(tftypes.Bool, true),
+		"11219": tftypes.NewValue(tftypes.Bool, false),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		families map[string]tftypes.Value
+	}{
+		{"neither", map[string]tftypes.Value{"Windows": family(nil, nil)}},
+		{"both", map[string]tftypes.Value{"General": family(true, general)}},
+		{"non-numeric plugin", map[string]tftypes.Value{"General": family(nil, map[string]tftypes.Value{"ping": tftypes.NewValue(tftypes.Bool, true)})}},
+	} {
+		var validateResp resource.ValidateConfigResponse
+		res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(planWith(tc.families))}, &validateResp)
+		if !validateResp.Diagnostics.HasError() {
+			t.Errorf("%s: expected the plugin family to be rejected", tc.name)
+		}
+	}
+
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: planWith(map[string]tftypes.Value{
+		"Windows": family(false, nil),
+		"General": family(nil, general),
+	})}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	plugins := fake.policies[1].Plugins
+	if plugins["Windows"].Status != "disabled" || plugins["General"].Status != "mixed" || plugins["General"].Individual["11219"] != "disabled" {
+		t.Fatalf("plugins not selected: %+v", plugins)
+	}
+
+	// Enabling a disabled family in the UI and re-enabling a plugin are
+	// read back as drift.
+	fake.policies[1].Plugins["Windows"] = tenable.PolicyPluginFamily{Status: "mixed", Individual: map[string]string{"21745": "enabled"}}
+	fake.policies[1].Plugins["General"] = tenable.PolicyPluginFamily{Status: "enabled"}
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var state policyResourceModel
+	readResp.State.Get(ctx, &state)
+	windows, generalFamily := state.PluginFamilies["Windows"], state.PluginFamilies["General"]
+	if !windows.Enabled.IsNull() || len(windows.Plugins.Elements()) != 1 {
+		t.Errorf("Windows after read = %+v", windows)
+	}
+	if v, ok := generalFamily.Plugins.Elements()["11219"].(types.Bool); !ok || !v.ValueBool() || len(generalFamily.Plugins.Elements()) != 2 {
+		t.Errorf("General after read = %+v", generalFamily)
+	}
+}
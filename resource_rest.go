@@ -0,0 +1,500 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	// Structured logging for resources
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &restResource{}
+var _ resource.ResourceWithConfigure = &restResource{}
+
+// restIDPlaceholder is substituted with the object ID in the read,
+// update and delete paths of the tenablevm_rest resource.
+const restIDPlaceholder = "{id}"
+
+// restResource implements a generic escape hatch resource that manages
+// an arbitrary Tenable VM endpoint from a path and a JSON body.  It is
+// intended for endpoints that the provider does not model yet, so
+// practitioners do not need to fork the provider.  Drift is only
+// detected for the body keys listed in compare_keys, because generic
+// responses rarely echo the request body verbatim.
+type restResource struct {
+	client *tenable.Client
+}
+
+// NewRestResource returns a new instance of the generic REST resource.
+func NewRestResource() resource.Resource {
+	return &restResource{}
+}
+
+// restResourceModel maps the resource schema data into a Go struct.
+type restResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	ReadPath     types.String `tfsdk:"read_path"`
+	UpdatePath   types.String `tfsdk:"update_path"`
+	DeletePath   types.String `tfsdk:"delete_path"`
+	CreateMethod types.String `tfsdk:"create_method"`
+	UpdateMethod types.String `tfsdk:"update_method"`
+	DeleteMethod types.String `tfsdk:"delete_method"`
+	Body         types.String `tfsdk:"body"`
+	IDPath       types.String `tfsdk:"id_path"`
+	CompareKeys  types.List   `tfsdk:"compare_keys"`
+	Response     types.String `tfsdk:"response"`
+}
+
+// Metadata sets the resource type name to `tenablevm_rest`.
+func (r *restResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rest"
+}
+
+// Schema defines the request paths, methods, body and drift detection
+// settings of the generic REST resource.
+func (r *restResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Identifier of the remote object, extracted from the create response using id_path.",
+				MarkdownDescription: "Identifier of the remote object, extracted from the create response using `id_path`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				Description:         "API path the create request is sent to (e.g. /tags/values). Changing this forces a new object to be created.",
+				MarkdownDescription: "API path the create request is sent to (e.g. `/tags/values`). Changing this forces a new object to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"read_path": schema.StringAttribute{
+				Optional:            true,
+				Description:         "API path used to read the object. {id} is replaced with the object ID. Defaults to path/{id}.",
+				MarkdownDescription: "API path used to read the object. `{id}` is replaced with the object ID. Defaults to `path/{id}`.",
+			},
+			"update_path": schema.StringAttribute{
+				Optional:            true,
+				Description:         "API path used to update the object. {id} is replaced with the object ID. Defaults to path/{id}.",
+				MarkdownDescription: "API path used to update the object. `{id}` is replaced with the object ID. Defaults to `path/{id}`.",
+			},
+			"delete_path": schema.StringAttribute{
+				Optional:            true,
+				Description:         "API path used to delete the object. {id} is replaced with the object ID. Defaults to path/{id}.",
+				MarkdownDescription: "API path used to delete the object. `{id}` is replaced with the object ID. Defaults to `path/{id}`.",
+			},
+			"create_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "HTTP method used to create the object. Defaults to POST.",
+				MarkdownDescription: "HTTP method used to create the object. Defaults to `POST`.",
+				Default:             stringdefault.StaticString(http.MethodPost),
+			},
+			"update_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "HTTP method used to update the object. Defaults to PUT.",
+				MarkdownDescription: "HTTP method used to update the object. Defaults to `PUT`.",
+				Default:             stringdefault.StaticString(http.MethodPut),
+			},
+			"delete_method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "HTTP method used to delete the object. Defaults to DELETE.",
+				MarkdownDescription: "HTTP method used to delete the object. Defaults to `DELETE`.",
+				Default:             stringdefault.StaticString(http.MethodDelete),
+			},
+			"body": schema.StringAttribute{
+				Required:            true,
+				Description:         "JSON request body sent on create and update. Use jsonencode() to build it.",
+				MarkdownDescription: "JSON request body sent on create and update. Use `jsonencode()` to build it.",
+				Validators:          []validator.String{jsonValidator{}},
+			},
+			"id_path": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Path expression locating the object ID in the create response, using the JMESPath subset of dotted keys and [n] indexes (e.g. value_uuid or data[0].id). Defaults to id.",
+				MarkdownDescription: "Path expression locating the object ID in the create response, using the JMESPath subset of dotted keys and `[n]` indexes (e.g. `value_uuid` or `data[0].id`). Defaults to `id`.",
+				Default:             stringdefault.StaticString("id"),
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"compare_keys": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				Description:         "Path expressions of body keys compared against the read response to detect drift. When a value differs, the body in state is updated so the next plan restores the configured value.",
+				MarkdownDescription: "Path expressions of body keys compared against the read response to detect drift. When a value differs, the body in state is updated so the next plan restores the configured value.",
+			},
+			"response": schema.StringAttribute{
+				Computed:            true,
+				Description:         "JSON response of the most recent create, update or read request.",
+				MarkdownDescription: "JSON response of the most recent create, update or read request.",
+			},
+		},
+		Description:         "Manages an arbitrary Tenable VM API object from a path and JSON body. Use this for endpoints the provider does not model yet.",
+		MarkdownDescription: "Manages an arbitrary Tenable VM API object from a path and JSON body. Use this for endpoints the provider does not model yet.",
+	}
+}
+
+// Configure sets the API client on the resource.
+func (r *restResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_rest resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// objectPath returns the path used for read, update or delete
+// requests.  A configured override has {id} substituted; otherwise the
+// ID is appended to the create path.
+func (m *restResourceModel) objectPath(override types.String) string {
+	id := m.ID.ValueString()
+	if !override.IsNull() && !override.IsUnknown() && override.ValueString() != "" {
+		return strings.ReplaceAll(override.ValueString(), restIDPlaceholder, id)
+	}
+	return strings.TrimRight(m.Path.ValueString(), "/") + "/" + id
+}
+
+// Create sends the body to the create path and extracts the object ID
+// from the response.
+func (r *restResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan restResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	method := strings.ToUpper(plan.CreateMethod.ValueString())
+	tflog.Debug(ctx, "Creating Tenable VM REST object", map[string]any{
+		"method": method,
+		"path":   plan.Path.ValueString(),
+	})
+	out, err := r.client.Request(ctx, method, plan.Path.ValueString(), json.RawMessage(plan.Body.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM REST object",
+			errorDetail(err),
+		)
+		return
+	}
+	var decoded interface{}
+	if len(out) > 0 {
+		if err := decodeJSON(out, &decoded); err != nil {
+			resp.Diagnostics.AddError(
+				"Error decoding Tenable VM REST response",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+	idValue, ok := lookupJSONPath(decoded, plan.IDPath.ValueString())
+	id := formatJSONScalar(idValue)
+	if !ok || id == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id_path"),
+			"Object ID Not Found",
+			fmt.Sprintf("The create response does not contain a scalar value at %q. Response: %s", plan.IDPath.ValueString(), string(out)),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM REST object", map[string]any{
+		"path": plan.Path.ValueString(),
+		"id":   id,
+	})
+
+	state := plan
+	state.ID = types.StringValue(id)
+	state.Response = types.StringValue(string(out))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read fetches the object from the read path.  When compare_keys is
+// set, each listed key of the stored body is compared with the
+// response and replaced by the remote value on mismatch.
+func (r *restResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state restResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	readPath := state.objectPath(state.ReadPath)
+	tflog.Debug(ctx, "Reading Tenable VM REST object", map[string]any{
+		"path": readPath,
+	})
+	out, err := r.client.Request(ctx, http.MethodGet, readPath, nil)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM REST object not found during read", map[string]any{
+			"path": readPath,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM REST object",
+			errorDetail(err),
+		)
+		return
+	}
+	state.Response = types.StringValue(string(out))
+
+	var keys []string
+	if !state.CompareKeys.IsNull() && !state.CompareKeys.IsUnknown() {
+		resp.Diagnostics.Append(state.CompareKeys.ElementsAs(ctx, &keys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if len(keys) > 0 {
+		var remote, body interface{}
+		if err := decodeJSON(out, &remote); err != nil {
+			resp.Diagnostics.AddError(
+				"Error decoding Tenable VM REST response",
+				errorDetail(err),
+			)
+			return
+		}
+		if err := decodeJSON([]byte(state.Body.ValueString()), &body); err != nil {
+			resp.Diagnostics.AddError(
+				"Error decoding stored tenablevm_rest body",
+				errorDetail(err),
+			)
+			return
+		}
+		drifted := false
+		for _, key := range keys {
+			want, inBody := lookupJSONPath(body, key)
+			got, inRemote := lookupJSONPath(remote, key)
+			if !inBody || !inRemote || reflect.DeepEqual(want, got) {
+				continue
+			}
+			tflog.Info(ctx, "Detected drift in Tenable VM REST object", map[string]any{
+				"key": key,
+			})
+			body, _ = setJSONPath(body, key, got)
+			drifted = true
+		}
+		if drifted {
+			b, err := json.Marshal(body)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error encoding tenablevm_rest body",
+					errorDetail(err),
+				)
+				return
+			}
+			state.Body = types.StringValue(string(b))
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sends the new body to the update path.
+func (r *restResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan restResourceModel
+	var state restResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	updatePath := plan.objectPath(plan.UpdatePath)
+	method := strings.ToUpper(plan.UpdateMethod.ValueString())
+	tflog.Debug(ctx, "Updating Tenable VM REST object", map[string]any{
+		"method": method,
+		"path":   updatePath,
+	})
+	out, err := r.client.Request(ctx, method, updatePath, json.RawMessage(plan.Body.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM REST object",
+			errorDetail(err),
+		)
+		return
+	}
+	plan.Response = types.StringValue(string(out))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the object using the delete path and method.
+func (r *restResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state restResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deletePath := state.objectPath(state.DeletePath)
+	method := strings.ToUpper(state.DeleteMethod.ValueString())
+	tflog.Debug(ctx, "Deleting Tenable VM REST object", map[string]any{
+		"method": method,
+		"path":   deletePath,
+	})
+	if _, err := r.client.Request(ctx, method, deletePath, nil); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM REST object",
+			errorDetail(err),
+		)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// jsonPathSegment is one step of a path expression: either an object
+// key or a list index.
+type jsonPathSegment struct {
+	key   string
+	index int
+}
+
+// parseJSONPath splits a path expression such as data[0].id into its
+// segments.  Only the dotted key and [n] index subset of JMESPath is
+// supported; anything else yields nil.
+func parseJSONPath(expr string) []jsonPathSegment {
+	var segments []jsonPathSegment
+	for _, part := range strings.Split(expr, ".") {
+		key := part
+		var indexes []int
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key = part[:i]
+			rest := part[i:]
+			for rest != "" {
+				end := strings.IndexByte(rest, ']')
+				if rest[0] != '[' || end < 0 {
+					return nil
+				}
+				n, err := strconv.Atoi(rest[1:end])
+				if err != nil || n < 0 {
+					return nil
+				}
+				indexes = append(indexes, n)
+				rest = rest[end+1:]
+			}
+		}
+		if key != "" {
+			segments = append(segments, jsonPathSegment{key: key, index: -1})
+		} else if len(indexes) == 0 {
+			return nil
+		}
+		for _, n := range indexes {
+			segments = append(segments, jsonPathSegment{index: n})
+		}
+	}
+	return segments
+}
+
+// lookupJSONPath evaluates a path expression against a decoded JSON
+// value.  The boolean result reports whether the path exists.
+func lookupJSONPath(v interface{}, expr string) (interface{}, bool) {
+	segments := parseJSONPath(expr)
+	if segments == nil {
+		return nil, false
+	}
+	for _, seg := range segments {
+		if seg.index < 0 {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[seg.key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+		l, ok := v.([]interface{})
+		if !ok || seg.index >= len(l) {
+			return nil, false
+		}
+		v = l[seg.index]
+	}
+	return v, true
+}
+
+// setJSONPath replaces the value at an existing path and returns the
+// updated document.  Paths that do not exist are left untouched.
+func setJSONPath(doc interface{}, expr string, value interface{}) (interface{}, bool) {
+	segments := parseJSONPath(expr)
+	if segments == nil {
+		return doc, false
+	}
+	return setJSONSegments(doc, segments, value)
+}
+
+// setJSONSegments is the recursive helper behind setJSONPath.
+func setJSONSegments(v interface{}, segments []jsonPathSegment, value interface{}) (interface{}, bool) {
+	if len(segments) == 0 {
+		return value, true
+	}
+	seg := segments[0]
+	if seg.index < 0 {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v, false
+		}
+		child, ok := m[seg.key]
+		if !ok {
+			return v, false
+		}
+		if m[seg.key], ok = setJSONSegments(child, segments[1:], value); !ok {
+			return v, false
+		}
+		return m, true
+	}
+	l, ok := v.([]interface{})
+	if !ok || seg.index >= len(l) {
+		return v, false
+	}
+	if l[seg.index], ok = setJSONSegments(l[seg.index], segments[1:], value); !ok {
+		return v, false
+	}
+	return l, true
+}
+
+// decodeJSON decodes b into v keeping numbers as json.Number, so
+// that IDs beyond 2^53 are not rounded through float64.
+func decodeJSON(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// formatJSONScalar renders a decoded JSON scalar as an ID string.
+// Numbers are formatted without exponent so numeric IDs round-trip;
+// objects, lists and null yield an empty string.
+func formatJSONScalar(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case json.Number:
+		return s.String()
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(s)
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRestResourceCreateAndReadDrift(t *testing.T) {
+	ctx := context.Background()
+
+	remote := map[string]interface{}{"value_uuid": "v-1", "value": "Tokyo", "description": "changed in UI"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/tags/values":
+			json.NewEncoder(w).Encode(map[string]interface{}{"value_uuid": "v-1", "value": "Tokyo"})
+		case r.Method == http.MethodGet && r.URL.Path == "/tags/values/v-1":
+			json.NewEncoder(w).Encode(remote)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	res := &restResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"path":          tftypes.NewValue(tftypes.String, "/tags/values"),
+		"create_method": tftypes.NewValue(tftypes.String, "POST"),
+		"update_method": tftypes.NewValue(tftypes.String, "PUT"),
+		"delete_method": tftypes.NewValue(tftypes.String, "DELETE"),
+		"body":          tftypes.NewValue(tftypes.String, `{"category_name":"Location","value":"Tokyo","description":"managed"}`),
+		"id_path":       tftypes.NewValue(tftypes.String, "value_uuid"),
+		"compare_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "description"),
+		}),
+		"response": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+	var state restResourceModel
+	if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if state.ID.ValueString() != "v-1" {
+		t.Errorf("id = %q, want v-1", state.ID.ValueString())
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(state.Body.ValueString()), &body); err != nil {
+		t.Fatalf("body decode error: %v", err)
+	}
+	if body["description"] != "changed in UI" || body["category_name"] != "Location" {
+		t.Errorf("unexpected body after drift detection: %v", body)
+	}
+}
+
+func TestLookupAndSetJSONPath(t *testing.T) {
+	var doc interface{}
+	json.Unmarshal([]byte(`{"data":[{"id":12,"tags":{"a":"b"}}]}`), &doc)
+
+	v, ok := lookupJSONPath(doc, "data[0].id")
+	if !ok || formatJSONScalar(v) != "12" {
+		t.Errorf("lookup data[0].id = %v, %v", v, ok)
+	}
+	if _, ok := lookupJSONPath(doc, "data[1].id"); ok {
+		t.Errorf("expected missing path for out of range index")
+	}
+	doc, ok = setJSONPath(doc, "data[0].tags.a", "c")
+	if !ok {
+		t.Fatalf("set data[0].tags.a failed")
+	}
+	v, _ = lookupJSONPath(doc, "data[0].tags")
+	if !reflect.DeepEqual(v, map[string]interface{}{"a": "c"}) {
+		t.Errorf("unexpected value after set: %v", v)
+	}
+}
+
+func TestRestResourceCreateLargeNumericID(t *testing.T) {
+	ctx := context.Background()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":9007199254740993}`))
+	}))
+	defer ts.Close()
+
+	res := &restResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"path":          tftypes.NewValue(tftypes.String, "/exclusions"),
+		"create_method": tftypes.NewValue(tftypes.String, "POST"),
+		"update_method": tftypes.NewValue(tftypes.String, "PUT"),
+		"delete_method": tftypes.NewValue(tftypes.String, "DELETE"),
+		"body":          tftypes.NewValue(tftypes.String, `{"name":"maintenance"}`),
+		"id_path":       tftypes.NewValue(tftypes.String, "id"),
+		"compare_keys":  tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"response":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	var state restResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state decode error: %v", diags)
+	}
+	if state.ID.ValueString() != "9007199254740993" {
+		t.Errorf("id = %q, want 9007199254740993", state.ID.ValueString())
+	}
+}
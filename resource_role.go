@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &roleResource{}
+var _ resource.ResourceWithConfigure = &roleResource{}
+var _ resource.ResourceWithImportState = &roleResource{}
+
+// defaultRoleTimeout limits each role operation unless the timeouts
+// block overrides it.
+const defaultRoleTimeout = 5 * time.Minute
+
+// roleResource manages a Tenable VM custom role and the privileges it
+// grants.  Privilege changes made in the UI show up as drift, so role
+// definitions stay under code review.
+type roleResource struct {
+	client TenableAPI
+}
+
+// NewRoleResource returns a new instance of the role resource.
+func NewRoleResource() resource.Resource {
+	return &roleResource{}
+}
+
+// roleResourceModel maps the resource schema data into a Go struct.
+type roleResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	UUID        types.String   `tfsdk:"uuid"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Privileges  types.Set      `tfsdk:"privileges"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_role`.
+func (r *roleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+// Schema defines the attributes of the role resource.  All of them are
+// updated in place.
+func (r *roleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Numeric identifier of the role.",
+				MarkdownDescription: "Numeric identifier of the role.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the role.",
+				MarkdownDescription: "UUID of the role.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Name of the role.",
+				MarkdownDescription: "Name of the role.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the role.",
+				MarkdownDescription: "Description of the role.",
+			},
+			"privileges": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				Description:         "Names of the privileges the role grants. The set replaces the role's privileges, so privileges added outside of Terraform are removed on the next apply.",
+				MarkdownDescription: "Names of the privileges the role grants. The set replaces the role's privileges, so privileges added outside of Terraform are removed on the next apply.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a Tenable VM custom role.",
+		MarkdownDescription: "Manages a Tenable VM custom role.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *roleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_role resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// planPrivileges returns the privileges of plan.
+func planPrivileges(ctx context.Context, plan roleResourceModel) ([]string, diag.Diagnostics) {
+	var privileges []string
+	diags := plan.Privileges.ElementsAs(ctx, &privileges, false)
+	return privileges, diags
+}
+
+// setRole records the API's view of role in state.  An empty
+// description is kept null so that leaving it unset does not show a
+// diff.
+func setRole(ctx context.Context, state *roleResourceModel, role *tenable.Role) diag.Diagnostics {
+	state.ID = types.StringValue(strconv.Itoa(role.ID))
+	state.UUID = types.StringValue(role.UUID)
+	state.Name = types.StringValue(role.Name)
+	state.Description = stringValueOrNull(role.Description)
+	privileges := role.Privileges
+	if privileges == nil {
+		privileges = []string{}
+	}
+	var diags diag.Diagnostics
+	state.Privileges, diags = types.SetValueFrom(ctx, types.StringType, privileges)
+	return diags
+}
+
+// Create creates the role.
+func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultRoleTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	privileges, diags := planPrivileges(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Tenable VM role", map[string]any{"name": plan.Name.ValueString()})
+	role, err := r.client.CreateRole(ctx, plan.Name.ValueString(), plan.Description.ValueString(), privileges)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM role",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM role", map[string]any{"role_id": role.ID})
+	state := plan
+	resp.Diagnostics.Append(setRole(ctx, &state, role)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the role from the API and removes it from state when
+// it was deleted outside of Terraform.
+func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultRoleTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Role ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	role, err := r.client.GetRole(ctx, id)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM role not found during read", map[string]any{"role_id": id})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM role",
+			errorDetail(err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setRole(ctx, &state, role)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the name, description and privileges of the role.
+func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state roleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultRoleTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Role ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	privileges, diags := planPrivileges(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	role, err := r.client.UpdateRole(ctx, id, plan.Name.ValueString(), plan.Description.ValueString(), privileges)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM role",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM role", map[string]any{"role_id": id})
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(setRole(ctx, &state, role)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the role.
+func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultRoleTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Role ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	// A role that is already gone has reached the desired state.
+	if err := r.client.DeleteRole(ctx, id); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM role",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM role", map[string]any{"role_id": id})
+}
+
+// ImportState imports an existing role by its numeric ID.  The other
+// attributes are populated by the subsequent Read.
+func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Role ID",
+			"Expected the numeric role ID but got: "+req.ID,
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
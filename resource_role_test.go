@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestRoleResourceLifecycle runs create, update, import, read and
+// delete against the in-memory fake, and checks that privileges
+// changed outside of Terraform show up as drift.
+func TestRoleResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &roleResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	privileges := func(names ...string) tftypes.Value {
+		values := make([]tftypes.Value, len(names))
+		for i, n := range names {
+			values[i] = tftypes.NewValue(tftypes.String, n)
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+	statePrivileges := func(state roleResourceModel) []string {
+		var names []string
+		state.Privileges.ElementsAs(ctx, &names, false)
+		sort.Strings(names)
+		return names
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "Scan Operator"),
+		"privileges": privileges("scan.launch", "scan.view"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state roleResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1" || state.UUID.ValueString() != "role-uuid-1" || !state.Description.IsNull() {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "1"),
+		"name":        tftypes.NewValue(tftypes.String, "Scan Operator"),
+		"description": tftypes.NewValue(tftypes.String, "Runs scans"),
+		"privileges":  privileges("scan.launch"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if r := fake.roles[0]; r.Description != "Runs scans" || !reflect.DeepEqual(r.Privileges, []string{"scan.launch"}) {
+		t.Errorf("role not updated in place: %+v", r)
+	}
+
+	// A privilege granted in the UI is read back as drift.
+	fake.roles[0].Privileges = append(fake.roles[0].Privileges, "user.manage")
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if got := statePrivileges(state); state.Description.ValueString() != "Runs scans" || !reflect.DeepEqual(got, []string{"scan.launch", "user.manage"}) {
+		t.Errorf("unexpected state after import: %+v, privileges %v", state, got)
+	}
+	invalid := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "Scan Operator"}, &invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("expected a non-numeric import ID to be rejected")
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.roles) != 0 {
+		t.Errorf("role not deleted: %v", fake.roles)
+	}
+
+	// A role deleted outside of Terraform is removed from state.
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted role to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
@@ -0,0 +1,572 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &scanResource{}
+var _ resource.ResourceWithConfigure = &scanResource{}
+var _ resource.ResourceWithImportState = &scanResource{}
+var _ resource.ResourceWithModifyPlan = &scanResource{}
+var _ resource.ResourceWithValidateConfig = &scanResource{}
+
+// defaultScanTimeout limits each scan operation unless the timeouts
+// block overrides it.  Operations make one or two API calls, plus the
+// wait for a new scan to become visible.
+const defaultScanTimeout = 5 * time.Minute
+
+// scanResource manages a Tenable VM scan configuration.  The resource
+// only defines the scan; launching it is left to its schedule or to
+// the Tenable UI.
+type scanResource struct {
+	client TenableAPI
+}
+
+// NewScanResource returns a new instance of the scan resource.
+func NewScanResource() resource.Resource {
+	return &scanResource{}
+}
+
+// scanResourceModel maps the resource schema data into a Go struct.
+type scanResourceModel struct {
+	ID            types.String                `tfsdk:"id"`
+	UUID          types.String                `tfsdk:"uuid"`
+	TemplateUUID  types.String                `tfsdk:"template_uuid"`
+	Name          types.String                `tfsdk:"name"`
+	Description   types.String                `tfsdk:"description"`
+	Targets       types.String                `tfsdk:"targets"`
+	ScannerID     types.String                `tfsdk:"scanner_id"`
+	FolderID      types.String                `tfsdk:"folder_id"`
+	Enabled       types.Bool                  `tfsdk:"enabled"`
+	AgentGroupIDs types.Set                   `tfsdk:"agent_group_ids"`
+	Schedule      *scanScheduleBlockModel     `tfsdk:"schedule"`
+	Notification  *scanNotificationBlockModel `tfsdk:"notification"`
+	Credentials   []scanCredentialModel       `tfsdk:"credentials"`
+	Timeouts      timeouts.Value              `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_scan`.
+func (r *scanResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan"
+}
+
+// Schema defines the attributes of the scan resource.  Changing the
+// template replaces the scan, as its settings depend on the template;
+// everything else is updated in place.
+func (r *scanResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Numeric identifier of the scan.",
+				MarkdownDescription: "Numeric identifier of the scan.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Schedule UUID of the scan, which other Tenable VM APIs use to reference it.",
+				MarkdownDescription: "Schedule UUID of the scan, which other Tenable VM APIs use to reference it.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"template_uuid": schema.StringAttribute{
+				Required:            true,
+				Description:         "UUID of the scan template, e.g. data.tenablevm_scan_template.example.uuid. Changing this forces a new scan to be created.",
+				MarkdownDescription: "UUID of the scan template, e.g. `data.tenablevm_scan_template.example.uuid`. Changing this forces a new scan to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Name of the scan.",
+				MarkdownDescription: "Name of the scan.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the scan.",
+				MarkdownDescription: "Description of the scan.",
+			},
+			"targets": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Comma-separated IP addresses, CIDR blocks, IP ranges and hostnames to scan. The normalize_targets function builds this value from a list. Conflicts with agent_group_ids.",
+				MarkdownDescription: "Comma-separated IP addresses, CIDR blocks, IP ranges and hostnames to scan. The `normalize_targets` function builds this value from a list. Conflicts with `agent_group_ids`.",
+			},
+			"scanner_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "UUID of the scanner or scanner group that runs the scan. Defaults to the Tenable cloud scanner. Conflicts with agent_group_ids.",
+				MarkdownDescription: "UUID of the scanner or scanner group that runs the scan. Defaults to the Tenable cloud scanner. Conflicts with `agent_group_ids`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"folder_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Numeric ID of the folder the scan is listed in. Defaults to the My Scans folder.",
+				MarkdownDescription: "Numeric ID of the folder the scan is listed in. Defaults to the My Scans folder.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Whether the scan's schedule is enabled. Defaults to schedule.enabled, or false without a schedule. Conflicts with the schedule block.",
+				MarkdownDescription: "Whether the scan's schedule is enabled. Defaults to `schedule.enabled`, or `false` without a schedule. Conflicts with the `schedule` block.",
+			},
+			"agent_group_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "UUIDs of the agent groups an agent scan runs on, e.g. data.tenablevm_agent_group.example.uuid. Required by agent scan templates and not allowed with other templates.",
+				MarkdownDescription: "UUIDs of the agent groups an agent scan runs on, e.g. `data.tenablevm_agent_group.example.uuid`. Required by agent scan templates and not allowed with other templates.",
+			},
+			"credentials": scanCredentialsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"schedule":     scanScheduleBlock(),
+			"notification": scanNotificationBlock(),
+			"timeouts":     timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a Tenable VM scan configuration.",
+		MarkdownDescription: "Manages a Tenable VM scan configuration.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *scanResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_scan resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// ValidateConfig rejects setting enabled next to a schedule block,
+// which has an enabled attribute of its own, mixing the targeting of
+// agent and network scans, credentials that set both or neither of
+// uuid and settings, and notifications without recipients.
+func (r *scanResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config scanResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.Enabled.IsNull() && config.Schedule != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enabled"),
+			"Conflicting Scan Settings",
+			"enabled cannot be set together with a schedule block; set schedule.enabled instead.",
+		)
+	}
+	if !config.AgentGroupIDs.IsNull() {
+		if !config.AgentGroupIDs.IsUnknown() && len(config.AgentGroupIDs.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("agent_group_ids"),
+				"Invalid Agent Groups",
+				"agent_group_ids must name at least one agent group.",
+			)
+		}
+		for _, name := range []string{"targets", "scanner_id"} {
+			var value types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Conflicting Scan Settings",
+					name+" cannot be set together with agent_group_ids: agent scans run on the agents of their groups.",
+				)
+			}
+		}
+	}
+	validateScanCredentials(config.Credentials, &resp.Diagnostics)
+	if n := config.Notification; n != nil && !n.Emails.IsNull() && !n.Emails.IsUnknown() && len(n.Emails.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("notification").AtName("emails"),
+			"Invalid Scan Notification",
+			"notification.emails must name at least one recipient; remove the notification block to email no one.",
+		)
+	}
+}
+
+// ModifyPlan checks that agent scan templates are used with agent
+// groups and other templates without, and plans enabled from the
+// schedule block when it is not configured, so that the two never
+// disagree.
+func (r *scanResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var config, plan scanResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The template only needs checking when it or the targeting
+	// changes, which saves a request on every plan.
+	if req.State.Raw.IsNull() {
+		r.checkTemplateFamily(ctx, plan, &resp.Diagnostics)
+	} else {
+		var state scanResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if !state.TemplateUUID.Equal(plan.TemplateUUID) || state.AgentGroupIDs.IsNull() != plan.AgentGroupIDs.IsNull() {
+			r.checkTemplateFamily(ctx, plan, &resp.Diagnostics)
+		}
+	}
+	if resp.Diagnostics.HasError() || !config.Enabled.IsNull() {
+		return
+	}
+	enabled := types.BoolValue(false)
+	if plan.Schedule != nil {
+		enabled = plan.Schedule.Enabled
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled"), enabled)...)
+}
+
+// checkTemplateFamily reports an error on agent_group_ids when they are
+// missing for an agent scan template or set for a network one.  It is
+// skipped while the template is unknown or the provider is not
+// configured, and for templates it cannot find, which Tenable rejects
+// on apply.
+func (r *scanResource) checkTemplateFamily(ctx context.Context, plan scanResourceModel, diags *diag.Diagnostics) {
+	if r.client == nil || plan.TemplateUUID.IsUnknown() || plan.AgentGroupIDs.IsUnknown() {
+		return
+	}
+	templates, err := r.client.ListScanTemplates(ctx, "scan")
+	if err != nil {
+		diags.AddError(
+			"Error listing Tenable VM scan templates",
+			errorDetail(err),
+		)
+		return
+	}
+	for _, t := range templates {
+		if t.UUID != plan.TemplateUUID.ValueString() {
+			continue
+		}
+		switch {
+		case t.IsAgent && plan.AgentGroupIDs.IsNull():
+			diags.AddAttributeError(
+				path.Root("agent_group_ids"),
+				"Missing Agent Groups",
+				fmt.Sprintf("The template %q is an agent scan template; set agent_group_ids to the agent groups to scan.", t.Title),
+			)
+		case !t.IsAgent && !plan.AgentGroupIDs.IsNull():
+			diags.AddAttributeError(
+				path.Root("agent_group_ids"),
+				"Unexpected Agent Groups",
+				fmt.Sprintf("The template %q is not an agent scan template; use targets instead of agent_group_ids, or an agent scan template.", t.Title),
+			)
+		}
+		return
+	}
+}
+
+// scanConfig builds the scan configuration from the plan.  Unset and
+// unknown optional attributes are left for Tenable to default.
+func scanConfig(ctx context.Context, plan scanResourceModel) (tenable.ScanConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := tenable.ScanConfig{
+		TemplateUUID: plan.TemplateUUID.ValueString(),
+		Name:         plan.Name.ValueString(),
+		Description:  plan.Description.ValueString(),
+		Targets:      plan.Targets.ValueString(),
+		ScannerID:    plan.ScannerID.ValueString(),
+		Enabled:      plan.Enabled.ValueBool(),
+	}
+	applyScanSchedule(&cfg, plan.Schedule)
+	notification, d := scanNotificationToAPI(ctx, plan.Notification)
+	diags.Append(d...)
+	cfg.Notification = notification
+	if !plan.AgentGroupIDs.IsNull() && !plan.AgentGroupIDs.IsUnknown() {
+		diags.Append(plan.AgentGroupIDs.ElementsAs(ctx, &cfg.AgentGroupIDs, false)...)
+	}
+	if folder := plan.FolderID.ValueString(); folder != "" {
+		id, err := strconv.Atoi(folder)
+		if err != nil {
+			diags.AddAttributeError(path.Root("folder_id"), "Invalid Folder ID", "Expected numeric ID but got: "+folder)
+		}
+		cfg.FolderID = id
+	}
+	return cfg, diags
+}
+
+// setScan records the API's view of scan in state.  Empty optional
+// values are kept null so that leaving them unset does not show a
+// diff.  The template is kept from state when the API does not report
+// it, and equivalent schedule values keep the spelling in state.
+func setScan(state *scanResourceModel, scan *tenable.Scan) {
+	state.ID = types.StringValue(strconv.Itoa(scan.ID))
+	state.UUID = stringValueOrNull(scan.UUID)
+	if scan.TemplateUUID != "" {
+		state.TemplateUUID = types.StringValue(scan.TemplateUUID)
+	}
+	state.Name = types.StringValue(scan.Name)
+	state.Description = stringValueOrNull(scan.Description)
+	state.Targets = stringValueOrNull(scan.Targets)
+	state.ScannerID = stringValueOrNull(scan.ScannerID)
+	state.FolderID = types.StringNull()
+	if scan.FolderID != 0 {
+		state.FolderID = types.StringValue(strconv.Itoa(scan.FolderID))
+	}
+	state.Enabled = types.BoolValue(scan.Enabled)
+	state.AgentGroupIDs = types.SetNull(types.StringType)
+	if len(scan.AgentGroupIDs) > 0 {
+		ids := make([]attr.Value, len(scan.AgentGroupIDs))
+		for i, id := range scan.AgentGroupIDs {
+			ids[i] = types.StringValue(id)
+		}
+		state.AgentGroupIDs = types.SetValueMust(types.StringType, ids)
+	}
+	state.Schedule = scanScheduleToModel(state.Schedule, scan)
+	state.Notification = scanNotificationToModel(state.Notification, scan.Notification)
+}
+
+// Create creates the scan and reads it back, so that the values
+// Tenable defaults are recorded in state.
+func (r *scanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan scanResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultScanTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cfg, diags := scanConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if len(plan.Credentials) > 0 {
+		add, diags := scanCredentialsToAPI(ctx, plan.Credentials)
+		resp.Diagnostics.Append(diags...)
+		cfg.Credentials = &tenable.ScanCredentials{Add: add}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Tenable VM scan", map[string]any{"name": cfg.Name, "template_uuid": cfg.TemplateUUID})
+	id, err := r.client.CreateScan(ctx, cfg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM scan",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM scan", map[string]any{"scan_id": id})
+	// Tenable may answer reads of the new scan with 404 for a few
+	// seconds, so wait until it is served, within a limit of its own.
+	waitCtx, cancelWait := context.WithTimeout(ctx, tenable.DefaultVisibilityTimeout)
+	scan, err := tenable.WaitUntilVisible(waitCtx, func() (*tenable.Scan, error) {
+		return r.client.GetScan(waitCtx, id)
+	})
+	cancelWait()
+	state := plan
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM scan after create",
+			errorDetail(err),
+		)
+		// The scan exists; save what is known so that Terraform taints
+		// and replaces it on the next apply instead of orphaning it.
+		scan = &tenable.Scan{
+			ID: id, TemplateUUID: cfg.TemplateUUID, Name: cfg.Name, Description: cfg.Description, Targets: cfg.Targets,
+			ScannerID: cfg.ScannerID, FolderID: cfg.FolderID, Enabled: cfg.Enabled, AgentGroupIDs: cfg.AgentGroupIDs,
+			RRules: cfg.RRules, StartTime: cfg.StartTime, Timezone: cfg.Timezone,
+		}
+		if len(cfg.Notification.Emails) > 0 {
+			scan.Notification = cfg.Notification
+		}
+	}
+	setScan(&state, scan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the scan from the API and removes it from state when
+// it was deleted outside of Terraform.
+func (r *scanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state scanResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultScanTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Scan ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	scan, err := r.client.GetScan(ctx, id)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM scan not found during read", map[string]any{"scan_id": id})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM scan",
+			errorDetail(err),
+		)
+		return
+	}
+	attached, err := r.client.ListScanCredentials(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM scan credentials",
+			errorDetail(err),
+		)
+		return
+	}
+	setScan(&state, scan)
+	state.Credentials = scanCredentialsFromAPI(state.Credentials, attached)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the scan's configuration and reads it back.
+func (r *scanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state scanResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultScanTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Scan ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	cfg, diags := scanConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Credentials cannot be compared with what is attached, as inline
+	// settings are not returned, so a change replaces all of them.
+	if !scanCredentialsEqual(plan.Credentials, state.Credentials) {
+		attached, err := r.client.ListScanCredentials(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Tenable VM scan credentials",
+				errorDetail(err),
+			)
+			return
+		}
+		add, diags := scanCredentialsToAPI(ctx, plan.Credentials)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		cfg.Credentials = &tenable.ScanCredentials{Add: add, Delete: scanCredentialIDs(attached)}
+	}
+	if err := r.client.UpdateScan(ctx, id, cfg); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM scan",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM scan", map[string]any{"scan_id": id})
+	scan, err := r.client.GetScan(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM scan after update",
+			errorDetail(err),
+		)
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	state.Schedule = plan.Schedule
+	state.Notification = plan.Notification
+	state.Credentials = plan.Credentials
+	setScan(&state, scan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the scan and its history.
+func (r *scanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state scanResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultScanTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Scan ID",
+			"Expected numeric ID but got: "+state.ID.ValueString(),
+		)
+		return
+	}
+	// A scan that is already gone has reached the desired state.
+	if err := r.client.DeleteScan(ctx, id); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM scan",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM scan", map[string]any{"scan_id": id})
+}
+
+// ImportState imports an existing scan by its numeric ID.  The other
+// attributes are populated by the subsequent Read.
+func (r *scanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Scan ID",
+			"Expected the numeric scan ID but got: "+req.ID,
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// scanCredentialModel is a credential attached to a scan: either a
+// managed credential referenced by uuid or an inline credential given
+// by its settings.
+type scanCredentialModel struct {
+	Category types.String `tfsdk:"category"`
+	Type     types.String `tfsdk:"type"`
+	UUID     types.String `tfsdk:"uuid"`
+	Settings types.Map    `tfsdk:"settings"`
+}
+
+// scanCredentialsAttribute returns the credentials attribute of the
+// scan resource.
+func scanCredentialsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional:            true,
+		Description:         "Credentials the scan authenticates with. Each entry sets either uuid, to attach a managed credential, or settings, to define the credential inline.",
+		MarkdownDescription: "Credentials the scan authenticates with. Each entry sets either `uuid`, to attach a managed credential, or `settings`, to define the credential inline.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"category": schema.StringAttribute{
+					Required:            true,
+					Description:         "Credential category as named in the scan editor, e.g. Host or Database.",
+					MarkdownDescription: "Credential category as named in the scan editor, e.g. `Host` or `Database`.",
+				},
+				"type": schema.StringAttribute{
+					Required:            true,
+					Description:         "Credential type as named in the scan editor, e.g. SSH or Windows.",
+					MarkdownDescription: "Credential type as named in the scan editor, e.g. `SSH` or `Windows`.",
+				},
+				"uuid": schema.StringAttribute{
+					Optional:            true,
+					Description:         "UUID of the managed credential to attach.",
+					MarkdownDescription: "UUID of the managed credential to attach.",
+				},
+				"settings": schema.MapAttribute{
+					ElementType:         types.StringType,
+					Optional:            true,
+					Sensitive:           true,
+					Description:         "Settings of an inline credential, e.g. auth_method, username and password for SSH. Tenable does not return them, so changes made outside of Terraform are not detected.",
+					MarkdownDescription: "Settings of an inline credential, e.g. `auth_method`, `username` and `password` for SSH. Tenable does not return them, so changes made outside of Terraform are not detected.",
+				},
+			},
+		},
+	}
+}
+
+// validateScanCredentials checks that each credential sets exactly one
+// of uuid and settings.  Unknown values are checked once they are known.
+func validateScanCredentials(creds []scanCredentialModel, diags *diag.Diagnostics) {
+	for i, c := range creds {
+		if c.UUID.IsUnknown() || c.Settings.IsUnknown() {
+			continue
+		}
+		if c.UUID.IsNull() == c.Settings.IsNull() {
+			diags.AddAttributeError(
+				path.Root("credentials").AtListIndex(i),
+				"Invalid Scan Credential",
+				"Set either uuid, to attach a managed credential, or settings, to define the credential inline, but not both.",
+			)
+		}
+	}
+}
+
+// scanCredentialsToAPI converts the credentials attribute to the
+// credentials to attach.
+func scanCredentialsToAPI(ctx context.Context, creds []scanCredentialModel) ([]tenable.ScanCredential, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	out := make([]tenable.ScanCredential, 0, len(creds))
+	for _, c := range creds {
+		cred := tenable.ScanCredential{
+			UUID:     c.UUID.ValueString(),
+			Category: c.Category.ValueString(),
+			Type:     c.Type.ValueString(),
+		}
+		if !c.Settings.IsNull() {
+			diags.Append(c.Settings.ElementsAs(ctx, &cred.Settings, false)...)
+		}
+		out = append(out, cred)
+	}
+	return out, diags
+}
+
+// scanCredentialsEqual reports whether a and b attach the same
+// credentials in the same order.
+func scanCredentialsEqual(a, b []scanCredentialModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Category.Equal(b[i].Category) || !a[i].Type.Equal(b[i].Type) ||
+			!a[i].UUID.Equal(b[i].UUID) || !a[i].Settings.Equal(b[i].Settings) {
+			return false
+		}
+	}
+	return true
+}
+
+// scanCredentialsFromAPI merges the credentials attached to a scan into
+// prior.  As Tenable does not return inline settings, an attached
+// credential is matched to an entry in prior of the same category and
+// type, and of the same uuid for managed credentials, which keeps the
+// settings in prior.  Entries of prior that are no longer attached are
+// dropped and credentials attached outside of Terraform are added
+// without settings, so both show up as drift.
+func scanCredentialsFromAPI(prior []scanCredentialModel, attached []tenable.ScanCredential) []scanCredentialModel {
+	matched := make([]bool, len(attached))
+	var out []scanCredentialModel
+	for _, p := range prior {
+		for i, a := range attached {
+			if matched[i] || p.Category.ValueString() != a.Category || p.Type.ValueString() != a.Type || p.UUID.ValueString() != a.UUID {
+				continue
+			}
+			matched[i] = true
+			out = append(out, p)
+			break
+		}
+	}
+	for i, a := range attached {
+		if matched[i] {
+			continue
+		}
+		out = append(out, scanCredentialModel{
+			Category: types.StringValue(a.Category),
+			Type:     types.StringValue(a.Type),
+			UUID:     stringValueOrNull(a.UUID),
+			Settings: types.MapNull(types.StringType),
+		})
+	}
+	return out
+}
+
+// scanCredentialIDs returns the IDs that remove creds from their scan.
+func scanCredentialIDs(creds []tenable.ScanCredential) []string {
+	ids := make([]string, len(creds))
+	for i, c := range creds {
+		ids[i] = c.ID
+	}
+	return ids
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// scanNotificationBlockModel is the notification block of the scan
+// resource.
+type scanNotificationBlockModel struct {
+	Emails     types.Set                     `tfsdk:"emails"`
+	FilterType types.String                  `tfsdk:"filter_type"`
+	Filters    []scanNotificationFilterModel `tfsdk:"filters"`
+}
+
+// scanNotificationFilterModel is a result filter of the notification
+// block.
+type scanNotificationFilterModel struct {
+	Filter  types.String `tfsdk:"filter"`
+	Quality types.String `tfsdk:"quality"`
+	Value   types.String `tfsdk:"value"`
+}
+
+// scanNotificationBlock returns the notification block of the scan
+// resource.
+func scanNotificationBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description:         "Who is emailed the results of the scan. Without a notification block the scan emails no one.",
+		MarkdownDescription: "Who is emailed the results of the scan. Without a `notification` block the scan emails no one.",
+		Attributes: map[string]schema.Attribute{
+			"emails": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				Description:         "Email addresses the results are sent to.",
+				MarkdownDescription: "Email addresses the results are sent to.",
+			},
+			"filter_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("and"),
+				Description:         "Whether a finding must match all filters (and) or any of them (or) to trigger the email. Defaults to and.",
+				MarkdownDescription: "Whether a finding must match all filters (`and`) or any of them (`or`) to trigger the email. Defaults to `and`.",
+				Validators:          []validator.String{stringOneOf("and", "or")},
+			},
+			"filters": schema.ListNestedAttribute{
+				Optional:            true,
+				Description:         "Result filters that findings must match for the email to be sent, e.g. severity eq Critical. Without filters every completed scan is emailed.",
+				MarkdownDescription: "Result filters that findings must match for the email to be sent, e.g. `severity` `eq` `Critical`. Without filters every completed scan is emailed.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"filter": schema.StringAttribute{
+							Required:            true,
+							Description:         "Name of the result filter, e.g. severity or plugin_id.",
+							MarkdownDescription: "Name of the result filter, e.g. `severity` or `plugin_id`.",
+						},
+						"quality": schema.StringAttribute{
+							Required:            true,
+							Description:         "Comparison operator, e.g. eq, neq or match.",
+							MarkdownDescription: "Comparison operator, e.g. `eq`, `neq` or `match`.",
+						},
+						"value": schema.StringAttribute{
+							Required:            true,
+							Description:         "Value to compare with, e.g. Critical.",
+							MarkdownDescription: "Value to compare with, e.g. `Critical`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// scanNotificationToAPI converts the notification block to the
+// notification to send.  A nil block sends a notification without
+// emails, which removes the scan's recipients.
+func scanNotificationToAPI(ctx context.Context, m *scanNotificationBlockModel) (*tenable.ScanNotification, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	n := &tenable.ScanNotification{}
+	if m == nil {
+		return n, diags
+	}
+	diags.Append(m.Emails.ElementsAs(ctx, &n.Emails, false)...)
+	n.FilterType = m.FilterType.ValueString()
+	for _, f := range m.Filters {
+		n.Filters = append(n.Filters, tenable.ScanNotificationFilter{
+			Filter:  f.Filter.ValueString(),
+			Quality: f.Quality.ValueString(),
+			Value:   f.Value.ValueString(),
+		})
+	}
+	return n, diags
+}
+
+// scanNotificationToModel converts the notification of a scan to the
+// notification block, or nil when the scan emails no one.  An empty
+// filters list in prior is kept when the scan has no filters, so that
+// filters = [] does not show a diff.
+func scanNotificationToModel(prior *scanNotificationBlockModel, n *tenable.ScanNotification) *scanNotificationBlockModel {
+	if n == nil {
+		return nil
+	}
+	emails := make([]attr.Value, len(n.Emails))
+	for i, e := range n.Emails {
+		emails[i] = types.StringValue(e)
+	}
+	m := &scanNotificationBlockModel{
+		Emails:     types.SetValueMust(types.StringType, emails),
+		FilterType: types.StringValue("and"),
+	}
+	if n.FilterType != "" {
+		m.FilterType = types.StringValue(n.FilterType)
+	}
+	for _, f := range n.Filters {
+		m.Filters = append(m.Filters, scanNotificationFilterModel{
+			Filter:  types.StringValue(f.Filter),
+			Quality: types.StringValue(f.Quality),
+			Value:   types.StringValue(f.Value),
+		})
+	}
+	if len(m.Filters) == 0 && prior != nil {
+		m.Filters = prior.Filters
+	}
+	return m
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// scanScheduleBlockModel is the schedule block of the scan resource.
+// The attribute names match the scan settings, so the object returned
+// by the scan_schedule function can be copied into it field by field.
+type scanScheduleBlockModel struct {
+	RRules    types.String `tfsdk:"rrules"`
+	StartTime types.String `tfsdk:"starttime"`
+	Timezone  types.String `tfsdk:"timezone"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+}
+
+// scanScheduleBlock returns the schedule block of the scan resource.
+func scanScheduleBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description:         "When the scan runs. Without a schedule the scan only runs when launched by hand. The scan_schedule function builds these values from friendly inputs.",
+		MarkdownDescription: "When the scan runs. Without a schedule the scan only runs when launched by hand. The `scan_schedule` function builds these values from friendly inputs.",
+		Attributes: map[string]schema.Attribute{
+			"rrules": schema.StringAttribute{
+				Required:            true,
+				Description:         "Recurrence rule, e.g. FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH. FREQ is one of ONETIME, DAILY, WEEKLY, MONTHLY or YEARLY.",
+				MarkdownDescription: "Recurrence rule, e.g. `FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH`. `FREQ` is one of `ONETIME`, `DAILY`, `WEEKLY`, `MONTHLY` or `YEARLY`.",
+				Validators:          []validator.String{scanRRuleValidator{}},
+			},
+			"starttime": schema.StringAttribute{
+				Required:            true,
+				Description:         "First run in the schedule's timezone, in the form YYYYMMDDTHHMMSS.",
+				MarkdownDescription: "First run in the schedule's timezone, in the form `YYYYMMDDTHHMMSS`.",
+				Validators:          []validator.String{scanStartTimeValidator{}},
+			},
+			"timezone": schema.StringAttribute{
+				Required:            true,
+				Description:         "IANA time zone name, e.g. Asia/Tokyo.",
+				MarkdownDescription: "IANA time zone name, e.g. `Asia/Tokyo`.",
+				Validators:          []validator.String{timezoneValidator{}},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				Description:         "Whether the schedule is active. Defaults to true.",
+				MarkdownDescription: "Whether the schedule is active. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+// applyScanSchedule copies the schedule block into cfg.  A nil block
+// sends an empty schedule, which removes the scan's schedule.
+func applyScanSchedule(cfg *tenable.ScanConfig, m *scanScheduleBlockModel) {
+	if m == nil {
+		return
+	}
+	cfg.RRules = m.RRules.ValueString()
+	cfg.StartTime = m.StartTime.ValueString()
+	cfg.Timezone = m.Timezone.ValueString()
+}
+
+// scanScheduleToModel converts the schedule of scan to the schedule
+// block, or nil when the scan has none.  Tenable normalizes schedules,
+// e.g. by adding INTERVAL=1 or reordering BYDAY, so a value that is
+// equivalent to the one in prior keeps prior's spelling; otherwise
+// every refresh would show a diff.
+func scanScheduleToModel(prior *scanScheduleBlockModel, scan *tenable.Scan) *scanScheduleBlockModel {
+	if scan.RRules == "" {
+		return nil
+	}
+	m := &scanScheduleBlockModel{
+		RRules:    types.StringValue(scan.RRules),
+		StartTime: types.StringValue(scan.StartTime),
+		Timezone:  types.StringValue(scan.Timezone),
+		Enabled:   types.BoolValue(scan.Enabled),
+	}
+	if prior == nil {
+		return m
+	}
+	if normalizeScanRRule(prior.RRules.ValueString()) == normalizeScanRRule(scan.RRules) {
+		m.RRules = prior.RRules
+	}
+	if equivalentScanStartTimes(prior.StartTime.ValueString(), scan.StartTime) {
+		m.StartTime = prior.StartTime
+	}
+	if strings.EqualFold(prior.Timezone.ValueString(), scan.Timezone) {
+		m.Timezone = prior.Timezone
+	}
+	return m
+}
+
+// equivalentScanStartTimes reports whether a and b are the same
+// starttime, possibly spelled differently.
+func equivalentScanStartTimes(a, b string) bool {
+	ta, errA := parseScanStartTime(a)
+	tb, errB := parseScanStartTime(b)
+	return errA == nil && errB == nil && ta.Equal(tb)
+}
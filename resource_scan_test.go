@@ -0,0 +1,561 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestScanResourceLifecycle runs create, update, import, read and
+// delete against the in-memory fake, and checks that defaults applied
+// by Tenable are recorded and changes made outside of Terraform show
+// up as drift.
+func TestScanResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &scanResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+		"targets":       tftypes.NewValue(tftypes.String, "10.0.0.0/24"),
+		"scanner_id":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"folder_id":     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"enabled":       tftypes.NewValue(tftypes.Bool, false),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state scanResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1" || state.UUID.ValueString() != "scan-uuid-1" || state.FolderID.ValueString() != "2" ||
+		!state.ScannerID.IsNull() || !state.Description.IsNull() {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "1"),
+		"uuid":          tftypes.NewValue(tftypes.String, "scan-uuid-1"),
+		"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+		"description":   tftypes.NewValue(tftypes.String, "DMZ hosts"),
+		"targets":       tftypes.NewValue(tftypes.String, "10.0.0.0/24,10.0.1.5"),
+		"scanner_id":    tftypes.NewValue(tftypes.String, "scanner-uuid"),
+		"folder_id":     tftypes.NewValue(tftypes.String, "5"),
+		"enabled":       tftypes.NewValue(tftypes.Bool, true),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if s := fake.scans[1]; s.Description != "DMZ hosts" || s.Targets != "10.0.0.0/24,10.0.1.5" || s.ScannerID != "scanner-uuid" || s.FolderID != 5 || !s.Enabled {
+		t.Errorf("scan not updated in place: %+v", s)
+	}
+
+	// Targets changed in the UI are read back as drift.
+	fake.scans[1].Targets = "10.0.0.0/24"
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.TemplateUUID.ValueString() != "basic-uuid" || state.Name.ValueString() != "Weekly DMZ" ||
+		state.Targets.ValueString() != "10.0.0.0/24" || state.FolderID.ValueString() != "5" || !state.Enabled.ValueBool() {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+	invalid := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "Weekly DMZ"}, &invalid)
+	if !invalid.Diagnostics.HasError() {
+		t.Error("expected a non-numeric import ID to be rejected")
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.scans) != 0 {
+		t.Errorf("scan not deleted: %v", fake.scans)
+	}
+	// Deleting a scan that is already gone succeeds, and the scan
+	// drops out of state.
+	deleteResp = resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete of a deleted scan: %v", deleteResp.Diagnostics)
+	}
+	readResp = resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted scan to be removed from state, got %v", readResp.Diagnostics)
+	}
+}
+
+// TestScanResourceSchedule checks that a schedule is sent to Tenable,
+// that the forms Tenable normalizes it to do not show up as a diff,
+// and that enabled follows the schedule block.
+func TestScanResourceSchedule(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &scanResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	scheduleType := schResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["schedule"]
+	schedule := func(rrules string, enabled any) tftypes.Value {
+		return tftypes.NewValue(scheduleType, map[string]tftypes.Value{
+			"rrules":    tftypes.NewValue(tftypes.String, rrules),
+			"starttime": tftypes.NewValue(tftypes.String, "20240401T093000"),
+			"timezone":  tftypes.NewValue(tftypes.String, "Asia/Tokyo"),
+			"enabled":   tftypes.NewValue(tftypes.Bool, enabled),
+		})
+	}
+
+	// enabled cannot be set next to a schedule block.
+	config := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+		"enabled":  tftypes.NewValue(tftypes.Bool, true),
+		"schedule": schedule("FREQ=WEEKLY;BYDAY=TH,MO", nil),
+	})
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(config)}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected enabled next to a schedule block to be rejected")
+	}
+
+	// An unset enabled is planned from the schedule.
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+		"scanner_id":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"folder_id":     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"enabled":       tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+		"schedule":      schedule("FREQ=WEEKLY;BYDAY=TH,MO", true),
+	})
+	modifyResp := resource.ModifyPlanResponse{Plan: plan}
+	res.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configOf(buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"schedule": schedule("FREQ=WEEKLY;BYDAY=TH,MO", nil),
+	})), Plan: plan, State: emptyState}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan: %v", modifyResp.Diagnostics)
+	}
+	var planned scanResourceModel
+	modifyResp.Plan.Get(ctx, &planned)
+	if !planned.Enabled.ValueBool() {
+		t.Errorf("enabled not planned from the schedule: %+v", planned.Enabled)
+	}
+
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: modifyResp.Plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if s := fake.scans[1]; s.RRules != "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH" || s.StartTime != "20240401T093000" || s.Timezone != "Asia/Tokyo" || !s.Enabled {
+		t.Fatalf("schedule not sent: %+v", s)
+	}
+	// Tenable's normal form and a starttime without seconds keep the
+	// configured spelling.
+	fake.scans[1].StartTime = "20240401T0930"
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var state scanResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.Schedule == nil || state.Schedule.RRules.ValueString() != "FREQ=WEEKLY;BYDAY=TH,MO" ||
+		state.Schedule.StartTime.ValueString() != "20240401T093000" || !state.Schedule.Enabled.ValueBool() || !state.Enabled.ValueBool() {
+		t.Errorf("unexpected schedule after read: %+v", state.Schedule)
+	}
+	// A schedule changed in the UI is read back as drift.
+	fake.scans[1].RRules = "FREQ=DAILY;INTERVAL=1"
+	readResp = resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	readResp.State.Get(ctx, &state)
+	if state.Schedule == nil || state.Schedule.RRules.ValueString() != "FREQ=DAILY;INTERVAL=1" {
+		t.Errorf("schedule drift not detected: %+v", state.Schedule)
+	}
+
+	// Removing the block removes the schedule.
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "1"),
+		"uuid":          tftypes.NewValue(tftypes.String, "scan-uuid-1"),
+		"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+		"scanner_id":    tftypes.NewValue(tftypes.String, ""),
+		"folder_id":     tftypes.NewValue(tftypes.String, "2"),
+		"enabled":       tftypes.NewValue(tftypes.Bool, false),
+	})
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &state)
+	if s := fake.scans[1]; s.RRules != "" || s.Enabled || state.Schedule != nil {
+		t.Errorf("schedule not removed: %+v, state %+v", s, state.Schedule)
+	}
+}
+
+// TestScanResourceCredentials checks that managed and inline
+// credentials are attached, that inline settings survive a refresh
+// and that a change replaces the attached credentials.
+func TestScanResourceCredentials(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &scanResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	credsType := schResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["credentials"].(tftypes.List)
+	credType := credsType.ElementType.(tftypes.Object)
+	settingsType := credType.AttributeTypes["settings"]
+	managed := func(typ, uuid string) tftypes.Value {
+		return tftypes.NewValue(credType, map[string]tftypes.Value{
+			"category": tftypes.NewValue(tftypes.String, "Host"),
+			"type":     tftypes.NewValue(tftypes.String, typ),
+			"uuid":     tftypes.NewValue(tftypes.String, uuid),
+			"settings": tftypes.NewValue(settingsType, nil),
+		})
+	}
+	inline := func(username string) tftypes.Value {
+		return tftypes.NewValue(credType, map[string]tftypes.Value{
+			"category": tftypes.NewValue(tftypes.String, "Host"),
+			"type":     tftypes.NewValue(tftypes.String, "SSH"),
+			"uuid":     tftypes.NewValue(tftypes.String, nil),
+			"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+				"auth_method": tftypes.NewValue(tftypes.String, "password"),
+				"username":    tftypes.NewValue(tftypes.String, username),
+				"password":    tftypes.NewValue(tftypes.String, "secret"),
+			}),
+		})
+	}
+	planWith := func(creds ...tftypes.Value) tfsdk.Plan {
+		attrs := map[string]tftypes.Value{
+			"id":            tftypes.NewValue(tftypes.String, "1"),
+			"uuid":          tftypes.NewValue(tftypes.String, "scan-uuid-1"),
+			"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+			"name":          tftypes.NewValue(tftypes.String, "Authenticated"),
+			"folder_id":     tftypes.NewValue(tftypes.String, "2"),
+			"enabled":       tftypes.NewValue(tftypes.Bool, false),
+		}
+		if creds != nil {
+			attrs["credentials"] = tftypes.NewValue(credsType, creds)
+		}
+		return buildResourcePlan(ctx, schResp.Schema, attrs)
+	}
+
+	// Each credential sets either uuid or settings.
+	var validateResp resource.ValidateConfigResponse
+	invalid := tftypes.NewValue(credType, map[string]tftypes.Value{
+		"category": tftypes.NewValue(tftypes.String, "Host"),
+		"type":     tftypes.NewValue(tftypes.String, "SSH"),
+		"uuid":     tftypes.NewValue(tftypes.String, nil),
+		"settings": tftypes.NewValue(settingsType, nil),
+	})
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(planWith(managed("Windows", "cred-uuid"), invalid))}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected a credential without uuid or settings to be rejected")
+	}
+
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: planWith(managed("Windows", "cred-uuid"), inline("scan"))}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if got := fake.scanCreds[1]; len(got) != 2 || got[0].UUID != "cred-uuid" || got[1].Type != "SSH" {
+		t.Fatalf("credentials not attached: %+v", got)
+	}
+
+	// The inline settings are kept, while a credential removed outside
+	// of Terraform shows up as drift.
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var state scanResourceModel
+	readResp.State.Get(ctx, &state)
+	if len(state.Credentials) != 2 || state.Credentials[1].Settings.IsNull() {
+		t.Fatalf("unexpected credentials after read: %+v", state.Credentials)
+	}
+	fake.scanCreds[1] = fake.scanCreds[1][1:]
+	readResp = resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	readResp.State.Get(ctx, &state)
+	if len(state.Credentials) != 1 || state.Credentials[0].Type.ValueString() != "SSH" {
+		t.Errorf("removed credential not detected: %+v", state.Credentials)
+	}
+
+	// A change replaces the attached credentials.
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: planWith(inline("admin")), State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if got := fake.scanCreds[1]; len(got) != 1 || got[0].Type != "SSH" || got[0].ID == "2" {
+		t.Errorf("credentials not replaced: %+v", got)
+	}
+	updateResp = resource.UpdateResponse{State: readResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: planWith(), State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if got := fake.scanCreds[1]; len(got) != 0 {
+		t.Errorf("credentials not removed: %+v", got)
+	}
+}
+
+// TestScanResourceAgentScan checks that agent scans target agent
+// groups, and that agent and network targeting cannot be mixed.
+func TestScanResourceAgentScan(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	fake.templates = map[string][]*tenable.ScanTemplate{"scan": {
+		{UUID: "basic-uuid", Title: "Basic Network Scan"},
+		{UUID: "agent-uuid", Title: "Basic Agent Scan", IsAgent: true},
+	}}
+	res := &scanResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	planFor := func(template string, attrs map[string]tftypes.Value) tfsdk.Plan {
+		attrs["template_uuid"] = tftypes.NewValue(tftypes.String, template)
+		attrs["name"] = tftypes.NewValue(tftypes.String, "Agents")
+		return buildResourcePlan(ctx, schResp.Schema, attrs)
+	}
+
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(planFor("agent-uuid", map[string]tftypes.Value{
+		"agent_group_ids": stringSetValue("group-uuid"),
+		"targets":         tftypes.NewValue(tftypes.String, "10.0.0.0/24"),
+	}))}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected targets next to agent_group_ids to be rejected")
+	}
+
+	for _, tc := range []struct {
+		name  string
+		plan  tfsdk.Plan
+		valid bool
+	}{
+		{"agent template with groups", planFor("agent-uuid", map[string]tftypes.Value{"agent_group_ids": stringSetValue("group-uuid")}), true},
+		{"agent template without groups", planFor("agent-uuid", map[string]tftypes.Value{}), false},
+		{"network template with groups", planFor("basic-uuid", map[string]tftypes.Value{"agent_group_ids": stringSetValue("group-uuid")}), false},
+		{"network template with targets", planFor("basic-uuid", map[string]tftypes.Value{"targets": tftypes.NewValue(tftypes.String, "10.0.0.1")}), true},
+	} {
+		modifyResp := resource.ModifyPlanResponse{Plan: tc.plan}
+		res.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configOf(tc.plan), Plan: tc.plan, State: emptyState}, &modifyResp)
+		if modifyResp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.name, tc.valid, modifyResp.Diagnostics)
+		}
+	}
+
+	plan := planFor("agent-uuid", map[string]tftypes.Value{
+		"agent_group_ids": stringSetValue("group-uuid-1", "group-uuid-2"),
+		"scanner_id":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"folder_id":       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"enabled":         tftypes.NewValue(tftypes.Bool, false),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if s := fake.scans[1]; len(s.AgentGroupIDs) != 2 || s.Targets != "" {
+		t.Fatalf("agent groups not sent: %+v", s)
+	}
+	var state scanResourceModel
+	createResp.State.Get(ctx, &state)
+	var groups []string
+	state.AgentGroupIDs.ElementsAs(ctx, &groups, false)
+	sort.Strings(groups)
+	if !reflect.DeepEqual(groups, []string{"group-uuid-1", "group-uuid-2"}) || !state.Targets.IsNull() {
+		t.Errorf("unexpected state after create: agent groups %v, targets %v", groups, state.Targets)
+	}
+}
+
+// TestScanResourceUpdateClearsTargets checks that switching a scan
+// from targets to agent groups clears the targets on the API, which
+// keeps the old value of any setting an update leaves out.
+func TestScanResourceUpdateClearsTargets(t *testing.T) {
+	ctx := context.Background()
+	stored := map[string]interface{}{"name": "Agents", "text_targets": "10.0.0.0/24", "scanner_id": "scanner-uuid"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/scans/7":
+			var body struct {
+				Settings map[string]interface{} `json:"settings"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			for k, v := range body.Settings {
+				stored[k] = v
+			}
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/scans/7":
+			json.NewEncoder(w).Encode(map[string]interface{}{"info": map[string]interface{}{
+				"template_uuid":  "agent-uuid",
+				"name":           stored["name"],
+				"targets":        stored["text_targets"],
+				"scanner_uuid":   stored["scanner_id"],
+				"agent_group_id": stored["agent_group_id"],
+			}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	res := &scanResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	state := tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "7"),
+		"template_uuid": tftypes.NewValue(tftypes.String, "agent-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Agents"),
+		"targets":       tftypes.NewValue(tftypes.String, "10.0.0.0/24"),
+		"scanner_id":    tftypes.NewValue(tftypes.String, "scanner-uuid"),
+		"enabled":       tftypes.NewValue(tftypes.Bool, false),
+	}).Raw}
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "7"),
+		"template_uuid":   tftypes.NewValue(tftypes.String, "agent-uuid"),
+		"name":            tftypes.NewValue(tftypes.String, "Agents"),
+		"agent_group_ids": stringSetValue("group-uuid"),
+		"enabled":         tftypes.NewValue(tftypes.Bool, false),
+	})
+	updateResp := resource.UpdateResponse{State: state}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if stored["text_targets"] != "" || stored["scanner_id"] != "" {
+		t.Errorf("targets and scanner not cleared: %v", stored)
+	}
+	var got scanResourceModel
+	updateResp.State.Get(ctx, &got)
+	if !got.Targets.IsNull() || !got.ScannerID.IsNull() || got.AgentGroupIDs.IsNull() {
+		t.Errorf("unexpected state after update: targets %v, scanner %v, agent groups %v", got.Targets, got.ScannerID, got.AgentGroupIDs)
+	}
+}
+
+// TestScanResourceNotification checks that the notification block is
+// sent as scan settings, that recipients changed in the UI are read
+// back as drift and that removing the block removes the recipients.
+func TestScanResourceNotification(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &scanResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+	notificationType := schResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["notification"].(tftypes.Object)
+	filtersType := notificationType.AttributeTypes["filters"].(tftypes.List)
+	notification := func(emails ...string) tftypes.Value {
+		values := make([]tftypes.Value, len(emails))
+		for i, e := range emails {
+			values[i] = tftypes.NewValue(tftypes.String, e)
+		}
+		return tftypes.NewValue(notificationType, map[string]tftypes.Value{
+			"emails":      tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values),
+			"filter_type": tftypes.NewValue(tftypes.String, "and"),
+			"filters": tftypes.NewValue(filtersType, []tftypes.Value{
+				tftypes.NewValue(filtersType.ElementType, map[string]tftypes.Value{
+					"filter":  tftypes.NewValue(tftypes.String, "severity"),
+					"quality": tftypes.NewValue(tftypes.String, "eq"),
+					"value":   tftypes.NewValue(tftypes.String, "Critical"),
+				}),
+			}),
+		})
+	}
+	attrs := func(notification tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+			"name":          tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+			"targets":       tftypes.NewValue(tftypes.String, "10.0.0.0/24"),
+			"scanner_id":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"folder_id":     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"enabled":       tftypes.NewValue(tftypes.Bool, false),
+			"notification":  notification,
+		}
+	}
+
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(buildResourcePlan(ctx, schResp.Schema, attrs(notification())))}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected a notification without emails to be rejected")
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs(notification("secops@example.com", "oncall@example.com")))
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	n := fake.scans[1].Notification
+	if n == nil {
+		t.Fatal("notification not sent")
+	}
+	emails := slices.Sorted(slices.Values(n.Emails))
+	wantFilters := []tenable.ScanNotificationFilter{{Filter: "severity", Quality: "eq", Value: "Critical"}}
+	if !reflect.DeepEqual(emails, []string{"oncall@example.com", "secops@example.com"}) || n.FilterType != "and" || !reflect.DeepEqual(n.Filters, wantFilters) {
+		t.Errorf("unexpected notification: %+v", n)
+	}
+
+	// Recipients changed in the UI are read back as drift.
+	fake.scans[1].Notification.Emails = []string{"secops@example.com"}
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var state scanResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.Notification == nil || len(state.Notification.Emails.Elements()) != 1 || len(state.Notification.Filters) != 1 {
+		t.Errorf("unexpected notification after read: %+v", state.Notification)
+	}
+
+	// Removing the block removes the recipients.
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "1"),
+		"uuid":          tftypes.NewValue(tftypes.String, "scan-uuid-1"),
+		"template_uuid": tftypes.NewValue(tftypes.String, "basic-uuid"),
+		"name":          tftypes.NewValue(tftypes.String, "Weekly DMZ"),
+		"targets":       tftypes.NewValue(tftypes.String, "10.0.0.0/24"),
+		"folder_id":     tftypes.NewValue(tftypes.String, "2"),
+		"enabled":       tftypes.NewValue(tftypes.Bool, false),
+	})
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &state)
+	if fake.scans[1].Notification != nil || state.Notification != nil {
+		t.Errorf("notification not removed: %+v, state %+v", fake.scans[1].Notification, state.Notification)
+	}
+}
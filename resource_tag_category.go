@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &tagCategoryResource{}
+var _ resource.ResourceWithConfigure = &tagCategoryResource{}
+var _ resource.ResourceWithImportState = &tagCategoryResource{}
+
+// defaultTagTimeout limits each tag category and tag value operation
+// unless the timeouts block overrides it.
+const defaultTagTimeout = 5 * time.Minute
+
+// tagCategoryResource manages a Tenable VM tag category.  Categories
+// are addressed by UUID, which is used as the resource ID.
+type tagCategoryResource struct {
+	client TenableAPI
+}
+
+// NewTagCategoryResource returns a new instance of the tag category
+// resource.
+func NewTagCategoryResource() resource.Resource {
+	return &tagCategoryResource{}
+}
+
+// tagCategoryResourceModel maps the resource schema data into a Go
+// struct.
+type tagCategoryResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_tag_category`.
+func (r *tagCategoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_category"
+}
+
+// Schema defines the attributes of the tag category resource.  Both
+// the name and the description are updated in place.
+func (r *tagCategoryResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the tag category.",
+				MarkdownDescription: "UUID of the tag category.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Name of the tag category, unique within the container.",
+				MarkdownDescription: "Name of the tag category, unique within the container.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the tag category.",
+				MarkdownDescription: "Description of the tag category.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a Tenable VM tag category.",
+		MarkdownDescription: "Manages a Tenable VM tag category.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *tagCategoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_tag_category resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// setTagCategory records the API's view of category in state.  An
+// empty description is kept null so that leaving it unset does not
+// show a diff.
+func setTagCategory(state *tagCategoryResourceModel, category *tenable.TagCategory) {
+	state.ID = types.StringValue(category.UUID)
+	state.Name = types.StringValue(category.Name)
+	state.Description = stringValueOrNull(category.Description)
+}
+
+// Create creates the tag category.
+func (r *tagCategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tagCategoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Creating Tenable VM tag category", map[string]any{"name": plan.Name.ValueString()})
+	category, err := r.client.CreateTagCategory(ctx, plan.Name.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM tag category",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM tag category", map[string]any{"category_uuid": category.UUID})
+	state := plan
+	setTagCategory(&state, category)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the tag category from the API and removes it from
+// state when it was deleted outside of Terraform.
+func (r *tagCategoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tagCategoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	category, err := r.client.GetTagCategory(ctx, uuid)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM tag category not found during read", map[string]any{"category_uuid": uuid})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM tag category",
+			errorDetail(err),
+		)
+		return
+	}
+	setTagCategory(&state, category)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update changes the name and description of the tag category.
+func (r *tagCategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tagCategoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	category, err := r.client.UpdateTagCategory(ctx, uuid, plan.Name.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM tag category",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM tag category", map[string]any{"category_uuid": uuid})
+	state.Timeouts = plan.Timeouts
+	setTagCategory(&state, category)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the tag category.  Tenable VM deletes its values with
+// it.
+func (r *tagCategoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tagCategoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	// A category that is already gone has reached the desired state.
+	if err := r.client.DeleteTagCategory(ctx, uuid); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM tag category",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM tag category", map[string]any{"category_uuid": uuid})
+}
+
+// ImportState imports an existing tag category by its UUID.  The other
+// attributes are populated by the subsequent Read.
+func (r *tagCategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// Ensure the resource implementation satisfies the expected interfaces.
+var _ resource.Resource = &tagValueResource{}
+var _ resource.ResourceWithConfigure = &tagValueResource{}
+var _ resource.ResourceWithImportState = &tagValueResource{}
+
+// tagValueResource manages a value within a Tenable VM tag category.
+// Values are addressed by UUID, which is used as the resource ID.
+type tagValueResource struct {
+	client TenableAPI
+}
+
+// NewTagValueResource returns a new instance of the tag value resource.
+func NewTagValueResource() resource.Resource {
+	return &tagValueResource{}
+}
+
+// tagValueResourceModel maps the resource schema data into a Go struct.
+type tagValueResourceModel struct {
+	ID           types.String     `tfsdk:"id"`
+	CategoryID   types.String     `tfsdk:"category_id"`
+	CategoryName types.String     `tfsdk:"category_name"`
+	Value        types.String     `tfsdk:"value"`
+	Description  types.String     `tfsdk:"description"`
+	Filters      *tagFiltersModel `tfsdk:"filters"`
+	Timeouts     timeouts.Value   `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name to `tenablevm_tag_value`.
+func (r *tagValueResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_value"
+}
+
+// Schema defines the attributes of the tag value resource.  The value,
+// description and rules are updated in place; moving the value to
+// another category replaces it, as the API cannot move values.
+func (r *tagValueResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the tag value.",
+				MarkdownDescription: "UUID of the tag value.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"category_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "UUID of the tag category, e.g. tenablevm_tag_category.example.id. Changing this forces a new tag value to be created.",
+				MarkdownDescription: "UUID of the tag category, e.g. `tenablevm_tag_category.example.id`. Changing this forces a new tag value to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"category_name": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Name of the tag category.",
+				MarkdownDescription: "Name of the tag category.",
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				Description:         "The tag value, unique within its category.",
+				MarkdownDescription: "The tag value, unique within its category.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the tag value.",
+				MarkdownDescription: "Description of the tag value.",
+			},
+			"filters": tagFiltersAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
+		Description:         "Manages a value within a Tenable VM tag category.",
+		MarkdownDescription: "Manages a value within a Tenable VM tag category.",
+	}
+}
+
+// Configure stores the provider's API client on the resource.
+func (r *tagValueResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			"The provider data supplied to the tenablevm_tag_value resource is not a *providerData. This is a bug in the provider implementation.",
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// setTagValue records the API's view of value in state.  An empty
+// description is kept null so that leaving it unset does not show a
+// diff.
+func setTagValue(state *tagValueResourceModel, value *tenable.TagValue) diag.Diagnostics {
+	state.ID = types.StringValue(value.UUID)
+	state.CategoryID = types.StringValue(value.CategoryUUID)
+	state.CategoryName = types.StringValue(value.CategoryName)
+	state.Value = types.StringValue(value.Value)
+	state.Description = stringValueOrNull(value.Description)
+	var diags diag.Diagnostics
+	state.Filters, diags = tagFiltersToModel(value.Filters)
+	return diags
+}
+
+// Create creates the tag value.
+func (r *tagValueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tagValueResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	filters, diags := tagFiltersFromModel(plan.Filters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating Tenable VM tag value", map[string]any{
+		"category_uuid": plan.CategoryID.ValueString(),
+		"value":         plan.Value.ValueString(),
+		"dynamic":       filters != nil,
+	})
+	value, err := r.client.CreateTagValue(ctx, plan.CategoryID.ValueString(), plan.Value.ValueString(), plan.Description.ValueString(), filters)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tenable VM tag value",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created Tenable VM tag value", map[string]any{"value_uuid": value.UUID})
+	state := plan
+	resp.Diagnostics.Append(setTagValue(&state, value)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the tag value from the API and removes it from state
+// when it, or its category, was deleted outside of Terraform.
+func (r *tagValueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tagValueResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	value, err := r.client.GetTagValue(ctx, uuid)
+	if errors.Is(err, tenable.ErrNotFound) {
+		tflog.Info(ctx, "Tenable VM tag value not found during read", map[string]any{"value_uuid": uuid})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM tag value",
+			errorDetail(err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setTagValue(&state, value)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update changes the value, description and rules.
+func (r *tagValueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tagValueResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	filters, diags := tagFiltersFromModel(plan.Filters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	value, err := r.client.UpdateTagValue(ctx, uuid, plan.Value.ValueString(), plan.Description.ValueString(), filters)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tenable VM tag value",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Updated Tenable VM tag value", map[string]any{"value_uuid": uuid})
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(setTagValue(&state, value)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the tag value, which unassigns it from every asset.
+func (r *tagValueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tagValueResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultTagTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	uuid := state.ID.ValueString()
+	// A value that is already gone, for example because its category
+	// was destroyed first, has reached the desired state.
+	if err := r.client.DeleteTagValue(ctx, uuid); err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Tenable VM tag value",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Deleted Tenable VM tag value", map[string]any{"value_uuid": uuid})
+}
+
+// ImportState imports an existing tag value by its UUID.  The other
+// attributes are populated by the subsequent Read.
+func (r *tagValueResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
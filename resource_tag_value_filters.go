@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// tagFiltersModel is the rule tree of a dynamic tag value.  The
+// conditions and groups are combined using filter_type, and each group
+// combines its own conditions, which gives the two levels the Tenable
+// VM rule editor offers.
+type tagFiltersModel struct {
+	FilterType types.String          `tfsdk:"filter_type"`
+	Conditions []assetFilterModel    `tfsdk:"conditions"`
+	Groups     []tagFilterGroupModel `tfsdk:"groups"`
+}
+
+// tagFilterGroupModel is a nested and/or of conditions.
+type tagFilterGroupModel struct {
+	FilterType types.String       `tfsdk:"filter_type"`
+	Conditions []assetFilterModel `tfsdk:"conditions"`
+}
+
+// tagFilterTypeAttribute returns the filter_type attribute of the rule
+// tree and its groups.
+func tagFilterTypeAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "How the conditions are combined: and or or. Defaults to and.",
+		MarkdownDescription: "How the conditions are combined: `and` or `or`. Defaults to `and`.",
+		Default:             stringdefault.StaticString("and"),
+		Validators:          []validator.String{stringOneOf("and", "or")},
+	}
+}
+
+// tagConditionsAttribute returns the conditions attribute of the rule
+// tree and its groups.
+func tagConditionsAttribute(required bool) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Required:            required,
+		Optional:            !required,
+		Description:         "Conditions on asset fields.",
+		MarkdownDescription: "Conditions on asset fields.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"field": schema.StringAttribute{
+					Required:            true,
+					Description:         "Asset field to match (e.g. operating_system, ipv4, fqdn).",
+					MarkdownDescription: "Asset field to match (e.g. `operating_system`, `ipv4`, `fqdn`).",
+				},
+				"operator": schema.StringAttribute{
+					Required:            true,
+					Description:         "Match operator (e.g. eq, neq, match, nmatch).",
+					MarkdownDescription: "Match operator (e.g. `eq`, `neq`, `match`, `nmatch`).",
+				},
+				"value": schema.StringAttribute{
+					Required:            true,
+					Description:         "Value to compare the field against. Separate multiple values with commas.",
+					MarkdownDescription: "Value to compare the field against. Separate multiple values with commas.",
+				},
+			},
+		},
+	}
+}
+
+// tagFiltersAttribute returns the filters attribute of the tag value
+// resource.
+func tagFiltersAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		Description:         "Rules that make the value dynamic: Tenable VM applies it to every asset matching them. Adding or removing filters forces a new tag value to be created, as Tenable VM does not convert values between static and dynamic.",
+		MarkdownDescription: "Rules that make the value dynamic: Tenable VM applies it to every asset matching them. Adding or removing `filters` forces a new tag value to be created, as Tenable VM does not convert values between static and dynamic.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplaceIf(
+				func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+				},
+				"Adding or removing filters forces a new tag value to be created.",
+				"Adding or removing `filters` forces a new tag value to be created.",
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"filter_type": tagFilterTypeAttribute(),
+			"conditions":  tagConditionsAttribute(false),
+			"groups": schema.ListNestedAttribute{
+				Optional:            true,
+				Description:         "Nested groups of conditions, each combined using its own filter_type.",
+				MarkdownDescription: "Nested groups of conditions, each combined using its own `filter_type`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"filter_type": tagFilterTypeAttribute(),
+						"conditions":  tagConditionsAttribute(true),
+					},
+				},
+			},
+		},
+	}
+}
+
+// combineTagFilters returns the and/or of nodes.
+func combineTagFilters(filterType string, nodes []tenable.TagFilter) tenable.TagFilter {
+	if strings.EqualFold(filterType, "or") {
+		return tenable.TagFilter{Or: nodes}
+	}
+	return tenable.TagFilter{And: nodes}
+}
+
+// tagConditions converts conditions to rule tree leaves.
+func tagConditions(conditions []assetFilterModel) []tenable.TagFilter {
+	nodes := make([]tenable.TagFilter, 0, len(conditions))
+	for _, c := range conditions {
+		nodes = append(nodes, tenable.TagFilter{
+			Field:    c.Field.ValueString(),
+			Operator: c.Operator.ValueString(),
+			Value:    c.Value.ValueString(),
+		})
+	}
+	return nodes
+}
+
+// tagFiltersFromModel converts the filters of the plan to the rule tree
+// sent to the API.  It returns nil for a static value.
+func tagFiltersFromModel(m *tagFiltersModel) (*tenable.TagFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m == nil {
+		return nil, diags
+	}
+	if len(m.Conditions) == 0 && len(m.Groups) == 0 {
+		diags.AddAttributeError(
+			path.Root("filters"),
+			"Missing Tag Filters",
+			"filters must contain at least one condition or group.",
+		)
+		return nil, diags
+	}
+	nodes := tagConditions(m.Conditions)
+	for i, g := range m.Groups {
+		if len(g.Conditions) == 0 {
+			diags.AddAttributeError(
+				path.Root("filters").AtName("groups").AtListIndex(i).AtName("conditions"),
+				"Missing Tag Filters",
+				"Each group must contain at least one condition.",
+			)
+			continue
+		}
+		nodes = append(nodes, combineTagFilters(g.FilterType.ValueString(), tagConditions(g.Conditions)))
+	}
+	root := combineTagFilters(m.FilterType.ValueString(), nodes)
+	return &root, diags
+}
+
+// splitTagFilter returns how node combines its children and the
+// children.  A single condition is treated as an and of itself.
+func splitTagFilter(node tenable.TagFilter) (string, []tenable.TagFilter) {
+	switch {
+	case node.And != nil:
+		return "and", node.And
+	case node.Or != nil:
+		return "or", node.Or
+	}
+	return "and", []tenable.TagFilter{node}
+}
+
+// isTagCondition reports whether node is a leaf of the rule tree.
+func isTagCondition(node tenable.TagFilter) bool {
+	return node.And == nil && node.Or == nil
+}
+
+// tagConditionModels converts rule tree leaves to conditions.
+func tagConditionModels(nodes []tenable.TagFilter) []assetFilterModel {
+	conditions := make([]assetFilterModel, 0, len(nodes))
+	for _, n := range nodes {
+		conditions = append(conditions, assetFilterModel{
+			Field:    types.StringValue(n.Field),
+			Operator: types.StringValue(n.Operator),
+			Value:    types.StringValue(n.Value),
+		})
+	}
+	return conditions
+}
+
+// tagFiltersToModel converts the rule tree read from the API to the
+// filters recorded in state.  Rule trees nested deeper than groups of
+// conditions, which only the API can create, cannot be represented and
+// are reported as an error.
+func tagFiltersToModel(root *tenable.TagFilter) (*tagFiltersModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if root == nil {
+		return nil, diags
+	}
+	filterType, nodes := splitTagFilter(*root)
+	m := &tagFiltersModel{FilterType: types.StringValue(filterType)}
+	var conditions []tenable.TagFilter
+	for _, n := range nodes {
+		if isTagCondition(n) {
+			conditions = append(conditions, n)
+			continue
+		}
+		groupType, children := splitTagFilter(n)
+		for _, c := range children {
+			if !isTagCondition(c) {
+				diags.AddAttributeError(
+					path.Root("filters"),
+					"Unsupported Tag Filters",
+					"The rules of this tag value nest groups within groups, which the filters attribute cannot represent. Simplify the rules in Tenable VM to conditions and groups of conditions.",
+				)
+				return nil, diags
+			}
+		}
+		m.Groups = append(m.Groups, tagFilterGroupModel{
+			FilterType: types.StringValue(groupType),
+			Conditions: tagConditionModels(children),
+		})
+	}
+	if len(conditions) > 0 {
+		m.Conditions = tagConditionModels(conditions)
+	}
+	return m, diags
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+// TestTagValueResourceLifecycle runs create, update, import, read and
+// delete against the in-memory fake, and checks that values deleted
+// with their category drop out of state.
+func TestTagValueResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	category, _ := fake.CreateTagCategory(ctx, "Location", "")
+	res := &tagValueResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"category_id": tftypes.NewValue(tftypes.String, category.UUID),
+		"value":       tftypes.NewValue(tftypes.String, "Tokyo"),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state tagValueResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "value-uuid-2" || state.CategoryName.ValueString() != "Location" || !state.Description.IsNull() {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "value-uuid-2"),
+		"category_id": tftypes.NewValue(tftypes.String, category.UUID),
+		"value":       tftypes.NewValue(tftypes.String, "Tokyo HQ"),
+		"description": tftypes.NewValue(tftypes.String, "Head office"),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if v := fake.tagValues["value-uuid-2"]; v.Value != "Tokyo HQ" || v.Description != "Head office" {
+		t.Errorf("value not updated in place: %+v", v)
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "value-uuid-2"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.CategoryID.ValueString() != category.UUID || state.Value.ValueString() != "Tokyo HQ" || state.Description.ValueString() != "Head office" {
+		t.Errorf("unexpected state after import: %+v", state)
+	}
+
+	// Deleting the category deletes its values; the value then drops
+	// out of state and destroying it succeeds.
+	fake.DeleteTagCategory(ctx, category.UUID)
+	refreshed := resource.ReadResponse{State: readResp.State}
+	res.Read(ctx, resource.ReadRequest{State: readResp.State}, &refreshed)
+	if refreshed.Diagnostics.HasError() || !refreshed.State.Raw.IsNull() {
+		t.Errorf("expected the deleted value to be removed from state, got %v", refreshed.Diagnostics)
+	}
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("Delete of a removed value: %v", deleteResp.Diagnostics)
+	}
+}
+
+// TestTagValueResourceFilters creates a dynamic value, checks the rule
+// tree sent to the API and that reading it back yields the planned
+// filters, and that rules too deep for the schema are reported.
+func TestTagValueResourceFilters(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	category, _ := fake.CreateTagCategory(ctx, "OS", "")
+	res := &tagValueResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	filtersType := schResp.Schema.Attributes["filters"].GetType().TerraformType(ctx).(tftypes.Object)
+	conditionsType := filtersType.AttributeTypes["conditions"].(tftypes.List)
+	conditionType := conditionsType.ElementType.(tftypes.Object)
+	groupsType := filtersType.AttributeTypes["groups"].(tftypes.List)
+	groupType := groupsType.ElementType.(tftypes.Object)
+	condition := func(field, operator, value string) tftypes.Value {
+		return tftypes.NewValue(conditionType, map[string]tftypes.Value{
+			"field":    tftypes.NewValue(tftypes.String, field),
+			"operator": tftypes.NewValue(tftypes.String, operator),
+			"value":    tftypes.NewValue(tftypes.String, value),
+		})
+	}
+	filters := tftypes.NewValue(filtersType, map[string]tftypes.Value{
+		"filter_type": tftypes.NewValue(tftypes.String, "or"),
+		"conditions": tftypes.NewValue(conditionsType, []tftypes.Value{
+			condition("operating_system", "match", "Windows"),
+		}),
+		"groups": tftypes.NewValue(groupsType, []tftypes.Value{
+			tftypes.NewValue(groupType, map[string]tftypes.Value{
+				"filter_type": tftypes.NewValue(tftypes.String, "and"),
+				"conditions": tftypes.NewValue(conditionsType, []tftypes.Value{
+					condition("operating_system", "match", "Linux"),
+					condition("ipv4", "eq", "10.0.0.0/8"),
+				}),
+			}),
+		}),
+	})
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"category_id": tftypes.NewValue(tftypes.String, category.UUID),
+		"value":       tftypes.NewValue(tftypes.String, "Managed servers"),
+		"filters":     filters,
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	stored := fake.tagValues["value-uuid-2"]
+	want := &tenable.TagFilter{Or: []tenable.TagFilter{
+		{Field: "operating_system", Operator: "match", Value: "Windows"},
+		{And: []tenable.TagFilter{
+			{Field: "operating_system", Operator: "match", Value: "Linux"},
+			{Field: "ipv4", Operator: "eq", Value: "10.0.0.0/8"},
+		}},
+	}}
+	if stored.Type != "dynamic" || !reflect.DeepEqual(stored.Filters, want) {
+		t.Fatalf("stored filters = %+v, want %+v", stored.Filters, want)
+	}
+	var planned, created types.Object
+	plan.GetAttribute(ctx, path.Root("filters"), &planned)
+	createResp.State.GetAttribute(ctx, path.Root("filters"), &created)
+	if !created.Equal(planned) {
+		t.Errorf("filters after create = %v, want %v", created, planned)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.Equal(createResp.State.Raw) {
+		t.Errorf("refresh shows a diff:\n%v\n%v", readResp.State.Raw, createResp.State.Raw)
+	}
+
+	// Groups within groups cannot be represented.
+	stored.Filters = &tenable.TagFilter{And: []tenable.TagFilter{{Or: []tenable.TagFilter{{And: []tenable.TagFilter{{Field: "fqdn", Operator: "eq", Value: "a"}}}}}}}
+	readResp = resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if !readResp.Diagnostics.HasError() {
+		t.Error("expected an error for rules nested too deeply")
+	}
+}
@@ -2,31 +2,67 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	// Structured logging for resources
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
 )
 
 // Ensure the resource implementation satisfies the expected interfaces.
 var _ resource.Resource = &userResource{}
 var _ resource.ResourceWithConfigure = &userResource{}
 var _ resource.ResourceWithImportState = &userResource{}
+var _ resource.ResourceWithValidateConfig = &userResource{}
+var _ resource.ResourceWithModifyPlan = &userResource{}
+
+// Default limits for the user operations, overridable through the
+// timeouts block.  An operation makes several API calls, each of which
+// may be retried, so the limits are generous.
+const (
+	defaultUserCreateTimeout = 10 * time.Minute
+	defaultUserReadTimeout   = 5 * time.Minute
+	defaultUserUpdateTimeout = 10 * time.Minute
+	defaultUserDeleteTimeout = 5 * time.Minute
+)
+
+// administratorPermissions is the permissions value of the
+// administrator role.
+const administratorPermissions = 64
+
+// Account types accepted by the users API.
+const (
+	accountTypeLocal = "local"
+	accountTypeSAML  = "saml"
+)
 
 // userResource implements the Terraform resource for managing Tenable VM
 // users.  It embeds a client pointer which is configured by the
 // provider.  Each CRUD method uses the client to interact with
 // Tenable's API.
 type userResource struct {
-	client *Client
+	client TenableAPI
+	// checkUsernames enables the plan-time username check.
+	checkUsernames bool
 }
 
 // NewUserResource returns a new instance of the user resource.  This
@@ -40,14 +76,29 @@ func NewUserResource() resource.Resource {
 // attributes leverage the framework's types to track null/unknown
 // values.
 type userResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Username    types.String `tfsdk:"username"`
-	Password    types.String `tfsdk:"password"`
-	Permissions types.Int64  `tfsdk:"permissions"`
-	Name        types.String `tfsdk:"name"`
-	Email       types.String `tfsdk:"email"`
-	AccountType types.String `tfsdk:"account_type"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
+	ID                  types.String   `tfsdk:"id"`
+	UUID                types.String   `tfsdk:"uuid"`
+	Username            types.String   `tfsdk:"username"`
+	Password            types.String   `tfsdk:"password"`
+	PasswordWOVersion   types.Int64    `tfsdk:"password_wo_version"`
+	Permissions         types.Int64    `tfsdk:"permissions"`
+	RoleName            types.String   `tfsdk:"role_name"`
+	Name                types.String   `tfsdk:"name"`
+	Email               types.String   `tfsdk:"email"`
+	AccountType         types.String   `tfsdk:"account_type"`
+	Enabled             types.Bool     `tfsdk:"enabled"`
+	GenerateAPIKeys     types.Bool     `tfsdk:"generate_api_keys"`
+	ForcePasswordChange types.Bool     `tfsdk:"force_password_change"`
+	APIAccessKey        types.String   `tfsdk:"api_access_key"`
+	APISecretKey        types.String   `tfsdk:"api_secret_key"`
+	APIPermitted        types.Bool     `tfsdk:"api_permitted"`
+	PasswordPermitted   types.Bool     `tfsdk:"password_permitted"`
+	SAMLPermitted       types.Bool     `tfsdk:"saml_permitted"`
+	LastLogin           types.String   `tfsdk:"last_login"`
+	LoginFailCount      types.Int64    `tfsdk:"login_fail_count"`
+	Lockout             types.Bool     `tfsdk:"lockout"`
+	RawJSON             types.String   `tfsdk:"raw_json"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata sets the resource type name.  The type name is appended
@@ -60,13 +111,15 @@ func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the schema for the Tenable VM user resource.  It
 // closely mirrors the fields accepted by Tenable's API while
 // adhering to Terraform semantics.  Certain attributes, such as
-// username, password and account_type, are marked with plan
-// modifiers to force a new resource if they change, since the
-// underlying API does not allow in‑place modification of these
-// values.  The password is write‑only and sensitive so it is never
-// persisted in state.
-func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+// username and account_type, are marked with plan modifiers to force
+// a new resource if they change, since the underlying API does not
+// allow in‑place modification of these values.  The password is
+// write‑only and sensitive so it is never persisted in state; because
+// Terraform cannot see changes to it, password_wo_version triggers
+// the in-place password change.
+func (r *userResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: userSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -74,24 +127,44 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				MarkdownDescription: "Numeric identifier of the user.",
 			},
+			"uuid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "UUID of the user, which other Tenable VM APIs such as permissions and tags use to reference it.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				MarkdownDescription: "UUID of the user, which other Tenable VM APIs such as permissions and tags use to reference it.",
+			},
 			"username": schema.StringAttribute{
 				Required:            true,
-				Description:         "The username for the Tenable VM user. Must be unique.",
+				Description:         "The username for the Tenable VM user, in the form of an email address. Must be unique.",
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
-				MarkdownDescription: "The username for the Tenable VM user. Must be unique.",
+				MarkdownDescription: "The username for the Tenable VM user, in the form of an email address. Must be unique.",
+				Validators:          []validator.String{emailValidator{}},
 			},
 			"password": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
 				WriteOnly:           true,
-				Description:         "Password for the user. Password updates are not supported; changing this forces replacement.",
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
-				MarkdownDescription: "Password for the user. Password updates are not supported; changing this forces replacement.",
+				Description:         "Password for the user. The value is write-only and never stored in state; change password_wo_version to apply a new password.",
+				MarkdownDescription: "Password for the user. The value is write-only and never stored in state; change `password_wo_version` to apply a new password.",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "Version of the write-only password. Changing it sets the user's password to the configured value in place.",
+				MarkdownDescription: "Version of the write-only `password`. Changing it sets the user's password to the configured value in place.",
 			},
 			"permissions": schema.Int64Attribute{
-				Required:            true,
-				Description:         "Numeric permissions role for the user. See Tenable's user roles documentation for valid values【946957473917885†L60-L74】.",
-				MarkdownDescription: "Numeric permissions role for the user. See Tenable's user roles documentation for valid values【946957473917885†L60-L74】.",
+				Optional:            true,
+				Computed:            true,
+				Description:         "Numeric permissions role for the user: 16 (basic), 24 (scan_operator), 32 (standard), 40 (scan_manager) or 64 (administrator). See Tenable's user roles documentation for details. Exactly one of permissions and role_name must be set.",
+				MarkdownDescription: "Numeric permissions role for the user: 16 (basic), 24 (scan_operator), 32 (standard), 40 (scan_manager) or 64 (administrator). See Tenable's user roles documentation for details. Exactly one of `permissions` and `role_name` must be set.",
+				Validators:          []validator.Int64{permissionsValidator{}},
+			},
+			"role_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Role of the user by name: basic, scan_operator, standard, scan_manager or administrator. An alternative to permissions; the other attribute is derived from whichever is set.",
+				MarkdownDescription: "Role of the user by name: `basic`, `scan_operator`, `standard`, `scan_manager` or `administrator`. An alternative to `permissions`; the other attribute is derived from whichever is set.",
+				Validators:          []validator.String{roleNameValidator{}},
 			},
 			"name": schema.StringAttribute{
 				Optional:            true,
@@ -102,20 +175,95 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:            true,
 				Description:         "Email address for the user.",
 				MarkdownDescription: "Email address for the user.",
+				Validators:          []validator.String{emailValidator{}},
 			},
 			"account_type": schema.StringAttribute{
 				Optional:            true,
-				Description:         "Account type for the user (e.g. local). Changing this forces a new user to be created.",
-				MarkdownDescription: "Account type for the user (e.g. local). Changing this forces a new user to be created.",
-				Default:             stringdefault.StaticString("local"),
+				Computed:            true,
+				Description:         "Account type for the user: local, which requires a password on creation, or saml, which authenticates through the identity provider and takes no password. Changing this forces a new user to be created.",
+				MarkdownDescription: "Account type for the user: `local`, which requires a `password` on creation, or `saml`, which authenticates through the identity provider and takes no password. Changing this forces a new user to be created.",
+				Default:             stringdefault.StaticString(accountTypeLocal),
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringOneOf(accountTypeLocal, accountTypeSAML)},
 			},
 			"enabled": schema.BoolAttribute{
 				Optional:            true,
+				Computed:            true,
 				Description:         "Whether the user account is enabled.",
 				MarkdownDescription: "Whether the user account is enabled.",
 				Default:             booldefault.StaticBool(true),
 			},
+			"force_password_change": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Whether the user must change the password at the next login, e.g. when handing out a temporary password. It is applied when the user is created, when the flag changes and when the password is rotated, and is not read back because the user clears it by changing the password.",
+				MarkdownDescription: "Whether the user must change the password at the next login, e.g. when handing out a temporary password. It is applied when the user is created, when the flag changes and when the password is rotated, and is not read back because the user clears it by changing the password.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"generate_api_keys": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Whether to generate an API key pair for the user, e.g. for service accounts. The keys are generated once, when the user is created or the flag is turned on; generating keys revokes any keys the user already has.",
+				MarkdownDescription: "Whether to generate an API key pair for the user, e.g. for service accounts. The keys are generated once, when the user is created or the flag is turned on; generating keys revokes any keys the user already has.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"api_access_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "Access key generated when generate_api_keys is true.",
+				MarkdownDescription: "Access key generated when `generate_api_keys` is true.",
+				PlanModifiers:       []planmodifier.String{apiKeyPlanModifier{}},
+			},
+			"api_secret_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "Secret key generated when generate_api_keys is true.",
+				MarkdownDescription: "Secret key generated when `generate_api_keys` is true.",
+				PlanModifiers:       []planmodifier.String{apiKeyPlanModifier{}},
+			},
+			"api_permitted": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Whether the user may authenticate with API keys. Defaults to the value Tenable assigns when unset.",
+				MarkdownDescription: "Whether the user may authenticate with API keys. Defaults to the value Tenable assigns when unset.",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"password_permitted": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Whether the user may log in with a password. Defaults to the value Tenable assigns when unset.",
+				MarkdownDescription: "Whether the user may log in with a password. Defaults to the value Tenable assigns when unset.",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"saml_permitted": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Whether the user may log in through SAML single sign-on. Defaults to the value Tenable assigns when unset.",
+				MarkdownDescription: "Whether the user may log in through SAML single sign-on. Defaults to the value Tenable assigns when unset.",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"last_login": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Time of the user's last successful login in RFC 3339 format, or null if the user never logged in.",
+				MarkdownDescription: "Time of the user's last successful login in RFC 3339 format, or null if the user never logged in.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"login_fail_count": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "Number of failed logins since the last successful one.",
+				MarkdownDescription: "Number of failed logins since the last successful one.",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"lockout": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Whether the account is locked after too many failed logins.",
+				MarkdownDescription: "Whether the account is locked after too many failed logins.",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"raw_json": rawJSONResourceAttribute("user"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
 		},
 		Description:         "Manages a Tenable Vulnerability Management user account.",
 		MarkdownDescription: "Manages a Tenable Vulnerability Management user account.",
@@ -130,15 +278,198 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data Type",
-			"The provider data supplied to the tenablevm_user resource is not a *Client. This is a bug in the provider implementation.",
+			"The provider data supplied to the tenablevm_user resource is not a *providerData. This is a bug in the provider implementation.",
 		)
 		return
 	}
-	r.client = client
+	r.client = data.Client
+	r.checkUsernames = data.CheckUsernames
+}
+
+// ValidateConfig requires exactly one of permissions and role_name, so
+// the role is stated once and cannot contradict itself.
+func (r *userResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config userResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch {
+	case config.Permissions.IsNull() && config.RoleName.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("permissions"),
+			"Missing user role",
+			"One of permissions or role_name must be set.",
+		)
+	case !config.Permissions.IsNull() && !config.RoleName.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_name"),
+			"Conflicting user role settings",
+			"Only one of permissions and role_name may be set.",
+		)
+	}
+	// SAML users log in through the identity provider, so a password
+	// would be silently ignored.
+	if config.AccountType.ValueString() == accountTypeSAML {
+		for _, attr := range []struct {
+			name string
+			set  bool
+		}{
+			{"password", !config.Password.IsNull()},
+			{"password_wo_version", !config.PasswordWOVersion.IsNull()},
+			{"force_password_change", config.ForcePasswordChange.ValueBool()},
+		} {
+			if attr.set {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attr.name),
+					"Password set for a SAML user",
+					fmt.Sprintf("SAML users authenticate through the identity provider and have no password. Remove %s or use account_type = %q.", attr.name, accountTypeLocal),
+				)
+			}
+		}
+	}
+}
+
+// ModifyPlan derives permissions from role_name, or role_name from
+// permissions, so both are known in the plan and stay consistent in
+// state whichever one is configured.  It also warns about updates that
+// lock administrators out, see warnLockout, and optionally rejects new
+// users whose username is taken, see checkUsername.
+func (r *userResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var config userResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch {
+	case !config.RoleName.IsNull() && !config.RoleName.IsUnknown():
+		if id, ok := permissionForRole(config.RoleName.ValueString()); ok {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("permissions"), types.Int64Value(id))...)
+		}
+	case !config.Permissions.IsNull() && !config.Permissions.IsUnknown():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("role_name"), roleNameValue(int(config.Permissions.ValueInt64())))...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if req.State.Raw.IsNull() {
+		if r.checkUsernames {
+			r.checkUsername(ctx, config.Username, &resp.Diagnostics)
+		}
+		return
+	}
+	var plan, state userResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.warnLockout(ctx, plan, state, &resp.Diagnostics)
+}
+
+// checkUsername fails the plan of a new user whose username is taken.
+// It is enabled by the provider's check_usernames option because it
+// lists all users.  A failed lookup is logged and left to the check
+// in Create.
+func (r *userResource) checkUsername(ctx context.Context, username types.String, diags *diag.Diagnostics) {
+	if r.client == nil || username.IsNull() || username.IsUnknown() {
+		return
+	}
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to check for an existing Tenable VM user during plan", map[string]any{
+			"username": username.ValueString(),
+			"error":    err.Error(),
+		})
+		return
+	}
+	if u := userByUsername(users, username.ValueString()); u != nil {
+		diags.AddAttributeError(path.Root("username"), "Tenable VM user already exists", existingUserDetail(u))
+	}
+}
+
+// userByUsername returns the user with the given username, compared
+// case-insensitively like Tenable does, or nil.
+func userByUsername(users []*tenable.User, username string) *tenable.User {
+	for _, u := range users {
+		if strings.EqualFold(u.Username, username) {
+			return u
+		}
+	}
+	return nil
+}
+
+// existingUserDetail explains how to adopt an existing user instead of
+// creating it.
+func existingUserDetail(u *tenable.User) string {
+	return fmt.Sprintf("A user with username %q already exists (ID %d). To manage it with Terraform, import it instead of creating it:\n\n  terraform import tenablevm_user.<name> %d", u.Username, u.ID, u.ID)
+}
+
+// warnLockout adds warnings when an update demotes or disables the
+// last enabled administrator, or demotes, disables or revokes API
+// access of the user the provider itself authenticates as.  Either
+// can leave nobody able to undo the change.  The checks only call the
+// API for such risky updates and are best effort: lookup failures are
+// logged and skipped.
+func (r *userResource) warnLockout(ctx context.Context, plan, state userResourceModel, diags *diag.Diagnostics) {
+	if r.client == nil {
+		return
+	}
+	demoted := !plan.Permissions.IsUnknown() && plan.Permissions.ValueInt64() < state.Permissions.ValueInt64()
+	disabled := !plan.Enabled.IsUnknown() && !plan.Enabled.ValueBool() && state.Enabled.ValueBool()
+	apiRevoked := !plan.APIPermitted.IsUnknown() && !plan.APIPermitted.IsNull() && !plan.APIPermitted.ValueBool() && state.APIPermitted.ValueBool()
+	if !demoted && !disabled && !apiRevoked {
+		return
+	}
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		return
+	}
+
+	if state.Permissions.ValueInt64() == administratorPermissions && (demoted || disabled) {
+		users, err := r.client.ListUsers(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Unable to list Tenable VM users for the administrator check", map[string]any{"error": err.Error()})
+		} else if !hasOtherAdministrator(users, id) {
+			diags.AddAttributeWarning(
+				path.Root("permissions"),
+				"Last administrator is being demoted or disabled",
+				fmt.Sprintf("User %s is the only enabled administrator. After this change no user will be able to manage users or restore administrator access.", state.Username.ValueString()),
+			)
+		}
+	}
+
+	current, err := r.client.CurrentUser(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to look up the Tenable VM user the provider authenticates as", map[string]any{"error": err.Error()})
+		return
+	}
+	if current.ID == id {
+		diags.AddAttributeWarning(
+			path.Root("enabled"),
+			"Change affects the provider's own account",
+			fmt.Sprintf("User %s owns the credentials this provider uses. Demoting or disabling it, or revoking its API access, can make this and later applies fail.", state.Username.ValueString()),
+		)
+	}
+}
+
+// hasOtherAdministrator reports whether an enabled administrator other
+// than the user with ID id exists.
+func hasOtherAdministrator(users []*tenable.User, id int) bool {
+	for _, u := range users {
+		if u.ID != id && u.Enabled && int64(u.Permissions) == administratorPermissions {
+			return true
+		}
+	}
+	return false
 }
 
 // Create implements the resource creation logic.  It reads the plan
@@ -152,12 +483,22 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	timeout, diags := plan.Timeouts.Create(ctx, defaultUserCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	// The password is write-only, so it is only present in the
+	// configuration.
+	password, diags := configPassword(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Extract values from plan
 	username := plan.Username.ValueString()
-	password := ""
-	if !plan.Password.IsNull() && !plan.Password.IsUnknown() {
-		password = plan.Password.ValueString()
-	}
 	permissions := int(plan.Permissions.ValueInt64())
 	var name string
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
@@ -167,10 +508,18 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if !plan.Email.IsNull() && !plan.Email.IsUnknown() {
 		email = plan.Email.ValueString()
 	}
-	accountType := "local"
+	accountType := accountTypeLocal
 	if !plan.AccountType.IsNull() && !plan.AccountType.IsUnknown() {
 		accountType = plan.AccountType.ValueString()
 	}
+	if accountType == accountTypeLocal && password == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing password",
+			"A password is required to create a local user. Set password, or use account_type = \"saml\" for users that log in through the identity provider.",
+		)
+		return
+	}
 	enabled := true
 	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
 		enabled = plan.Enabled.ValueBool()
@@ -183,13 +532,46 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"enabled":     enabled,
 	})
 
+	// Tenable rejects duplicate usernames with an opaque error, which
+	// practitioners usually hit when adopting accounts that already
+	// exist.  With check_usernames, look for a clash up front so the
+	// diagnostic can point at terraform import; the option is opt-in
+	// because it lists all users.  The check is best effort: if the
+	// list call fails, creation proceeds and the API has the final
+	// word.
+	if r.checkUsernames {
+		existing, err := r.client.ListUsers(ctx)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to check for an existing Tenable VM user", map[string]any{
+				"username": username,
+				"error":    err.Error(),
+			})
+		}
+		if u := userByUsername(existing, username); u != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Tenable VM user already exists",
+				existingUserDetail(u),
+			)
+			return
+		}
+	}
+
 	// Call API to create user
-	user, err := r.client.CreateUser(username, password, permissions, name, email, accountType, enabled)
+	user, err := r.client.CreateUser(ctx, username, password, permissions, name, email, accountType, enabled)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Tenable VM user",
-			err.Error(),
+			errorDetail(err),
 		)
+		if user != nil {
+			// The user exists even though a follow-up call failed.
+			// Save it so that Terraform taints and replaces it on the
+			// next apply instead of creating a duplicate.
+			state := partialUserState(plan, user, accountType)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			resp.Diagnostics.Append(setUserIdentity(ctx, resp.Identity, user)...)
+		}
 		return
 	}
 	// Log info with created user ID
@@ -197,14 +579,36 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"user_id":  user.ID,
 		"username": user.Username,
 	})
+	// Tenable may answer reads of the new user with 404 for a few
+	// seconds; wait until it is served so the next refresh does not
+	// mistake it for a deletion.  The user exists either way, so a
+	// timeout only warns.  The wait gets its own limit so that it
+	// leaves the rest of the create timeout to the calls below.
+	waitCtx, cancelWait := context.WithTimeout(ctx, tenable.DefaultVisibilityTimeout)
+	visible, err := tenable.WaitUntilVisible(waitCtx, func() (*tenable.User, error) {
+		return r.client.GetUser(waitCtx, user.ID)
+	})
+	cancelWait()
+	if err != nil {
+		tflog.Warn(ctx, "Created Tenable VM user is not visible yet", map[string]any{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		})
+	} else {
+		user = visible
+	}
 
 	// Build state from API response and plan
 	var state userResourceModel
+	state.Timeouts = plan.Timeouts
 	state.ID = types.StringValue(strconv.Itoa(user.ID))
+	state.UUID = types.StringValue(user.UUID)
 	state.Username = types.StringValue(user.Username)
 	// Never persist password in state; mark as null
 	state.Password = types.StringNull()
+	state.PasswordWOVersion = plan.PasswordWOVersion
 	state.Permissions = types.Int64Value(int64(user.Permissions))
+	state.RoleName = roleNameValue(user.Permissions)
 	if user.Name != "" {
 		state.Name = types.StringValue(user.Name)
 	} else {
@@ -215,15 +619,74 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	} else {
 		state.Email = types.StringNull()
 	}
-	// AccountType is not returned by the API; use plan value
-	if !plan.AccountType.IsNull() && !plan.AccountType.IsUnknown() {
-		state.AccountType = types.StringValue(plan.AccountType.ValueString())
-	} else {
-		state.AccountType = types.StringValue(accountType)
-	}
+	state.AccountType = accountTypeValue(user, types.StringValue(accountType))
 	state.Enabled = types.BoolValue(user.Enabled)
+	setLoginActivity(&state, user)
+	state.RawJSON = rawJSONValue(user.RawJSON)
+	state.GenerateAPIKeys = types.BoolValue(plan.GenerateAPIKeys.ValueBool())
+	state.ForcePasswordChange = types.BoolValue(plan.ForcePasswordChange.ValueBool())
+	state.APIAccessKey = types.StringNull()
+	state.APISecretKey = types.StringNull()
+	// Apply the configured authorizations and record the ones Tenable
+	// assigned to the others.
+	if err := r.syncAuthorizations(ctx, user.ID, plan, &state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting Tenable VM user authorizations",
+			errorDetail(err),
+		)
+	}
+	if plan.ForcePasswordChange.ValueBool() {
+		if err := r.client.SetUserForcePasswordChange(ctx, user.ID, true); err != nil {
+			resp.Diagnostics.AddError(
+				"Error requiring a password change for Tenable VM user",
+				errorDetail(err),
+			)
+		}
+	}
+	if plan.GenerateAPIKeys.ValueBool() {
+		// The user exists at this point, so a failure still saves
+		// it to state; Terraform taints it and recreates it on the
+		// next apply.
+		if err := r.generateAPIKeys(ctx, user.ID, &state); err != nil {
+			resp.Diagnostics.AddError(
+				"Error generating API keys for Tenable VM user",
+				errorDetail(err),
+			)
+		}
+	}
 	// Save state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setUserIdentity(ctx, resp.Identity, user)...)
+}
+
+// partialUserState is the state of a user whose creation failed after
+// the user itself was created.  Values the plan leaves unknown and
+// that Create has not read yet are null.
+func partialUserState(plan userResourceModel, user *tenable.User, accountType string) userResourceModel {
+	state := plan
+	state.ID = types.StringValue(strconv.Itoa(user.ID))
+	state.UUID = types.StringValue(user.UUID)
+	state.Password = types.StringNull()
+	state.Permissions = types.Int64Value(int64(user.Permissions))
+	state.RoleName = roleNameValue(user.Permissions)
+	state.AccountType = types.StringValue(accountType)
+	state.Enabled = types.BoolValue(user.Enabled)
+	state.GenerateAPIKeys = types.BoolValue(plan.GenerateAPIKeys.ValueBool())
+	state.ForcePasswordChange = types.BoolValue(plan.ForcePasswordChange.ValueBool())
+	state.APIAccessKey = types.StringNull()
+	state.APISecretKey = types.StringNull()
+	if state.APIPermitted.IsUnknown() {
+		state.APIPermitted = types.BoolNull()
+	}
+	if state.PasswordPermitted.IsUnknown() {
+		state.PasswordPermitted = types.BoolNull()
+	}
+	if state.SAMLPermitted.IsUnknown() {
+		state.SAMLPermitted = types.BoolNull()
+	}
+	setLoginActivity(&state, user)
+	state.RawJSON = rawJSONValue(user.RawJSON)
+	return state
 }
 
 // Read refreshes the resource state from the API.  If the user no
@@ -241,6 +704,13 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	timeout, diags := state.Timeouts.Read(ctx, defaultUserReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 	// Parse ID
 	idStr := state.ID.ValueString()
 	id, err := strconv.Atoi(idStr)
@@ -252,12 +722,36 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	// Call API to get user
-	user, err := r.client.GetUser(id)
+	user, err := r.client.GetUser(ctx, id)
+	if errors.Is(err, tenable.ErrNotFound) {
+		// The ID may have been reassigned; the UUID identity still
+		// finds the user in that case.
+		uuid, diags := identityUUID(ctx, req.Identity)
+		resp.Diagnostics.Append(diags...)
+		if uuid != "" {
+			if found, findErr := r.findUserByUUID(ctx, uuid); findErr == nil {
+				tflog.Info(ctx, "Tenable VM user was renumbered", map[string]any{
+					"uuid":        uuid,
+					"old_user_id": id,
+					"user_id":     found.ID,
+				})
+				user, err, id = found, nil, found.ID
+				state.ID = types.StringValue(strconv.Itoa(id))
+			} else if !errors.Is(findErr, tenable.ErrNotFound) {
+				err = findErr
+			}
+		}
+	}
+	if err != nil && !errors.Is(err, tenable.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM user",
+			errorDetail(err),
+		)
+		return
+	}
 	if err != nil {
-		// If the user cannot be found (e.g. 404), remove from state
-		// Note: The client does not differentiate error types, so
-		// remove state for any API error to ensure recreation on next
-		// apply
+		// The user was deleted outside of Terraform; remove it from
+		// state so the next apply recreates it.
 		tflog.Info(ctx, "Tenable VM user not found during read", map[string]any{
 			"user_id": state.ID.ValueString(),
 			"error":   err.Error(),
@@ -270,8 +764,10 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 	// Update state with retrieved values
+	state.UUID = types.StringValue(user.UUID)
 	state.Username = types.StringValue(user.Username)
 	state.Permissions = types.Int64Value(int64(user.Permissions))
+	state.RoleName = roleNameValue(user.Permissions)
 	if user.Name != "" {
 		state.Name = types.StringValue(user.Name)
 	} else {
@@ -282,12 +778,33 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	} else {
 		state.Email = types.StringNull()
 	}
-	// Preserve account_type from existing state since API doesn't return it
+	// Record the account type so that a user switched to SAML or
+	// back outside Terraform shows up as drift.
+	state.AccountType = accountTypeValue(user, state.AccountType)
 	// Preserve password as null
 	state.Password = types.StringNull()
+	// Imported users start without generated keys
+	if state.GenerateAPIKeys.IsNull() {
+		state.GenerateAPIKeys = types.BoolValue(false)
+	}
+	if state.ForcePasswordChange.IsNull() {
+		state.ForcePasswordChange = types.BoolValue(false)
+	}
+	auth, err := r.client.GetUserAuthorizations(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Tenable VM user authorizations",
+			errorDetail(err),
+		)
+		return
+	}
+	setAuthorizations(&state, auth)
 	state.Enabled = types.BoolValue(user.Enabled)
+	setLoginActivity(&state, user)
+	state.RawJSON = rawJSONValue(user.RawJSON)
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setUserIdentity(ctx, resp.Identity, user)...)
 	// Log debug message after successful read
 	tflog.Debug(ctx, "Read Tenable VM user", map[string]any{
 		"user_id":  state.ID.ValueString(),
@@ -296,7 +813,8 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 // Update applies changes from the plan to the existing resource.  Only
-// permissions, name, email and enabled can be updated.  If no
+// permissions, name, email and enabled can be updated, and the
+// password is changed when password_wo_version changes.  If no
 // changes are detected, the method returns without calling the API.
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Read plan and state
@@ -307,6 +825,14 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	timeout, diags := plan.Timeouts.Update(ctx, defaultUserUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	state.Timeouts = plan.Timeouts
 	id, err := strconv.Atoi(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -360,8 +886,53 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		b := plan.Enabled.ValueBool()
 		enabled = &b
 	}
-	// If no updatable fields changed, return early
-	if perms == nil && name == nil && email == nil && enabled == nil {
+	// Password: the value itself is write-only, so a new version is
+	// the signal to send it.
+	var password *string
+	if !plan.PasswordWOVersion.IsUnknown() && !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		p, diags := configPassword(ctx, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if p == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing password",
+				"password_wo_version changed but no password is configured. Set password to the new value.",
+			)
+			return
+		}
+		password = &p
+	}
+	// API keys are generated when the flag is turned on; turning it
+	// off only forgets them, as the API cannot revoke keys on its own.
+	generateKeys := plan.GenerateAPIKeys.ValueBool() && !state.GenerateAPIKeys.ValueBool()
+	if !plan.GenerateAPIKeys.ValueBool() {
+		state.APIAccessKey = types.StringNull()
+		state.APISecretKey = types.StringNull()
+	}
+	state.GenerateAPIKeys = types.BoolValue(plan.GenerateAPIKeys.ValueBool())
+	// Authorizations: only configured flags are compared, the others
+	// keep whatever Tenable has.
+	authChanged := false
+	for _, pair := range [][2]types.Bool{
+		{plan.APIPermitted, state.APIPermitted},
+		{plan.PasswordPermitted, state.PasswordPermitted},
+		{plan.SAMLPermitted, state.SAMLPermitted},
+	} {
+		if !pair[0].IsNull() && !pair[0].IsUnknown() && !pair[0].Equal(pair[1]) {
+			authChanged = true
+		}
+	}
+	// A rotated password is temporary as well while
+	// force_password_change is on.
+	forceChange := plan.ForcePasswordChange.ValueBool()
+	setForceChange := forceChange != state.ForcePasswordChange.ValueBool() || (forceChange && password != nil)
+	state.ForcePasswordChange = types.BoolValue(forceChange)
+	// If no updatable fields changed, only the flag needs saving
+	if perms == nil && name == nil && email == nil && enabled == nil && password == nil && !generateKeys && !authChanged && !setForceChange {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
 	}
 	// Log debug message about which fields are being updated
@@ -372,29 +943,74 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		"name_changed":        name != nil,
 		"email_changed":       email != nil,
 		"enabled_changed":     enabled != nil,
+		"password_changed":    password != nil,
+		"force_change":        setForceChange,
+		"generate_api_keys":   generateKeys,
+		"auth_changed":        authChanged,
 	})
 
 	// Call API to update user
-	_, err = r.client.UpdateUser(id, perms, name, email, enabled)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating Tenable VM user",
-			err.Error(),
-		)
-		return
+	if perms != nil || name != nil || email != nil || enabled != nil {
+		if _, err := r.client.UpdateUser(ctx, id, perms, name, email, enabled); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Tenable VM user",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+	// Change the password in place; recreating the user would drop
+	// its group memberships and the objects it owns.
+	if password != nil {
+		if err := r.client.ChangeUserPassword(ctx, id, *password); err != nil {
+			resp.Diagnostics.AddError(
+				"Error changing Tenable VM user password",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+	if setForceChange {
+		if err := r.client.SetUserForcePasswordChange(ctx, id, forceChange); err != nil {
+			resp.Diagnostics.AddError(
+				"Error setting the password change requirement of Tenable VM user",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+	if generateKeys {
+		if err := r.generateAPIKeys(ctx, id, &state); err != nil {
+			resp.Diagnostics.AddError(
+				"Error generating API keys for Tenable VM user",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+	if authChanged {
+		if err := r.syncAuthorizations(ctx, id, plan, &state); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Tenable VM user authorizations",
+				errorDetail(err),
+			)
+			return
+		}
 	}
 	// Fetch latest user state
-	updatedUser, err := r.client.GetUser(id)
+	updatedUser, err := r.client.GetUser(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Tenable VM user after update",
-			err.Error(),
+			errorDetail(err),
 		)
 		return
 	}
 	// Update state fields
+	state.UUID = types.StringValue(updatedUser.UUID)
 	state.Username = types.StringValue(updatedUser.Username)
 	state.Permissions = types.Int64Value(int64(updatedUser.Permissions))
+	state.RoleName = roleNameValue(updatedUser.Permissions)
 	if updatedUser.Name != "" {
 		state.Name = types.StringValue(updatedUser.Name)
 	} else {
@@ -407,8 +1023,11 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 	// AccountType remains unchanged
 	state.Password = types.StringNull()
+	state.PasswordWOVersion = plan.PasswordWOVersion
 	state.Enabled = types.BoolValue(updatedUser.Enabled)
+	state.RawJSON = rawJSONValue(updatedUser.RawJSON)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setUserIdentity(ctx, resp.Identity, updatedUser)...)
 	// Log info after successful update
 	tflog.Info(ctx, "Updated Tenable VM user", map[string]any{
 		"user_id":  state.ID.ValueString(),
@@ -425,6 +1044,13 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	timeout, diags := state.Timeouts.Delete(ctx, defaultUserDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 	id, err := strconv.Atoi(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -439,10 +1065,11 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		"username": state.Username.ValueString(),
 	})
 	// Call API to delete user
-	if err := r.client.DeleteUser(id); err != nil {
+	// A user that is already gone has reached the desired state.
+	if err := r.client.DeleteUser(ctx, id); err != nil && !errors.Is(err, tenable.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error deleting Tenable VM user",
-			err.Error(),
+			errorDetail(err),
 		)
 		return
 	}
@@ -455,10 +1082,153 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	})
 }
 
+// generateAPIKeys generates a key pair for the user and records it in
+// state.
+func (r *userResource) generateAPIKeys(ctx context.Context, id int, state *userResourceModel) error {
+	keys, err := r.client.GenerateAPIKeys(ctx, id)
+	if err != nil {
+		return err
+	}
+	state.APIAccessKey = types.StringValue(keys.AccessKey)
+	state.APISecretKey = types.StringValue(keys.SecretKey)
+	return nil
+}
+
+// syncAuthorizations applies the authorization flags set in plan,
+// leaving unset ones as Tenable has them, and records the result in
+// state.  The API replaces all flags at once, so the current ones are
+// read first.
+func (r *userResource) syncAuthorizations(ctx context.Context, id int, plan userResourceModel, state *userResourceModel) error {
+	auth, err := r.client.GetUserAuthorizations(ctx, id)
+	if err != nil {
+		return err
+	}
+	desired := *auth
+	for _, flag := range []struct {
+		value types.Bool
+		dst   *bool
+	}{
+		{plan.APIPermitted, &desired.APIPermitted},
+		{plan.PasswordPermitted, &desired.PasswordPermitted},
+		{plan.SAMLPermitted, &desired.SAMLPermitted},
+	} {
+		if !flag.value.IsNull() && !flag.value.IsUnknown() {
+			*flag.dst = flag.value.ValueBool()
+		}
+	}
+	if desired != *auth {
+		if err := r.client.UpdateUserAuthorizations(ctx, id, desired); err != nil {
+			return err
+		}
+	}
+	setAuthorizations(state, &desired)
+	return nil
+}
+
+// setAuthorizations records the authorization flags in state.
+func setAuthorizations(state *userResourceModel, auth *tenable.UserAuthorizations) {
+	state.APIPermitted = types.BoolValue(auth.APIPermitted)
+	state.PasswordPermitted = types.BoolValue(auth.PasswordPermitted)
+	state.SAMLPermitted = types.BoolValue(auth.SAMLPermitted)
+}
+
+// accountTypeValue returns the account type of user.  Deployments
+// that leave the type out of user records keep fallback, the value
+// Terraform already knows.
+func accountTypeValue(user *tenable.User, fallback types.String) types.String {
+	if user.Type == "" {
+		return fallback
+	}
+	return types.StringValue(strings.ToLower(user.Type))
+}
+
+// setLoginActivity records the login telemetry of user in state.  The
+// values change whenever the user logs in, so they are only refreshed
+// by Create and Read; Update keeps the planned prior values.
+func setLoginActivity(state *userResourceModel, user *tenable.User) {
+	state.LastLogin = lastLoginValue(user.LastLogin)
+	state.LoginFailCount = types.Int64Value(int64(user.LoginFailCount))
+	state.Lockout = types.BoolValue(user.Lockout != 0)
+}
+
+// lastLoginValue converts a last login time in milliseconds since the
+// Unix epoch to RFC 3339, or null for users who never logged in.
+func lastLoginValue(ms int64) types.String {
+	if ms == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(time.UnixMilli(ms).UTC().Format(time.RFC3339))
+}
+
+// apiKeyPlanModifier plans the generated API keys.  The keys keep
+// their state value while generate_api_keys stays on, become null when
+// it is off and are left unknown when it is turned on, which is when
+// Update generates them.
+type apiKeyPlanModifier struct{}
+
+func (m apiKeyPlanModifier) Description(_ context.Context) string {
+	return "Keeps the generated API key unless generate_api_keys is turned on or off."
+}
+
+func (m apiKeyPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m apiKeyPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to keep on create, and nothing to plan on destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var generate, generated types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("generate_api_keys"), &generate)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("generate_api_keys"), &generated)...)
+	if resp.Diagnostics.HasError() || generate.IsUnknown() {
+		return
+	}
+	switch {
+	case !generate.ValueBool():
+		resp.PlanValue = types.StringNull()
+	case generated.ValueBool():
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// roleNameValue returns the role name of a permissions value, or null
+// for values that do not correspond to a known role.
+func roleNameValue(permissions int) types.String {
+	if name, ok := roleForPermission(int64(permissions)); ok {
+		return types.StringValue(name)
+	}
+	return types.StringNull()
+}
+
+// configPassword returns the write-only password from the
+// configuration, or an empty string when none is set.
+func configPassword(ctx context.Context, config tfsdk.Config) (string, diag.Diagnostics) {
+	var password types.String
+	diags := config.GetAttribute(ctx, path.Root("password"), &password)
+	return password.ValueString(), diags
+}
+
 // ImportState enables users to import existing Tenable VM users into
-// Terraform state.  The import ID should be the numeric user ID.
-// Only the ID attribute is set; other attributes will be populated
-// during the subsequent Read operation.
+// Terraform state.  The import ID is the numeric user ID or the UUID;
+// import blocks may give the UUID identity instead.  Only the ID
+// attribute is set; other attributes will be populated during the
+// subsequent Read operation.
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		uuid, diags := identityUUID(ctx, req.Identity)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.importUserByUUID(ctx, uuid, resp)
+		return
+	}
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		// Not a numeric ID, so it is taken to be the UUID.
+		r.importUserByUUID(ctx, req.ID, resp)
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
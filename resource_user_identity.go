@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+var _ resource.ResourceWithIdentity = &userResource{}
+
+// userIdentityModel is the identity of a tenablevm_user.  The numeric
+// ID is only unique within a container and Tenable may reassign it,
+// for example when a container is migrated, whereas the UUID stays
+// with the user.  Terraform 1.12 and later track the user by it and
+// accept it in import blocks.
+type userIdentityModel struct {
+	UUID types.String `tfsdk:"uuid"`
+}
+
+// IdentitySchema defines the identity of the user resource.
+func (r *userResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UUID of the Tenable VM user.",
+			},
+		},
+	}
+}
+
+// setUserIdentity records the identity of user.  identity is nil when
+// Terraform does not support resource identity.
+func setUserIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, user *tenable.User) diag.Diagnostics {
+	if identity == nil || user.UUID == "" {
+		return nil
+	}
+	return identity.Set(ctx, userIdentityModel{UUID: types.StringValue(user.UUID)})
+}
+
+// identityUUID returns the UUID held by identity, or an empty string
+// when there is none yet, such as for state written before identity
+// support.
+func identityUUID(ctx context.Context, identity *tfsdk.ResourceIdentity) (string, diag.Diagnostics) {
+	if identity == nil || identity.Raw.IsNull() {
+		return "", nil
+	}
+	var model userIdentityModel
+	diags := identity.Get(ctx, &model)
+	return model.UUID.ValueString(), diags
+}
+
+// findUserByUUID looks the user with the given UUID up in the user
+// list, because the API only reads single users by ID.  It fails with
+// an error wrapping tenable.ErrNotFound when there is no such user.
+func (r *userResource) findUserByUUID(ctx context.Context, uuid string) (*tenable.User, error) {
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.UUID == uuid {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("no user with UUID %s: %w", uuid, tenable.ErrNotFound)
+}
+
+// importUserByUUID imports the user with the given UUID, taken from
+// the import ID or the uuid of an import block's identity.  It
+// resolves the UUID to the numeric ID, which the rest of the resource
+// works with.
+func (r *userResource) importUserByUUID(ctx context.Context, uuid string, resp *resource.ImportStateResponse) {
+	user, err := r.findUserByUUID(ctx, uuid)
+	if err != nil {
+		if errors.Is(err, tenable.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("uuid"),
+				"Tenable VM user not found",
+				fmt.Sprintf("No Tenable VM user has the UUID %q.", uuid),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error looking up Tenable VM user",
+			errorDetail(err),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Resolved Tenable VM user identity", map[string]any{
+		"uuid":    uuid,
+		"user_id": user.ID,
+	})
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(user.ID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), user.UUID)...)
+	resp.Diagnostics.Append(setUserIdentity(ctx, resp.Identity, user)...)
+}
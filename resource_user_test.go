@@ -0,0 +1,996 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"tenablevm_provider_framework/internal/tenable"
+)
+
+func buildResourcePlan(ctx context.Context, sch schema.Schema, attrs map[string]tftypes.Value) tfsdk.Plan {
+	// The schema type covers blocks such as timeouts as well as
+	// attributes.
+	objType := sch.Type().TerraformType(ctx).(tftypes.Object)
+	vals := make(map[string]tftypes.Value)
+	for name, typ := range objType.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+	raw := tftypes.NewValue(objType, vals)
+	return tfsdk.Plan{Schema: sch, Raw: raw}
+}
+
+// configOf returns the configuration matching plan, which is where
+// the resource finds write-only values such as the password.
+func configOf(plan tfsdk.Plan) tfsdk.Config {
+	return tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+}
+
+// TestUserResourceCreateDuplicateUsername checks that check_usernames
+// reports an existing username with an import hint before creating,
+// and that users are created without a list call otherwise.
+func TestUserResourceCreateDuplicateUsername(t *testing.T) {
+	ctx := context.Background()
+
+	list := []map[string]interface{}{
+		{"id": 7, "uuid": "uuid-7", "username": "alice@example.com"},
+	}
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet || r.URL.Path != "/users" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Duplicate username"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer ts.Close()
+
+	res := &userResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"username":     tftypes.NewValue(tftypes.String, "Alice@example.com"),
+		"password":     tftypes.NewValue(tftypes.String, "initialPassword123!"),
+		"permissions":  tftypes.NewValue(tftypes.Number, 16),
+		"account_type": tftypes.NewValue(tftypes.String, "local"),
+		"enabled":      tftypes.NewValue(tftypes.Bool, true),
+	})
+	req := resource.CreateRequest{Config: configOf(plan), Plan: plan}
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	// Without check_usernames the user is created right away and the
+	// API has the final word.
+	resp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() || len(requests) != 1 || requests[0] != "POST /users" {
+		t.Errorf("expected a single create request, got %v", requests)
+	}
+
+	requests = nil
+	res.checkUsernames = true
+	resp = resource.CreateResponse{State: emptyState}
+	res.Create(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected duplicate username diagnostic")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "terraform import tenablevm_user.<name> 7") {
+		t.Errorf("diagnostic detail does not suggest import: %s", detail)
+	}
+	if len(requests) != 1 || requests[0] != "GET /users" {
+		t.Errorf("expected only the user list to be requested, got %v", requests)
+	}
+}
+
+func TestUserResourceReadErrors(t *testing.T) {
+	ctx := context.Background()
+
+	status := http.StatusNotFound
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	res := &userResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	state := tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "7"),
+		"username": tftypes.NewValue(tftypes.String, "alice@example.com"),
+	}).Raw}
+
+	resp := resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+		t.Errorf("expected a deleted user to be removed from state, got %v", resp.Diagnostics)
+	}
+
+	// Any other failure, including transient ones, must not drop the
+	// user from state.
+	for _, status = range []int{http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		resp = resource.ReadResponse{State: state}
+		res.Read(ctx, resource.ReadRequest{State: state}, &resp)
+		if !resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+			t.Errorf("expected an error and the state to be kept for a %d", status)
+		}
+	}
+}
+
+// TestUserResourceLifecycle runs create, read, update and delete
+// against the in-memory fake.
+func TestUserResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"username":     tftypes.NewValue(tftypes.String, "alice@example.com"),
+		"password":     tftypes.NewValue(tftypes.String, "initialPassword123!"),
+		"permissions":  tftypes.NewValue(tftypes.Number, 16),
+		"name":         tftypes.NewValue(tftypes.String, "Alice"),
+		"account_type": tftypes.NewValue(tftypes.String, "local"),
+		"enabled":      tftypes.NewValue(tftypes.Bool, true),
+	})
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state userResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1" || !state.Password.IsNull() {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+	if fake.passwords[1] != "initialPassword123!" {
+		t.Errorf("password from the configuration not sent on create: %q", fake.passwords[1])
+	}
+
+	update := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "1"),
+		"username":     tftypes.NewValue(tftypes.String, "alice@example.com"),
+		"permissions":  tftypes.NewValue(tftypes.Number, 40),
+		"name":         tftypes.NewValue(tftypes.String, "Alice"),
+		"account_type": tftypes.NewValue(tftypes.String, "local"),
+		"enabled":      tftypes.NewValue(tftypes.Bool, false),
+	})
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(update), Plan: update, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if u := fake.users[1]; u.Permissions != 40 || u.Enabled {
+		t.Errorf("user not updated: %+v", u)
+	}
+
+	// Logins happen outside Terraform and show up on the next read.
+	fake.users[1].LastLogin = 1700000000000
+	fake.users[1].LoginFailCount = 2
+	readResp := resource.ReadResponse{State: updateResp.State}
+	res.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if state.Permissions.ValueInt64() != 40 || state.Enabled.ValueBool() {
+		t.Errorf("unexpected state after read: %+v", state)
+	}
+	if state.LastLogin.ValueString() != "2023-11-14T22:13:20Z" || state.LoginFailCount.ValueInt64() != 2 || state.Lockout.ValueBool() {
+		t.Errorf("unexpected login activity after read: %s, %s, %s", state.LastLogin, state.LoginFailCount, state.Lockout)
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	res.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.users) != 0 {
+		t.Errorf("user not deleted: %v", fake.users)
+	}
+}
+
+// TestUserResourceUpdatePassword checks that bumping
+// password_wo_version changes the password in place and that the
+// version is required to be accompanied by a password.
+func TestUserResourceUpdatePassword(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable(&tenable.User{ID: 3, Username: "bob@example.com", Permissions: 16, Enabled: true})
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	attrs := func(version int, password string) map[string]tftypes.Value {
+		v := map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "3"),
+			"username":            tftypes.NewValue(tftypes.String, "bob@example.com"),
+			"password_wo_version": tftypes.NewValue(tftypes.Number, version),
+			"permissions":         tftypes.NewValue(tftypes.Number, 16),
+			"account_type":        tftypes.NewValue(tftypes.String, "local"),
+			"enabled":             tftypes.NewValue(tftypes.Bool, true),
+		}
+		if password != "" {
+			v["password"] = tftypes.NewValue(tftypes.String, password)
+		}
+		return v
+	}
+	state := tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, attrs(1, "")).Raw}
+
+	// An unchanged version leaves the password alone.
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs(1, ""))
+	config := configOf(buildResourcePlan(ctx, schResp.Schema, attrs(1, "rotated-Secret1")))
+	resp := resource.UpdateResponse{State: state}
+	res.Update(ctx, resource.UpdateRequest{Config: config, Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+	if _, ok := fake.passwords[3]; ok {
+		t.Errorf("password changed without a new version")
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs(2, ""))
+	config = configOf(buildResourcePlan(ctx, schResp.Schema, attrs(2, "rotated-Secret1")))
+	resp = resource.UpdateResponse{State: state}
+	res.Update(ctx, resource.UpdateRequest{Config: config, Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+	if fake.passwords[3] != "rotated-Secret1" || fake.users[3] == nil {
+		t.Errorf("password not changed in place: %q", fake.passwords[3])
+	}
+	var got userResourceModel
+	resp.State.Get(ctx, &got)
+	if got.PasswordWOVersion.ValueInt64() != 2 || !got.Password.IsNull() {
+		t.Errorf("unexpected state after password change: %+v", got)
+	}
+
+	// A new version without a password is a configuration error.
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs(3, ""))
+	resp = resource.UpdateResponse{State: state}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: state}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error for a new version without a password")
+	}
+}
+
+// TestUserResourceGenerateAPIKeys checks that keys are generated on
+// create, kept while the flag stays on and forgotten when it is
+// turned off.
+func TestUserResourceGenerateAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	attrs := func(generate bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"username":          tftypes.NewValue(tftypes.String, "svc-scanner@example.com"),
+			"password":          tftypes.NewValue(tftypes.String, "initialPassword123!"),
+			"permissions":       tftypes.NewValue(tftypes.Number, 32),
+			"account_type":      tftypes.NewValue(tftypes.String, "local"),
+			"enabled":           tftypes.NewValue(tftypes.Bool, true),
+			"generate_api_keys": tftypes.NewValue(tftypes.Bool, generate),
+		}
+	}
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs(true))
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state userResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.APIAccessKey.ValueString() != "access-1" || state.APISecretKey.ValueString() != "secret-1" {
+		t.Fatalf("keys not generated on create: %+v", state)
+	}
+
+	// Keys survive an unrelated update without being regenerated.
+	update := attrs(true)
+	update["id"] = tftypes.NewValue(tftypes.String, "1")
+	update["name"] = tftypes.NewValue(tftypes.String, "Scanner service")
+	plan = buildResourcePlan(ctx, schResp.Schema, update)
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &state)
+	if state.APIAccessKey.ValueString() != "access-1" || fake.keySerial != 1 {
+		t.Errorf("keys regenerated on an unrelated update: %+v", state)
+	}
+
+	update["generate_api_keys"] = tftypes.NewValue(tftypes.Bool, false)
+	plan = buildResourcePlan(ctx, schResp.Schema, update)
+	offResp := resource.UpdateResponse{State: updateResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: updateResp.State}, &offResp)
+	if offResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", offResp.Diagnostics)
+	}
+	offResp.State.Get(ctx, &state)
+	if !state.APIAccessKey.IsNull() || !state.APISecretKey.IsNull() || state.GenerateAPIKeys.ValueBool() {
+		t.Errorf("keys kept after turning generate_api_keys off: %+v", state)
+	}
+
+	update["generate_api_keys"] = tftypes.NewValue(tftypes.Bool, true)
+	plan = buildResourcePlan(ctx, schResp.Schema, update)
+	onResp := resource.UpdateResponse{State: offResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: offResp.State}, &onResp)
+	if onResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", onResp.Diagnostics)
+	}
+	onResp.State.Get(ctx, &state)
+	if state.APIAccessKey.ValueString() != "access-2" || state.APISecretKey.ValueString() != "secret-2" {
+		t.Errorf("keys not generated when turning generate_api_keys on: %+v", state)
+	}
+}
+
+// TestUserResourceAuthorizations checks that configured authorization
+// flags are applied, unset ones are read back from Tenable, and
+// changes made outside Terraform show up on refresh.
+func TestUserResourceAuthorizations(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	attrs := map[string]tftypes.Value{
+		"username":           tftypes.NewValue(tftypes.String, "sso-only@example.com"),
+		"permissions":        tftypes.NewValue(tftypes.Number, 16),
+		"account_type":       tftypes.NewValue(tftypes.String, "saml"),
+		"enabled":            tftypes.NewValue(tftypes.Bool, true),
+		"generate_api_keys":  tftypes.NewValue(tftypes.Bool, false),
+		"password_permitted": tftypes.NewValue(tftypes.Bool, false),
+		"api_permitted":      tftypes.NewValue(tftypes.Bool, false),
+	}
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs)
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	want := tenable.UserAuthorizations{SAMLPermitted: true}
+	if fake.auths[1] != want {
+		t.Errorf("authorizations not applied: %+v", fake.auths[1])
+	}
+	var state userResourceModel
+	createResp.State.Get(ctx, &state)
+	if !state.SAMLPermitted.ValueBool() || state.PasswordPermitted.ValueBool() {
+		t.Errorf("unexpected state after create: %+v", state)
+	}
+
+	// A change made in the Tenable UI is detected on refresh.
+	fake.auths[1] = tenable.UserAuthorizations{PasswordPermitted: true, SAMLPermitted: true}
+	readResp := resource.ReadResponse{State: createResp.State}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &state)
+	if !state.PasswordPermitted.ValueBool() {
+		t.Errorf("drift not detected: %+v", state)
+	}
+
+	attrs["id"] = tftypes.NewValue(tftypes.String, "1")
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs)
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if fake.auths[1] != want {
+		t.Errorf("drift not corrected: %+v", fake.auths[1])
+	}
+}
+
+// TestUserResourceRoleName checks that exactly one of permissions and
+// role_name is accepted and that the other is derived in the plan.
+func TestUserResourceRoleName(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	base := func(extra map[string]tftypes.Value) map[string]tftypes.Value {
+		attrs := map[string]tftypes.Value{
+			"username":     tftypes.NewValue(tftypes.String, "carol@example.com"),
+			"password":     tftypes.NewValue(tftypes.String, "initialPassword123!"),
+			"account_type": tftypes.NewValue(tftypes.String, "local"),
+			"enabled":      tftypes.NewValue(tftypes.Bool, true),
+		}
+		for k, v := range extra {
+			attrs[k] = v
+		}
+		return attrs
+	}
+	for name, tc := range map[string]struct {
+		attrs map[string]tftypes.Value
+		valid bool
+	}{
+		"permissions": {map[string]tftypes.Value{"permissions": tftypes.NewValue(tftypes.Number, 40)}, true},
+		"role_name":   {map[string]tftypes.Value{"role_name": tftypes.NewValue(tftypes.String, "scan_manager")}, true},
+		"neither":     {nil, false},
+		"both": {map[string]tftypes.Value{
+			"permissions": tftypes.NewValue(tftypes.Number, 40),
+			"role_name":   tftypes.NewValue(tftypes.String, "scan_manager"),
+		}, false},
+	} {
+		config := configOf(buildResourcePlan(ctx, schResp.Schema, base(tc.attrs)))
+		var resp resource.ValidateConfigResponse
+		res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", name, tc.valid, resp.Diagnostics)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		attrs map[string]tftypes.Value
+	}{
+		"from role_name":   {map[string]tftypes.Value{"role_name": tftypes.NewValue(tftypes.String, "scan_manager")}},
+		"from permissions": {map[string]tftypes.Value{"permissions": tftypes.NewValue(tftypes.Number, 40)}},
+	} {
+		plan := buildResourcePlan(ctx, schResp.Schema, base(tc.attrs))
+		planResp := resource.ModifyPlanResponse{Plan: plan}
+		res.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configOf(plan), Plan: plan}, &planResp)
+		if planResp.Diagnostics.HasError() {
+			t.Fatalf("%s: ModifyPlan: %v", name, planResp.Diagnostics)
+		}
+		var planned userResourceModel
+		planResp.Plan.Get(ctx, &planned)
+		if planned.Permissions.ValueInt64() != 40 || planned.RoleName.ValueString() != "scan_manager" {
+			t.Errorf("%s: unexpected plan: %+v", name, planned)
+		}
+	}
+
+	// The derived permissions are what gets sent on create.
+	config := buildResourcePlan(ctx, schResp.Schema, base(map[string]tftypes.Value{"role_name": tftypes.NewValue(tftypes.String, "standard")}))
+	planResp := resource.ModifyPlanResponse{Plan: config}
+	res.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configOf(config), Plan: config}, &planResp)
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(config), Plan: planResp.Plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if u := fake.users[1]; u.Permissions != 32 {
+		t.Errorf("role_name not translated on create: %+v", u)
+	}
+	var state userResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.Permissions.ValueInt64() != 32 || state.RoleName.ValueString() != "standard" {
+		t.Errorf("unexpected state after create: %+v", state)
+	}
+}
+
+// TestUserResourceAccountType checks that SAML users are created
+// without a password and reject one in configuration, while local
+// users require it.
+func TestUserResourceAccountType(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	attrs := func(accountType, password string) map[string]tftypes.Value {
+		v := map[string]tftypes.Value{
+			"username":     tftypes.NewValue(tftypes.String, "dave@example.com"),
+			"permissions":  tftypes.NewValue(tftypes.Number, 16),
+			"account_type": tftypes.NewValue(tftypes.String, accountType),
+			"enabled":      tftypes.NewValue(tftypes.Bool, true),
+		}
+		if password != "" {
+			v["password"] = tftypes.NewValue(tftypes.String, password)
+		}
+		return v
+	}
+
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(buildResourcePlan(ctx, schResp.Schema, attrs("saml", "secret-Pass1")))}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Errorf("expected an error for a password on a SAML user")
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs("local", ""))
+	createResp := resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if !createResp.Diagnostics.HasError() || len(fake.users) != 0 {
+		t.Errorf("expected a local user without password to be rejected")
+	}
+
+	plan = buildResourcePlan(ctx, schResp.Schema, attrs("saml", ""))
+	createResp = resource.CreateResponse{State: emptyState}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state userResourceModel
+	createResp.State.Get(ctx, &state)
+	if state.AccountType.ValueString() != "saml" || fake.passwords[1] != "" {
+		t.Errorf("unexpected SAML user: %+v, password %q", state, fake.passwords[1])
+	}
+}
+
+// TestUserResourceLockoutWarnings checks the plan-time warnings for
+// demoting the last administrator and disabling the provider's own
+// account.
+func TestUserResourceLockoutWarnings(t *testing.T) {
+	ctx := context.Background()
+	var schResp resource.SchemaResponse
+	(&userResource{}).Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	attrs := func(permissions int, enabled bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":           tftypes.NewValue(tftypes.String, "1"),
+			"username":     tftypes.NewValue(tftypes.String, "admin@example.com"),
+			"permissions":  tftypes.NewValue(tftypes.Number, permissions),
+			"account_type": tftypes.NewValue(tftypes.String, "local"),
+			"enabled":      tftypes.NewValue(tftypes.Bool, enabled),
+		}
+	}
+	plan := func(fake *fakeTenable, permissions int, enabled bool) []string {
+		res := &userResource{client: fake}
+		state := tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, attrs(64, true)).Raw}
+		p := buildResourcePlan(ctx, schResp.Schema, attrs(permissions, enabled))
+		resp := resource.ModifyPlanResponse{Plan: p}
+		res.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configOf(p), Plan: p, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("ModifyPlan: %v", resp.Diagnostics)
+		}
+		var summaries []string
+		for _, d := range resp.Diagnostics.Warnings() {
+			summaries = append(summaries, d.Summary())
+		}
+		return summaries
+	}
+
+	admin := func() *tenable.User { return &tenable.User{ID: 1, Permissions: 64, Enabled: true} }
+	fake := newFakeTenable(admin(), &tenable.User{ID: 2, Permissions: 32, Enabled: true})
+	fake.currentID = 2
+	if got := plan(fake, 32, true); len(got) != 1 || got[0] != "Last administrator is being demoted or disabled" {
+		t.Errorf("demoting the last administrator: %v", got)
+	}
+
+	fake = newFakeTenable(admin(), &tenable.User{ID: 2, Permissions: 64, Enabled: true})
+	fake.currentID = 1
+	if got := plan(fake, 64, false); len(got) != 1 || got[0] != "Change affects the provider's own account" {
+		t.Errorf("disabling the provider's account: %v", got)
+	}
+
+	fake.currentID = 2
+	if got := plan(fake, 40, true); len(got) != 0 {
+		t.Errorf("unexpected warnings with another administrator: %v", got)
+	}
+	if got := plan(fake, 64, true); len(got) != 0 {
+		t.Errorf("unexpected warnings without a risky change: %v", got)
+	}
+}
+
+// TestUserResourceUpgradeStateV0 checks that state written by schema
+// version 0 is carried over with the newer attributes filled in.
+func TestUserResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	res := &userResource{}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	upgrader, ok := res.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("no upgrader for version 0")
+	}
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: buildResourcePlan(ctx, *upgrader.PriorSchema, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "5"),
+		"username":     tftypes.NewValue(tftypes.String, "erin@example.com"),
+		"permissions":  tftypes.NewValue(tftypes.Number, 40),
+		"email":        tftypes.NewValue(tftypes.String, "erin@example.com"),
+		"account_type": tftypes.NewValue(tftypes.String, "local"),
+		"enabled":      tftypes.NewValue(tftypes.Bool, true),
+	}).Raw}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState: %v", resp.Diagnostics)
+	}
+	var state userResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "5" || state.Permissions.ValueInt64() != 40 || state.RoleName.ValueString() != "scan_manager" ||
+		state.Email.ValueString() != "erin@example.com" || state.GenerateAPIKeys.ValueBool() || !state.APIAccessKey.IsNull() {
+		t.Errorf("unexpected upgraded state: %+v", state)
+	}
+}
+
+// TestUserResourceTimeouts checks that the timeouts block bounds the
+// client calls of an operation.
+func TestUserResourceTimeouts(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable(&tenable.User{ID: 2, Username: "frank@example.com", Permissions: 16, Enabled: true})
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	timeoutsType := schResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["timeouts"]
+	stateWithRead := func(read string) tfsdk.State {
+		return tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+			"id":       tftypes.NewValue(tftypes.String, "2"),
+			"username": tftypes.NewValue(tftypes.String, "frank@example.com"),
+			"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, nil),
+				"read":   tftypes.NewValue(tftypes.String, read),
+				"update": tftypes.NewValue(tftypes.String, nil),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}).Raw}
+	}
+
+	state := stateWithRead("1ns")
+	resp := resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if !resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		t.Fatalf("expected an expired read timeout to fail and keep the state, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, context.DeadlineExceeded.Error()) {
+		t.Errorf("diagnostic does not mention the deadline: %s", detail)
+	}
+
+	state = stateWithRead("2m")
+	resp = resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	var got userResourceModel
+	resp.State.Get(ctx, &got)
+	if got.Timeouts.IsNull() {
+		t.Error("the timeouts block was not kept in state")
+	}
+}
+
+// TestUserResourceIdentity checks that the UUID identity is recorded,
+// follows a renumbered user and imports a user.
+func TestUserResourceIdentity(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	var idResp resource.IdentitySchemaResponse
+	res.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &idResp)
+	identityType := idResp.IdentitySchema.Type().TerraformType(ctx)
+	newIdentity := func(uuid interface{}) *tfsdk.ResourceIdentity {
+		raw := tftypes.NewValue(identityType, nil)
+		if uuid != nil {
+			raw = tftypes.NewValue(identityType, map[string]tftypes.Value{"uuid": tftypes.NewValue(tftypes.String, uuid)})
+		}
+		return &tfsdk.ResourceIdentity{Schema: idResp.IdentitySchema, Raw: raw}
+	}
+	identityOf := func(identity *tfsdk.ResourceIdentity) string {
+		uuid, diags := identityUUID(ctx, identity)
+		if diags.HasError() {
+			t.Fatalf("identity: %v", diags)
+		}
+		return uuid
+	}
+
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"username":    tftypes.NewValue(tftypes.String, "grace@example.com"),
+		"password":    tftypes.NewValue(tftypes.String, "initialPassword123!"),
+		"permissions": tftypes.NewValue(tftypes.Number, 16),
+	})
+	createResp := resource.CreateResponse{
+		State:    tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)},
+		Identity: newIdentity(nil),
+	}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan, Identity: newIdentity(nil)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if got := identityOf(createResp.Identity); got != "uuid-1" {
+		t.Fatalf("identity after create = %q, want uuid-1", got)
+	}
+
+	// Renumber the user; Read finds it again by its UUID.
+	u := fake.users[1]
+	delete(fake.users, 1)
+	u.ID = 9
+	fake.users[9] = u
+	readResp := resource.ReadResponse{State: createResp.State, Identity: createResp.Identity}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State, Identity: createResp.Identity}, &readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var state userResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "9" {
+		t.Errorf("ID after renumbering = %s, want 9", state.ID)
+	}
+
+	importResp := resource.ImportStateResponse{
+		State:    tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)},
+		Identity: newIdentity("uuid-1"),
+	}
+	res.ImportState(ctx, resource.ImportStateRequest{Identity: newIdentity("uuid-1")}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	importResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "9" || identityOf(importResp.Identity) != "uuid-1" {
+		t.Errorf("unexpected import: ID %s, identity %q", state.ID, identityOf(importResp.Identity))
+	}
+
+	importResp.Identity = newIdentity("uuid-unknown")
+	importResp.Diagnostics = nil
+	res.ImportState(ctx, resource.ImportStateRequest{Identity: newIdentity("uuid-unknown")}, &importResp)
+	if !importResp.Diagnostics.HasError() {
+		t.Error("expected importing an unknown UUID to fail")
+	}
+}
+
+// TestUserResourceImportByUUID checks that the import ID may be the
+// UUID as well as the numeric ID.
+func TestUserResourceImportByUUID(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable(&tenable.User{ID: 4, UUID: "0b6a7c1e-uuid", Username: "heidi@example.com", Permissions: 16, Enabled: true})
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	for _, id := range []string{"4", "0b6a7c1e-uuid"} {
+		importResp := resource.ImportStateResponse{State: emptyState}
+		res.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+		if importResp.Diagnostics.HasError() {
+			t.Fatalf("ImportState(%q): %v", id, importResp.Diagnostics)
+		}
+		readResp := resource.ReadResponse{State: importResp.State}
+		res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("Read after importing %q: %v", id, readResp.Diagnostics)
+		}
+		var state userResourceModel
+		readResp.State.Get(ctx, &state)
+		if state.ID.ValueString() != "4" || state.UUID.ValueString() != "0b6a7c1e-uuid" {
+			t.Errorf("import of %q: ID %s, UUID %s", id, state.ID, state.UUID)
+		}
+	}
+
+	importResp := resource.ImportStateResponse{State: emptyState}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "no-such-uuid"}, &importResp)
+	if !importResp.Diagnostics.HasError() {
+		t.Error("expected importing an unknown UUID to fail")
+	}
+}
+
+// TestUserResourceCreatePartialFailure checks that a user whose
+// creation fails after the user was created is saved to state, so
+// that the next apply replaces it instead of creating a duplicate.
+func TestUserResourceCreatePartialFailure(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	fake.enableErr = &tenable.APIError{StatusCode: http.StatusForbidden, Status: "403 Forbidden", Path: "/users/1/enabled"}
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	unknown := func(typ tftypes.Type) tftypes.Value { return tftypes.NewValue(typ, tftypes.UnknownValue) }
+	plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":               unknown(tftypes.String),
+		"uuid":             unknown(tftypes.String),
+		"username":         tftypes.NewValue(tftypes.String, "ivan@example.com"),
+		"password":         tftypes.NewValue(tftypes.String, "initialPassword123!"),
+		"permissions":      tftypes.NewValue(tftypes.Number, 16),
+		"enabled":          tftypes.NewValue(tftypes.Bool, false),
+		"api_access_key":   unknown(tftypes.String),
+		"api_permitted":    unknown(tftypes.Bool),
+		"last_login":       unknown(tftypes.String),
+		"login_fail_count": unknown(tftypes.Number),
+		"lockout":          unknown(tftypes.Bool),
+	})
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when disabling the new user fails")
+	}
+	if !resp.State.Raw.IsFullyKnown() {
+		t.Errorf("state holds unknown values: %s", resp.State.Raw)
+	}
+	var state userResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "1" || state.UUID.ValueString() != "uuid-1" || !state.Enabled.ValueBool() {
+		t.Errorf("expected the created user in state, got %+v", state)
+	}
+}
+
+// TestUserResourceReadDrift checks that Read records the account type
+// and authorizations from Tenable, so that changes made outside
+// Terraform show up as drift.
+func TestUserResourceReadDrift(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable(&tenable.User{ID: 6, UUID: "uuid-6", Username: "judy@example.com", Permissions: 16, Enabled: true, Type: "local"})
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	state := tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, "6"),
+		"username":           tftypes.NewValue(tftypes.String, "judy@example.com"),
+		"account_type":       tftypes.NewValue(tftypes.String, "local"),
+		"password_permitted": tftypes.NewValue(tftypes.Bool, true),
+	}).Raw}
+
+	// Someone switches the user to SAML in the UI.
+	fake.users[6].Type = "SAML"
+	fake.auths[6] = tenable.UserAuthorizations{APIPermitted: true, SAMLPermitted: true}
+
+	resp := resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	var got userResourceModel
+	resp.State.Get(ctx, &got)
+	if got.AccountType.ValueString() != "saml" || got.PasswordPermitted.ValueBool() || !got.SAMLPermitted.ValueBool() {
+		t.Errorf("drift not recorded: account_type %s, password_permitted %s, saml_permitted %s", got.AccountType, got.PasswordPermitted, got.SAMLPermitted)
+	}
+}
+
+// TestUserResourceForcePasswordChange checks that the password change
+// requirement is set on create, on a rotated password and when the
+// flag changes.
+func TestUserResourceForcePasswordChange(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable()
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+
+	attrs := func(version int, force bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"username":              tftypes.NewValue(tftypes.String, "kim@example.com"),
+			"password":              tftypes.NewValue(tftypes.String, "temporary-Secret1"),
+			"password_wo_version":   tftypes.NewValue(tftypes.Number, version),
+			"permissions":           tftypes.NewValue(tftypes.Number, 16),
+			"account_type":          tftypes.NewValue(tftypes.String, "local"),
+			"enabled":               tftypes.NewValue(tftypes.Bool, true),
+			"force_password_change": tftypes.NewValue(tftypes.Bool, force),
+		}
+	}
+	plan := buildResourcePlan(ctx, schResp.Schema, attrs(1, true))
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}}
+	res.Create(ctx, resource.CreateRequest{Config: configOf(plan), Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	if !fake.forceChange[1] {
+		t.Fatal("password change not required after create")
+	}
+
+	update := func(state tfsdk.State, version int, force bool) tfsdk.State {
+		t.Helper()
+		v := attrs(version, force)
+		v["id"] = tftypes.NewValue(tftypes.String, "1")
+		plan := buildResourcePlan(ctx, schResp.Schema, v)
+		resp := resource.UpdateResponse{State: state}
+		res.Update(ctx, resource.UpdateRequest{Config: configOf(plan), Plan: plan, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Update: %v", resp.Diagnostics)
+		}
+		return resp.State
+	}
+
+	// The user changed the password, which clears the flag; a rotated
+	// temporary password requires a change again.
+	fake.forceChange[1] = false
+	state := update(createResp.State, 2, true)
+	if !fake.forceChange[1] {
+		t.Error("password change not required after rotation")
+	}
+	update(state, 2, false)
+	if fake.forceChange[1] {
+		t.Error("password change still required after turning the flag off")
+	}
+
+	// SAML users have no password to change.
+	saml := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"username":              tftypes.NewValue(tftypes.String, "sso@example.com"),
+		"permissions":           tftypes.NewValue(tftypes.Number, 16),
+		"account_type":          tftypes.NewValue(tftypes.String, "saml"),
+		"force_password_change": tftypes.NewValue(tftypes.Bool, true),
+	})
+	var validateResp resource.ValidateConfigResponse
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: configOf(saml)}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected force_password_change to be rejected for a SAML user")
+	}
+}
+
+// TestUserResourceCheckUsernames checks that the opt-in plan-time
+// check rejects a new user whose username is taken.
+func TestUserResourceCheckUsernames(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTenable(&tenable.User{ID: 8, Username: "Mallory@example.com", Permissions: 16, Enabled: true})
+	res := &userResource{client: fake}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	emptyState := tfsdk.State{Schema: schResp.Schema, Raw: tftypes.NewValue(schResp.Schema.Type().TerraformType(ctx), nil)}
+
+	modifyPlan := func(username string) resource.ModifyPlanResponse {
+		plan := buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+			"username":    tftypes.NewValue(tftypes.String, username),
+			"permissions": tftypes.NewValue(tftypes.Number, 16),
+		})
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		res.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configOf(plan), Plan: plan, State: emptyState}, &resp)
+		return resp
+	}
+
+	if resp := modifyPlan("mallory@example.com"); resp.Diagnostics.HasError() {
+		t.Errorf("username checked without check_usernames: %v", resp.Diagnostics)
+	}
+	res.checkUsernames = true
+	resp := modifyPlan("mallory@example.com")
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "terraform import tenablevm_user.<name> 8") {
+		t.Errorf("expected the plan to fail with an import hint, got %v", resp.Diagnostics)
+	}
+	if resp := modifyPlan("nina@example.com"); resp.Diagnostics.HasError() {
+		t.Errorf("unexpected error for a free username: %v", resp.Diagnostics)
+	}
+}
+
+// TestUserResourceRawJSON checks that Read exposes the complete user
+// record, including fields without a dedicated attribute.
+func TestUserResourceRawJSON(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/7":
+			w.Write([]byte(`{"id":7,"uuid":"uuid-7","username":"olivia@example.com","permissions":16,"enabled":true,"container_uuid":"c-1","uuid_id":"u-7"}`))
+		case "/users/7/authorizations":
+			w.Write([]byte(`{"api_permitted":true,"password_permitted":true,"saml_permitted":false}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	res := &userResource{client: newTestClient(ts)}
+	var schResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schResp)
+	state := tfsdk.State{Schema: schResp.Schema, Raw: buildResourcePlan(ctx, schResp.Schema, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "7"),
+		"username": tftypes.NewValue(tftypes.String, "olivia@example.com"),
+	}).Raw}
+	resp := resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	var got userResourceModel
+	resp.State.Get(ctx, &got)
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(got.RawJSON.ValueString()), &raw); err != nil {
+		t.Fatalf("raw_json is not JSON: %v", err)
+	}
+	if raw["container_uuid"] != "c-1" || raw["uuid_id"] != "u-7" {
+		t.Errorf("unexpected raw_json: %s", got.RawJSON)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &userResource{}
+
+// userSchemaVersion is the current version of the tenablevm_user
+// schema.  Bump it together with a new entry in UpgradeState when an
+// attribute is removed, renamed or changes type, so practitioners
+// never have to edit or taint state by hand.  Adding an optional or
+// computed attribute needs no bump: the framework reads it as null
+// from older state and the next refresh fills it in.
+//
+// Version 0 is the original schema; version 1 added
+// password_wo_version, the generated API keys, the authorization flags
+// and role_name.  The timeouts block, uuid, force_password_change,
+// the login details and raw_json were added to version 1 without a
+// bump.
+const userSchemaVersion = 1
+
+// userResourceModelV0 is the state of schema version 0.
+type userResourceModelV0 struct {
+	ID          types.String `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Permissions types.Int64  `tfsdk:"permissions"`
+	Name        types.String `tfsdk:"name"`
+	Email       types.String `tfsdk:"email"`
+	AccountType types.String `tfsdk:"account_type"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+// userSchemaV0 is schema version 0.  Only the attribute types matter
+// for reading old state.
+func userSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true},
+			"username":     schema.StringAttribute{Required: true},
+			"password":     schema.StringAttribute{Optional: true, Sensitive: true, WriteOnly: true},
+			"permissions":  schema.Int64Attribute{Required: true},
+			"name":         schema.StringAttribute{Optional: true},
+			"email":        schema.StringAttribute{Optional: true},
+			"account_type": schema.StringAttribute{Optional: true, Computed: true},
+			"enabled":      schema.BoolAttribute{Optional: true, Computed: true},
+		},
+	}
+}
+
+// UpgradeState converts state written by earlier schema versions.
+func (r *userResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   userSchemaV0(),
+			StateUpgrader: upgradeUserStateV0,
+		},
+	}
+}
+
+// upgradeUserStateV0 carries the version 0 attributes over and fills
+// in the ones added since: no generated keys or forced password
+// change, the role name matching the permissions, and the values
+// Tenable reports left null for the next refresh to read.
+func upgradeUserStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior userResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := userResourceModel{
+		ID:                  prior.ID,
+		UUID:                types.StringNull(),
+		Username:            prior.Username,
+		Password:            types.StringNull(),
+		PasswordWOVersion:   types.Int64Null(),
+		Permissions:         prior.Permissions,
+		RoleName:            roleNameValue(int(prior.Permissions.ValueInt64())),
+		Name:                prior.Name,
+		Email:               prior.Email,
+		AccountType:         prior.AccountType,
+		Enabled:             prior.Enabled,
+		GenerateAPIKeys:     types.BoolValue(false),
+		ForcePasswordChange: types.BoolValue(false),
+		APIAccessKey:        types.StringNull(),
+		APISecretKey:        types.StringNull(),
+		APIPermitted:        types.BoolNull(),
+		PasswordPermitted:   types.BoolNull(),
+		SAMLPermitted:       types.BoolNull(),
+		LastLogin:           types.StringNull(),
+		LoginFailCount:      types.Int64Null(),
+		Lockout:             types.BoolNull(),
+		RawJSON:             types.StringNull(),
+		Timeouts: timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		})},
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
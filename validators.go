@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// emailValidator rejects strings that are not a bare email address,
+// so that typos fail at terraform validate instead of coming back from
+// the API as an opaque 400.  Tenable VM usernames are email addresses
+// too.
+type emailValidator struct{}
+
+var _ validator.String = emailValidator{}
+
+func (v emailValidator) Description(_ context.Context) string {
+	return "value must be an email address such as user@example.com"
+}
+
+func (v emailValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if err := validateEmail(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid email address",
+			fmt.Sprintf("%q is not a valid email address: %s.", value, err),
+		)
+	}
+}
+
+// validateEmail reports why s is not a bare address of the form
+// local@domain.  Display names and angle brackets are rejected because
+// the API expects the address alone.
+func validateEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return errors.New("expected the form user@example.com")
+	}
+	if addr.Name != "" || addr.Address != s {
+		return errors.New("only the address is allowed, without a display name")
+	}
+	domain := s[strings.LastIndex(s, "@")+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return fmt.Errorf("the domain %q is not fully qualified", domain)
+	}
+	return nil
+}
+
+// permissionsValidator rejects permissions values that are not one of
+// the Tenable VM user roles listed in userPermissions.  The error
+// names each role next to its value, so the fix is obvious without
+// looking up the API documentation.
+type permissionsValidator struct{}
+
+var _ validator.Int64 = permissionsValidator{}
+
+func (v permissionsValidator) Description(_ context.Context) string {
+	return "value must be one of " + userPermissionChoices()
+}
+
+func (v permissionsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v permissionsValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueInt64()
+	for _, p := range userPermissions {
+		if p.ID == value {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid permissions value",
+		fmt.Sprintf("%d is not a Tenable VM user role. Use one of %s, or the permission_id function to convert a role name.", value, userPermissionChoices()),
+	)
+}
+
+// userPermissionChoices lists the permissions values with their role
+// names, e.g. "16 (basic), 24 (scan_operator)".
+func userPermissionChoices() string {
+	choices := make([]string, len(userPermissions))
+	for i, p := range userPermissions {
+		choices[i] = fmt.Sprintf("%d (%s)", p.ID, p.Name)
+	}
+	return strings.Join(choices, ", ")
+}
+
+// roleNameValidator rejects role names that are not listed in
+// userPermissions.  Unlike the permission_id function it requires the
+// canonical spelling, because the value is stored in state as given.
+type roleNameValidator struct{}
+
+var _ validator.String = roleNameValidator{}
+
+func (v roleNameValidator) Description(_ context.Context) string {
+	return "value must be one of " + strings.Join(userPermissionNames(), ", ")
+}
+
+func (v roleNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v roleNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	for _, p := range userPermissions {
+		if p.Name == value {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid role name",
+		fmt.Sprintf("%q is not a Tenable VM user role. Use one of %s.", value, strings.Join(userPermissionNames(), ", ")),
+	)
+}
+
+// stringOneOfValidator rejects strings outside a fixed set of values.
+// On a set of strings it checks every element.
+type stringOneOfValidator struct {
+	values []string
+}
+
+var _ validator.String = stringOneOfValidator{}
+var _ validator.Set = stringOneOfValidator{}
+
+// stringOneOf returns a validator accepting exactly the given values.
+func stringOneOf(values ...string) stringOneOfValidator {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return "value must be one of " + strings.Join(v.values, ", ")
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validate(req.Path, req.ConfigValue.ValueString(), &resp.Diagnostics)
+}
+
+func (v stringOneOfValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		v.validate(req.Path.AtSetValue(value), value.ValueString(), &resp.Diagnostics)
+	}
+}
+
+func (v stringOneOfValidator) validate(p path.Path, value string, diags *diag.Diagnostics) {
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+	diags.AddAttributeError(
+		p,
+		"Invalid value",
+		fmt.Sprintf("%q is not allowed. Use one of %s.", value, strings.Join(v.values, ", ")),
+	)
+}
+
+// scanRRuleValidator rejects scan schedule rrules that parseScanRRule
+// does not accept, so that a typo in a schedule fails at plan time
+// rather than producing a scan that never runs.
+type scanRRuleValidator struct{}
+
+var _ validator.String = scanRRuleValidator{}
+
+func (v scanRRuleValidator) Description(_ context.Context) string {
+	return "value must be an rrule such as FREQ=WEEKLY;INTERVAL=1;BYDAY=MO"
+}
+
+func (v scanRRuleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v scanRRuleValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseScanRRule(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid rrule",
+			fmt.Sprintf("%q is not a valid scan rrule: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// scanStartTimeValidator rejects starttime values that are not in the
+// format of the scans API.
+type scanStartTimeValidator struct{}
+
+var _ validator.String = scanStartTimeValidator{}
+
+func (v scanStartTimeValidator) Description(_ context.Context) string {
+	return "value must be a time of the form YYYYMMDDTHHMMSS"
+}
+
+func (v scanStartTimeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v scanStartTimeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseScanStartTime(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid start time", err.Error()+".")
+	}
+}
+
+// timezoneValidator rejects names that are not in the IANA time zone
+// database embedded by function_schedule.go.
+type timezoneValidator struct{}
+
+var _ validator.String = timezoneValidator{}
+
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA time zone name such as Asia/Tokyo"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid timezone",
+			fmt.Sprintf("%q is not an IANA time zone name such as Asia/Tokyo.", value),
+		)
+	}
+}
+
+// jsonValidator rejects values that are not a valid JSON document, so
+// that a malformed request body fails at plan time rather than apply.
+type jsonValidator struct{}
+
+var _ validator.String = jsonValidator{}
+
+func (v jsonValidator) Description(_ context.Context) string {
+	return "value must be a valid JSON document"
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if !json.Valid([]byte(req.ConfigValue.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			fmt.Sprintf("%s must be a valid JSON document.", req.Path),
+		)
+	}
+}
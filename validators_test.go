@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value types.String
+		valid bool
+	}{
+		{types.StringValue("alice@example.com"), true},
+		{types.StringValue("first.last+tf@sub.example.co.jp"), true},
+		{types.StringNull(), true},
+		{types.StringUnknown(), true},
+		{types.StringValue(""), false},
+		{types.StringValue("alice"), false},
+		{types.StringValue("alice@example"), false},
+		{types.StringValue("alice@@example.com"), false},
+		{types.StringValue("Alice <alice@example.com>"), false},
+		{types.StringValue(" alice@example.com"), false},
+	} {
+		req := validator.StringRequest{Path: path.Root("email"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		emailValidator{}.ValidateString(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.value, tc.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestPermissionsValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value types.Int64
+		valid bool
+	}{
+		{types.Int64Value(16), true},
+		{types.Int64Value(64), true},
+		{types.Int64Null(), true},
+		{types.Int64Unknown(), true},
+		{types.Int64Value(0), false},
+		{types.Int64Value(20), false},
+		{types.Int64Value(128), false},
+	} {
+		req := validator.Int64Request{Path: path.Root("permissions"), ConfigValue: tc.value}
+		var resp validator.Int64Response
+		permissionsValidator{}.ValidateInt64(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.value, tc.valid, resp.Diagnostics)
+		}
+		if !tc.valid && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "40 (scan_manager)") {
+			t.Errorf("%s: detail does not list the roles: %s", tc.value, resp.Diagnostics.Errors()[0].Detail())
+		}
+	}
+}
+
+func TestRoleNameValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value types.String
+		valid bool
+	}{
+		{types.StringValue("scan_manager"), true},
+		{types.StringNull(), true},
+		{types.StringValue("Scan Manager"), false},
+		{types.StringValue("root"), false},
+	} {
+		req := validator.StringRequest{Path: path.Root("role_name"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		roleNameValidator{}.ValidateString(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.value, tc.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestStringOneOfValidator(t *testing.T) {
+	ctx := context.Background()
+	v := stringOneOf("local", "saml")
+	for _, tc := range []struct {
+		value types.String
+		valid bool
+	}{
+		{types.StringValue("local"), true},
+		{types.StringValue("saml"), true},
+		{types.StringNull(), true},
+		{types.StringValue("Local"), false},
+		{types.StringValue("sso"), false},
+	} {
+		req := validator.StringRequest{Path: path.Root("account_type"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		v.ValidateString(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.value, tc.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestStringOneOfValidatorSet(t *testing.T) {
+	ctx := context.Background()
+	v := stringOneOf("CanView", "CanScan")
+	set := func(values ...string) types.Set {
+		elements := make([]attr.Value, len(values))
+		for i, value := range values {
+			elements[i] = types.StringValue(value)
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+	for _, tc := range []struct {
+		value types.Set
+		valid bool
+	}{
+		{set("CanView", "CanScan"), true},
+		{set(), true},
+		{types.SetNull(types.StringType), true},
+		{set("CanView", "CanDelete"), false},
+	} {
+		req := validator.SetRequest{Path: path.Root("actions"), ConfigValue: tc.value}
+		var resp validator.SetResponse
+		v.ValidateSet(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.value, tc.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestScanScheduleValidators(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		v     validator.String
+		value types.String
+		valid bool
+	}{
+		{scanRRuleValidator{}, types.StringValue("FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TH"), true},
+		{scanRRuleValidator{}, types.StringValue("freq=monthly;bymonthday=15"), true},
+		{scanRRuleValidator{}, types.StringValue("FREQ=ONETIME"), true},
+		{scanRRuleValidator{}, types.StringUnknown(), true},
+		{scanRRuleValidator{}, types.StringValue("INTERVAL=1"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=HOURLY"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=DAILY;INTERVAL=0"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=DAILY;BYDAY=MO"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=WEEKLY;BYDAY=MONDAY"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=MONTHLY;BYMONTHDAY=32"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=WEEKLY;FREQ=DAILY"), false},
+		{scanRRuleValidator{}, types.StringValue("FREQ=WEEKLY;COUNT=3"), false},
+		{scanStartTimeValidator{}, types.StringValue("20240401T093000"), true},
+		{scanStartTimeValidator{}, types.StringValue("20240401T0930"), true},
+		{scanStartTimeValidator{}, types.StringValue("2024-04-01T09:30"), false},
+		{timezoneValidator{}, types.StringValue("Asia/Tokyo"), true},
+		{timezoneValidator{}, types.StringValue("UTC"), true},
+		{timezoneValidator{}, types.StringValue("Local"), false},
+		{timezoneValidator{}, types.StringValue("Mars/Olympus"), false},
+	} {
+		req := validator.StringRequest{Path: path.Root("schedule"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		tc.v.ValidateString(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%T %s: valid = %t, diagnostics %v", tc.v, tc.value, tc.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestJSONValidator(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		value types.String
+		valid bool
+	}{
+		{types.StringValue(`{"name":"maintenance"}`), true},
+		{types.StringValue(`[]`), true},
+		{types.StringNull(), true},
+		{types.StringUnknown(), true},
+		{types.StringValue(""), false},
+		{types.StringValue(`{"name":`), false},
+		{types.StringValue(`{name: "maintenance"}`), false},
+	} {
+		req := validator.StringRequest{Path: path.Root("body"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		jsonValidator{}.ValidateString(ctx, req, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: valid = %t, diagnostics %v", tc.value, tc.valid, resp.Diagnostics)
+		}
+	}
+}